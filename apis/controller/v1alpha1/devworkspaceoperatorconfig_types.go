@@ -70,6 +70,106 @@ type CleanupCronJobConfig struct {
 	// +kubebuilder:default:="0 0 1 * *"
 	// +kubebuilder:validation:Optional
 	Schedule string `json:"schedule,omitempty"`
+	// OrphanedStorageCleanup configures periodic removal of workspace directories in common PVCs that
+	// no longer belong to any DevWorkspace (e.g. because the DevWorkspace was deleted without its
+	// finalizers running). Orphaned storage cleanup runs on the same schedule as the cleanup cron job,
+	// and can be enabled independently of DevWorkspace pruning.
+	// +kubebuilder:validation:Optional
+	OrphanedStorageCleanup *OrphanedStorageCleanupConfig `json:"orphanedStorageCleanup,omitempty"`
+	// StorageUsage configures periodic collection of the storage used by each DevWorkspace. Storage usage
+	// is collected on the same schedule as the cleanup cron job, and can be enabled independently of
+	// DevWorkspace pruning.
+	// +kubebuilder:validation:Optional
+	StorageUsage *StorageUsageConfig `json:"storageUsage,omitempty"`
+	// Policies define retain times for DevWorkspaces in specific namespaces or with specific labels, which
+	// are used instead of RetainTime. The first policy that matches a DevWorkspace applies; DevWorkspaces
+	// that do not match any policy use RetainTime. DevWorkspaces with the
+	// 'controller.devfile.io/prune-protected: "true"' annotation are never pruned.
+	// +kubebuilder:validation:Optional
+	Policies []PruningPolicy `json:"policies,omitempty"`
+	// MaxStoppedWorkspacesPerUser is the maximum number of stopped DevWorkspaces that are kept for each user,
+	// identified by the 'controller.devfile.io/creator' label. If a user has more stopped DevWorkspaces, the
+	// least recently active DevWorkspaces are pruned, even if they are within their retain time. Protected
+	// DevWorkspaces are not counted. If not specified, the number of stopped DevWorkspaces is not limited.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	MaxStoppedWorkspacesPerUser *int32 `json:"maxStoppedWorkspacesPerUser,omitempty"`
+	// GracePeriod specifies the time (in seconds) between scheduling a DevWorkspace for deletion and deleting it.
+	// When set, DevWorkspaces eligible for pruning are first annotated with the time they will be deleted at
+	// ('controller.devfile.io/scheduled-for-deletion-at'), and are only deleted by a run of the cleanup job after
+	// that time if they are still eligible for pruning. If not specified or 0, eligible DevWorkspaces are deleted
+	// immediately.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	GracePeriod *int32 `json:"gracePeriod,omitempty"`
+	// Notification configures how users are notified when their DevWorkspaces are scheduled for deletion. Only
+	// used when GracePeriod is set.
+	// +kubebuilder:validation:Optional
+	Notification *PruningNotificationConfig `json:"notification,omitempty"`
+	// RequireBackup, if true and the backup cron job is enabled, prevents pruning DevWorkspaces that were not
+	// successfully backed up since they were last stopped. A backup is requested for such DevWorkspaces, and they
+	// are pruned by a later run of the cleanup cron job once the backup succeeded. Defaults to false.
+	// +kubebuilder:validation:Optional
+	RequireBackup *bool `json:"requireBackup,omitempty"`
+}
+
+// PruningNotificationConfig configures notifications about DevWorkspaces scheduled for deletion. An Event is always
+// created for a DevWorkspace that is scheduled for deletion.
+type PruningNotificationConfig struct {
+	// WebhookURL is the URL that a JSON description of each DevWorkspace scheduled for deletion is sent to in an
+	// HTTP POST request. If not specified, no webhook notifications are sent.
+	// +kubebuilder:validation:Optional
+	WebhookURL string `json:"webhookURL,omitempty"`
+}
+
+// PruningPolicy defines the retain time of the DevWorkspaces it matches. A policy matches the DevWorkspaces that
+// are in Namespace, if specified, and match Selector, if specified.
+type PruningPolicy struct {
+	// Namespace is the namespace of the DevWorkspaces this policy applies to. If not specified, the policy
+	// applies to DevWorkspaces in all namespaces.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+	// Selector selects the DevWorkspaces this policy applies to by their labels. If not specified, the policy
+	// applies to all DevWorkspaces in Namespace.
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// RetainTime specifies the minimum time (in seconds) since a DevWorkspace matched by this policy was last
+	// started before it is eligible for pruning.
+	// +kubebuilder:validation:Minimum=0
+	RetainTime int32 `json:"retainTime"`
+}
+
+type OrphanedStorageCleanupConfig struct {
+	// Enable determines whether orphaned workspace directories in common PVCs should be removed.
+	// Defaults to false if not specified.
+	// +kubebuilder:validation:Optional
+	Enable *bool `json:"enable,omitempty"`
+	// DryRun determines whether orphaned storage cleanup should be run in dry-run mode. If set to true,
+	// orphaned directories are logged and reported in metrics, but are not removed.
+	// Defaults to false if not specified.
+	// +kubebuilder:validation:Optional
+	DryRun *bool `json:"dryRun,omitempty"`
+}
+
+type StorageUsageConfig struct {
+	// Enable determines whether the storage usage of DevWorkspaces should be collected. Collected usage is
+	// reported in the 'StorageUsage' condition of each DevWorkspace and in the devworkspace_storage_usage_bytes
+	// metric.
+	// Defaults to false if not specified.
+	// +kubebuilder:validation:Optional
+	Enable *bool `json:"enable,omitempty"`
+	// MinimumUsageForPruning is the minimum amount of storage a DevWorkspace must use to be eligible for
+	// pruning by the cleanup cron job. DevWorkspaces for which no storage usage has been collected yet are
+	// not affected by this setting. Only used when storage usage collection is enabled.
+	// +kubebuilder:validation:Optional
+	MinimumUsageForPruning *resource.Quantity `json:"minimumUsageForPruning,omitempty"`
+	// KubeletStats determines whether the usage of per-workspace PVCs of running DevWorkspaces is read from the
+	// kubelet stats summary of the nodes the DevWorkspaces run on, instead of being computed by a storage usage job.
+	// Reading the kubelet stats summary requires the 'get' permission on 'nodes/proxy', which is not granted to the
+	// DevWorkspace Operator by default and must be granted separately by applying deploy/kubelet-stats-rbac.yaml.
+	// Defaults to false if not specified.
+	// +kubebuilder:validation:Optional
+	KubeletStats *bool `json:"kubeletStats,omitempty"`
 }
 
 type RegistryConfig struct {
@@ -89,8 +189,38 @@ type RegistryConfig struct {
 	AuthSecret string `json:"authSecret,omitempty"`
 }
 
+// S3Config defines a bucket in S3-compatible object storage where backups are stored. Backups are stored
+// under {prefix}/${DEVWORKSPACE_NAMESPACE}/${DEVWORKSPACE_NAME}/ in the bucket.
+type S3Config struct {
+	// Endpoint is the URL of the S3-compatible service, e.g. https://s3.us-east-1.amazonaws.com.
+	// Buckets are accessed using path-style URLs.
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+	// Bucket is the name of the bucket where backups are stored.
+	// +kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+	// Prefix is an optional path prefix for backups in the bucket.
+	// +kubebuilder:validation:Optional
+	Prefix string `json:"prefix,omitempty"`
+	// Region is the region of the bucket. Defaults to us-east-1 if not specified.
+	// +kubebuilder:validation:Optional
+	Region string `json:"region,omitempty"`
+	// CredentialsSecret is the name of a secret that contains the access key ID and secret access key used to
+	// access the bucket, in the "accessKeyId" and "secretAccessKey" keys. The secret is copied from the operator's
+	// namespace to the workspace namespace as "devworkspace-backup-s3-credentials"; a secret with that name in the
+	// workspace namespace takes precedence. If a secret named "<credentialsSecret>-<workspace namespace>" exists in
+	// the operator's namespace, it is copied instead. As the copied credentials are readable in the workspace
+	// namespace, they should only grant access to the "<prefix>/<workspace namespace>/" path of the bucket.
+	// If not specified, the bucket is accessed without authentication.
+	// The secrets must contain the "controller.devfile.io/watch-secret=true" label so that they can be recognized by
+	// the operator.
+	// +kubebuilder:validation:Optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
 type OrasConfig struct {
-	// ExtraArgs are additional arguments passed to the oras CLI
+	// ExtraArgs are additional registry options used when pushing and pulling backups. The supported
+	// options are --insecure, --plain-http and --ca-file <path>; other options are ignored.
 	// +kubebuilder:validation:Optional
 	ExtraArgs string `json:"extraArgs,omitempty"`
 }
@@ -101,8 +231,13 @@ type BackupCronJobConfig struct {
 	// +kubebuilder:validation:Optional
 	Enable *bool `json:"enable,omitempty"`
 	// RegistryConfig defines the registry configuration where backup images are stored.
-	// +kubebuilder:validation:Required
+	// Either Registry or S3 must be specified.
+	// +kubebuilder:validation:Optional
 	Registry *RegistryConfig `json:"registry,omitempty"`
+	// S3 defines S3-compatible object storage where backups are stored, as an alternative to a registry.
+	// If specified, S3 is used instead of Registry.
+	// +kubebuilder:validation:Optional
+	S3 *S3Config `json:"s3,omitempty"`
 	// OrasConfig defines additional configuration options for the oras CLI used to
 	// push and pull backup images.
 	OrasConfig *OrasConfig `json:"oras,omitempty"`
@@ -117,6 +252,111 @@ type BackupCronJobConfig struct {
 	// +kubebuilder:default:=1
 	// +kubebuilder:validation:Optional
 	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// Retention defines which backups of a DevWorkspace are kept in the registry. If not specified,
+	// backups are never removed from the registry.
+	// +kubebuilder:validation:Optional
+	Retention *BackupRetentionConfig `json:"retention,omitempty"`
+	// Encryption defines how backups are encrypted before they are uploaded. If not specified,
+	// backups are not encrypted.
+	// +kubebuilder:validation:Optional
+	Encryption *BackupEncryptionConfig `json:"encryption,omitempty"`
+	// RunningWorkspaces defines whether and how running DevWorkspaces are backed up. If not specified,
+	// only DevWorkspaces that were stopped since their last backup are backed up.
+	// +kubebuilder:validation:Optional
+	RunningWorkspaces *RunningWorkspacesBackupConfig `json:"runningWorkspaces,omitempty"`
+	// Include defines which DevWorkspace data is backed up in addition to the projects. If not specified,
+	// only the projects of DevWorkspaces are backed up.
+	// +kubebuilder:validation:Optional
+	Include *BackupIncludeConfig `json:"include,omitempty"`
+}
+
+// BackupIncludeConfig defines DevWorkspace data that is backed up in addition to the projects. When a DevWorkspace
+// is restored from a backup, this data is restored into the same volumes of the restored DevWorkspace.
+type BackupIncludeConfig struct {
+	// PersistentHome determines whether the persistent home directory of DevWorkspaces is backed up.
+	// Only applies to DevWorkspaces that use a persistent home directory (see config.workspace.persistUserHome).
+	// Defaults to false if not specified.
+	// +kubebuilder:validation:Optional
+	PersistentHome *bool `json:"persistentHome,omitempty"`
+	// Volumes is a list of names of devfile volume components whose data is backed up. Volumes that are not
+	// defined by a DevWorkspace, or that are ephemeral, are ignored.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Volumes []string `json:"volumes,omitempty"`
+}
+
+// RunningWorkspacesBackupConfig defines backups of running DevWorkspaces. To get a consistent backup without
+// stopping the DevWorkspace, a CSI VolumeSnapshot of the DevWorkspace PVC is created and the backup job reads
+// the DevWorkspace data from a temporary PVC restored from the snapshot. Requires VolumeSnapshots to be
+// supported by the cluster and the storage class of DevWorkspace PVCs.
+type RunningWorkspacesBackupConfig struct {
+	// Enable determines whether running DevWorkspaces are backed up on every scheduled backup. Only
+	// DevWorkspaces using per-workspace storage are backed up while running, as the PVC used by common and
+	// per-user storage is shared by all DevWorkspaces in the namespace.
+	// Defaults to false if not specified.
+	// +kubebuilder:validation:Optional
+	Enable *bool `json:"enable,omitempty"`
+	// VolumeSnapshotClassName is the VolumeSnapshotClass used to create snapshots of the PVCs of running
+	// DevWorkspaces. If not specified, config.workspace.volumeSnapshotClassName is used; if that is not
+	// specified either, the cluster's default VolumeSnapshotClass is used.
+	// +kubebuilder:validation:Optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// BackupEncryptionConfig defines the client-side encryption of backups. Backup layers are encrypted before they are
+// uploaded to the registry or object storage, and decrypted by the restore init container.
+type BackupEncryptionConfig struct {
+	// Required determines whether backups must be encrypted. If set to true, backups are not created
+	// unless an encryption key is configured.
+	// Defaults to false if not specified.
+	// +kubebuilder:validation:Optional
+	Required *bool `json:"required,omitempty"`
+	// KeySecret is the name of a secret that contains the key used to encrypt and decrypt backups: either
+	// an age X25519 key pair (as generated by "age-keygen") in the "publicKey" and "privateKey" keys, which is
+	// recommended, or a base64-encoded 32-byte symmetric key in the "key" key. Backups are encrypted with the public
+	// key and can only be restored with the private key. The secret is copied from the operator's namespace to the
+	// workspace namespace as "devworkspace-backup-encryption-key" without the private key; a secret named
+	// "<keySecret>-<namespace>" in the operator's namespace is copied instead, including the private key, if it exists.
+	// A symmetric key is never copied to other namespaces, so it must be stored in a "<keySecret>-<namespace>" secret;
+	// backups fail in namespaces without one.
+	// A secret named "devworkspace-backup-encryption-key" in the workspace namespace takes precedence.
+	// The secret must contain the "controller.devfile.io/watch-secret=true" label so that it can be recognized
+	// by the operator.
+	// +kubebuilder:validation:Optional
+	KeySecret string `json:"keySecret,omitempty"`
+}
+
+// BackupRetentionConfig defines which backups of a DevWorkspace are kept in the registry. After each successful
+// backup, backups that are not kept by any of KeepLast, KeepDaily and KeepWeekly, or that are older than MaxAge,
+// are removed. The most recent backup of a DevWorkspace is never removed.
+type BackupRetentionConfig struct {
+	// KeepLast specifies the number of most recent backups to keep.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+	// KeepDaily specifies the number of days for which the most recent backup of the day is kept.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+	// KeepWeekly specifies the number of weeks for which the most recent backup of the week is kept.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	KeepWeekly *int32 `json:"keepWeekly,omitempty"`
+	// MaxAge specifies the maximum age (in seconds) of a backup. Older backups are removed even if they
+	// would be kept by KeepLast, KeepDaily or KeepWeekly.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	MaxAge *int32 `json:"maxAge,omitempty"`
+	// DeleteOnWorkspaceDeletion determines whether all backups of a DevWorkspace are removed from the
+	// registry when the DevWorkspace is deleted.
+	// Defaults to false if not specified.
+	// +kubebuilder:validation:Optional
+	DeleteOnWorkspaceDeletion *bool `json:"deleteOnWorkspaceDeletion,omitempty"`
+	// DryRun determines whether backups should be pruned in dry-run mode. If set to true, backups that
+	// would have been removed are logged, but are not removed from the registry.
+	// Defaults to false if not specified.
+	// +kubebuilder:validation:Optional
+	DryRun *bool `json:"dryRun,omitempty"`
 }
 
 type RoutingConfig struct {
@@ -200,6 +440,12 @@ type WorkspaceConfig struct {
 	// StorageClassName defines an optional storageClass to use for persistent
 	// volume claims created to support DevWorkspaces
 	StorageClassName *string `json:"storageClassName,omitempty"`
+	// VolumeSnapshotClassName defines an optional VolumeSnapshotClass to use when creating
+	// VolumeSnapshots of DevWorkspace PVCs, e.g. when a DevWorkspace is cloned from another
+	// DevWorkspace using the 'controller.devfile.io/clone-from' attribute. If not specified,
+	// DevWorkspaces are cloned by using the source PVC directly as the data source of the new PVC
+	// (CSI volume cloning).
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
 	// DefaultStorageSize defines an optional struct with fields to specify the sizes of Persistent Volume Claims for storage
 	// classes used by DevWorkspaces.
 	DefaultStorageSize *StorageSizes `json:"defaultStorageSize,omitempty"`
@@ -285,6 +531,86 @@ type WorkspaceConfig struct {
 	// Overrides defines configuration options for `container-overrides` and
 	// `pod-overrides` DevWorkspace attributes.
 	Overrides *OverrideConfig `json:"overrides,omitempty"`
+	// SharedCacheVolumes defines a list of cluster-admin managed volumes (e.g. Maven, npm or Go module
+	// caches) that are automatically mounted read-only into all matching DevWorkspaces.
+	// +kubebuilder:validation:Optional
+	SharedCacheVolumes []SharedCacheVolume `json:"sharedCacheVolumes,omitempty"`
+	// StorageProfiles defines a list of named storage profiles that DevWorkspaces can select using the
+	// 'controller.devfile.io/storage-profile' attribute. A storage profile overrides the storage class,
+	// access modes, default size and volume mode used for the PVCs backing a DevWorkspace. Namespaces
+	// can restrict the profiles available to DevWorkspaces within them using the
+	// 'controller.devfile.io/allowed-storage-profiles' annotation.
+	// +kubebuilder:validation:Optional
+	StorageProfiles []StorageProfile `json:"storageProfiles,omitempty"`
+}
+
+// StorageProfile defines a named set of storage options for PVCs created to support DevWorkspaces.
+// Fields that are not specified fall back to the corresponding global setting.
+type StorageProfile struct {
+	// Name identifies the storage profile. DevWorkspaces select the profile by setting the
+	// 'controller.devfile.io/storage-profile' attribute to this name.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// StorageClassName defines the storageClass to use for PVCs created using this profile.
+	// If not specified, the global storageClassName is used.
+	// +kubebuilder:validation:Optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// StorageAccessMode defines the access modes for PVCs created using this profile.
+	// If not specified, the global storageAccessMode is used.
+	// +kubebuilder:validation:Optional
+	StorageAccessMode []corev1.PersistentVolumeAccessMode `json:"storageAccessMode,omitempty"`
+	// DefaultSize defines the default size of PVCs created using this profile. It takes precedence
+	// over the global and per-namespace default PVC sizes, but not over sizes required by the
+	// volumes in a DevWorkspace.
+	// +kubebuilder:validation:Optional
+	DefaultSize *resource.Quantity `json:"defaultSize,omitempty"`
+	// VolumeMode defines the volumeMode of PVCs created using this profile.
+	// +kubebuilder:validation:Optional
+	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+}
+
+// SharedCacheVolume defines a volume that is shared read-only across DevWorkspaces. Exactly one
+// of PersistentVolumeClaim or Image must be specified.
+type SharedCacheVolume struct {
+	// Name identifies the shared cache volume. The volume is added to workspace pods
+	// with the name `shared-cache-<name>`.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +kubebuilder:validation:MaxLength=50
+	Name string `json:"name"`
+	// MountPath is the path within workspace containers at which the volume should be mounted.
+	// Must not contain ':'.
+	// +kubebuilder:validation:Required
+	MountPath string `json:"mountPath"`
+	// SubPath is an optional path within the volume that should be mounted instead of its root.
+	// +kubebuilder:validation:Optional
+	SubPath string `json:"subPath,omitempty"`
+	// PersistentVolumeClaim refers to a PersistentVolumeClaim with the given name in the namespace of
+	// the DevWorkspace. Typically, this is a ReadWriteMany or ReadOnlyMany claim bound to a volume that
+	// is populated by the cluster administrator. If the claim does not exist in a workspace's namespace,
+	// the volume is not mounted to that workspace.
+	// +kubebuilder:validation:Optional
+	PersistentVolumeClaim *SharedCachePVCSource `json:"persistentVolumeClaim,omitempty"`
+	// Image defines an OCI image or artifact whose contents are mounted as the cache. Requires the
+	// ImageVolume feature to be enabled on the cluster.
+	// +kubebuilder:validation:Optional
+	Image *corev1.ImageVolumeSource `json:"image,omitempty"`
+	// NamespaceSelector restricts the namespaces whose DevWorkspaces receive this volume. If not
+	// specified, DevWorkspaces in all namespaces match.
+	// +kubebuilder:validation:Optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// WorkspaceSelector restricts the DevWorkspaces that receive this volume based on the labels on
+	// the DevWorkspace object. If not specified, all DevWorkspaces match.
+	// +kubebuilder:validation:Optional
+	WorkspaceSelector *metav1.LabelSelector `json:"workspaceSelector,omitempty"`
+}
+
+type SharedCachePVCSource struct {
+	// ClaimName is the name of the PersistentVolumeClaim in the DevWorkspace's namespace.
+	// +kubebuilder:validation:Required
+	ClaimName string `json:"claimName"`
 }
 
 type WebhookConfig struct {
@@ -404,6 +730,103 @@ type ProjectCloneConfig struct {
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 	// Env allows defining additional environment variables for the project clone container.
 	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Parallelism is the maximum number of projects the project clone container sets up
+	// concurrently. Projects with overlapping clone paths are always set up in the order
+	// they are defined. If undefined, projects are set up one at a time. Values above 16
+	// are treated as 16.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	// +kubebuilder:validation:Optional
+	Parallelism *int32 `json:"parallelism,omitempty"`
+	// SSH configures how the project clone container clones projects over SSH. If a
+	// secret named 'git-ssh-key' exists in the workspace's namespace, its private key
+	// (and optional passphrase) are used to authenticate with SSH remotes.
+	// +kubebuilder:validation:Optional
+	SSH *ProjectCloneSSHConfig `json:"ssh,omitempty"`
+	// Depth is the default depth used when cloning git projects. If set to a value greater
+	// than zero, projects are cloned with a history truncated to the specified number of
+	// commits. Can be overridden per project via the 'depth' project attribute. If undefined
+	// or zero, the full history is cloned.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	Depth *int32 `json:"depth,omitempty"`
+	// Filter is the default partial clone filter used when cloning git projects, e.g.
+	// 'blob:none' to download file contents only when they are checked out. Can be overridden
+	// per project via the 'filter' project attribute. See the '--filter' option of 'git clone'
+	// for supported values.
+	// +kubebuilder:validation:Optional
+	Filter string `json:"filter,omitempty"`
+	// SingleBranch defines whether git projects are cloned with only the history of a single
+	// branch by default. Other branches, tags or commits referenced by a project's checkoutFrom
+	// revision are fetched separately. Can be overridden per project via the 'singleBranch'
+	// project attribute. Defaults to false.
+	// +kubebuilder:validation:Optional
+	SingleBranch *bool `json:"singleBranch,omitempty"`
+	// GitCache configures a shared cache of git mirrors that is used to speed up cloning
+	// repositories that are used by many DevWorkspaces in a namespace.
+	// +kubebuilder:validation:Optional
+	GitCache *ProjectCloneGitCacheConfig `json:"gitCache,omitempty"`
+}
+
+type ProjectCloneGitCacheConfig struct {
+	// Enable defines whether the git mirror cache is used. When enabled, the project clone
+	// container of DevWorkspaces in namespaces that contain the cache PVC uses the mirrors
+	// in the PVC as a reference when cloning projects, and the mirrors are refreshed
+	// periodically. Defaults to false.
+	// +kubebuilder:validation:Optional
+	Enable *bool `json:"enable,omitempty"`
+	// ClaimName is the name of the PersistentVolumeClaim that stores git mirrors. The PVC is
+	// not created by the DevWorkspace Operator; the cache is only used in namespaces where a PVC
+	// with this name exists. As the PVC is mounted by all DevWorkspaces in a namespace, it
+	// should support the ReadWriteMany access mode. Defaults to 'git-mirror-cache'.
+	// +kubebuilder:validation:Optional
+	ClaimName string `json:"claimName,omitempty"`
+	// Schedule is the cron schedule on which git mirrors are refreshed. Defaults to every
+	// 4 hours ('0 */4 * * *').
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+	// MinWorkspaces is the minimum number of DevWorkspaces in a namespace that must use a git
+	// repository for the repository to be mirrored. Defaults to 2.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MinWorkspaces *int32 `json:"minWorkspaces,omitempty"`
+	// MaxRepositories is the maximum number of git repositories that are mirrored in a namespace.
+	// If more repositories are eligible, the ones used by the most DevWorkspaces are mirrored.
+	// Defaults to 20.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxRepositories *int32 `json:"maxRepositories,omitempty"`
+}
+
+// ProjectCloneSSHHostKeyPolicy defines how host keys of SSH remotes are verified when cloning projects
+// +kubebuilder:validation:Enum=Strict;AcceptNew;Disabled
+type ProjectCloneSSHHostKeyPolicy string
+
+const (
+	// ProjectCloneSSHHostKeyStrict only allows connecting to hosts with a host key in known_hosts
+	ProjectCloneSSHHostKeyStrict ProjectCloneSSHHostKeyPolicy = "Strict"
+	// ProjectCloneSSHHostKeyAcceptNew allows connecting to hosts that are not in known_hosts, but refuses
+	// to connect to hosts whose host key does not match the one in known_hosts. As known_hosts only contains
+	// the configured entries for every clone, this is equivalent to Disabled for hosts that are not listed.
+	ProjectCloneSSHHostKeyAcceptNew ProjectCloneSSHHostKeyPolicy = "AcceptNew"
+	// ProjectCloneSSHHostKeyDisabled disables host key verification
+	ProjectCloneSSHHostKeyDisabled ProjectCloneSSHHostKeyPolicy = "Disabled"
+)
+
+type ProjectCloneSSHConfig struct {
+	// HostKeyPolicy defines how the host keys of SSH remotes are verified. If set to 'Strict',
+	// only hosts with a host key in known_hosts can be cloned from. If set to 'AcceptNew',
+	// unknown hosts are accepted but mismatched host keys are rejected; as known_hosts only contains
+	// the configured entries, this provides no protection for unlisted hosts, like 'Disabled'. If set to
+	// 'Disabled', host keys are not verified. Defaults to 'Strict' if known_hosts entries are provided
+	// through KnownHosts or the 'known_hosts' key of the 'git-ssh-key' secret, and to 'Disabled' otherwise.
+	// +kubebuilder:validation:Optional
+	HostKeyPolicy ProjectCloneSSHHostKeyPolicy `json:"hostKeyPolicy,omitempty"`
+	// KnownHosts is a list of entries in OpenSSH known_hosts format that are trusted when
+	// cloning projects. Entries from the 'known_hosts' key of the 'git-ssh-key' secret, if
+	// present, are trusted as well.
+	// +kubebuilder:validation:Optional
+	KnownHosts []string `json:"knownHosts,omitempty"`
 }
 
 type RestoreConfig struct {
@@ -431,6 +854,44 @@ type OperatorConfigurationStatus struct {
 	// LastBackupTime is the timestamp of the last successful backup. Nil if
 	// no backup is configured or no backup has yet succeeded.
 	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+	// PruningRuns summarises the most recent runs of DevWorkspace pruning by the cleanup cron job, from newest
+	// to oldest. At most 10 runs are kept.
+	PruningRuns []PruningRunReport `json:"pruningRuns,omitempty"`
+}
+
+// PruningRunReport summarises a run of DevWorkspace pruning by the cleanup cron job.
+type PruningRunReport struct {
+	// StartTime is the time the run started.
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is the time the run completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// DryRun is true if the run was in dry-run mode, in which case no DevWorkspaces were modified or deleted.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Candidates is the number of DevWorkspaces that were eligible for pruning.
+	Candidates int32 `json:"candidates"`
+	// ScheduledForDeletion is the number of DevWorkspaces that were scheduled for deletion after a grace period.
+	ScheduledForDeletion int32 `json:"scheduledForDeletion,omitempty"`
+	// DeletedCount is the number of DevWorkspaces that were deleted. In dry-run mode, the number of DevWorkspaces
+	// that would have been deleted.
+	DeletedCount int32 `json:"deletedCount"`
+	// Deleted lists the DevWorkspaces that were deleted, or would have been deleted in dry-run mode. At most 100
+	// DevWorkspaces are listed.
+	Deleted []PrunedDevWorkspace `json:"deleted,omitempty"`
+	// BackupsRequested is the number of DevWorkspaces for which a backup was requested, as they were not backed
+	// up since they were last stopped.
+	BackupsRequested int32 `json:"backupsRequested,omitempty"`
+	// Skipped is the number of DevWorkspaces that were not deleted, by reason. Possible reasons are 'Started',
+	// 'MissingStartedCondition', 'Protected', 'WithinRetainTime', 'BelowMinimumStorageUsage', 'GracePeriod',
+	// 'DeletionCancelled' and 'BackupPending'.
+	Skipped map[string]int32 `json:"skipped,omitempty"`
+	// Errors lists the errors that occurred during the run.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// PrunedDevWorkspace identifies a DevWorkspace deleted by the cleanup cron job.
+type PrunedDevWorkspace struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
 }
 
 // DevWorkspaceOperatorConfig is the Schema for the devworkspaceoperatorconfigs API
@@ -60,6 +60,11 @@ func (in *BackupCronJobConfig) DeepCopyInto(out *BackupCronJobConfig) {
 		*out = new(RegistryConfig)
 		**out = **in
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3Config)
+		**out = **in
+	}
 	if in.OrasConfig != nil {
 		in, out := &in.OrasConfig, &out.OrasConfig
 		*out = new(OrasConfig)
@@ -70,6 +75,26 @@ func (in *BackupCronJobConfig) DeepCopyInto(out *BackupCronJobConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(BackupRetentionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BackupEncryptionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RunningWorkspaces != nil {
+		in, out := &in.RunningWorkspaces, &out.RunningWorkspaces
+		*out = new(RunningWorkspacesBackupConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = new(BackupIncludeConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupCronJobConfig.
@@ -82,6 +107,96 @@ func (in *BackupCronJobConfig) DeepCopy() *BackupCronJobConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEncryptionConfig) DeepCopyInto(out *BackupEncryptionConfig) {
+	*out = *in
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEncryptionConfig.
+func (in *BackupEncryptionConfig) DeepCopy() *BackupEncryptionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEncryptionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupIncludeConfig) DeepCopyInto(out *BackupIncludeConfig) {
+	*out = *in
+	if in.PersistentHome != nil {
+		in, out := &in.PersistentHome, &out.PersistentHome
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupIncludeConfig.
+func (in *BackupIncludeConfig) DeepCopy() *BackupIncludeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupIncludeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetentionConfig) DeepCopyInto(out *BackupRetentionConfig) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepDaily != nil {
+		in, out := &in.KeepDaily, &out.KeepDaily
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepWeekly != nil {
+		in, out := &in.KeepWeekly, &out.KeepWeekly
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeleteOnWorkspaceDeletion != nil {
+		in, out := &in.DeleteOnWorkspaceDeletion, &out.DeleteOnWorkspaceDeletion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetentionConfig.
+func (in *BackupRetentionConfig) DeepCopy() *BackupRetentionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetentionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanupCronJobConfig) DeepCopyInto(out *CleanupCronJobConfig) {
 	*out = *in
@@ -100,6 +215,43 @@ func (in *CleanupCronJobConfig) DeepCopyInto(out *CleanupCronJobConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.OrphanedStorageCleanup != nil {
+		in, out := &in.OrphanedStorageCleanup, &out.OrphanedStorageCleanup
+		*out = new(OrphanedStorageCleanupConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageUsage != nil {
+		in, out := &in.StorageUsage, &out.StorageUsage
+		*out = new(StorageUsageConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]PruningPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxStoppedWorkspacesPerUser != nil {
+		in, out := &in.MaxStoppedWorkspacesPerUser, &out.MaxStoppedWorkspacesPerUser
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Notification != nil {
+		in, out := &in.Notification, &out.Notification
+		*out = new(PruningNotificationConfig)
+		**out = **in
+	}
+	if in.RequireBackup != nil {
+		in, out := &in.RequireBackup, &out.RequireBackup
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupCronJobConfig.
@@ -488,6 +640,13 @@ func (in *OperatorConfigurationStatus) DeepCopyInto(out *OperatorConfigurationSt
 		in, out := &in.LastBackupTime, &out.LastBackupTime
 		*out = (*in).DeepCopy()
 	}
+	if in.PruningRuns != nil {
+		in, out := &in.PruningRuns, &out.PruningRuns
+		*out = make([]PruningRunReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigurationStatus.
@@ -515,6 +674,31 @@ func (in *OrasConfig) DeepCopy() *OrasConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedStorageCleanupConfig) DeepCopyInto(out *OrphanedStorageCleanupConfig) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedStorageCleanupConfig.
+func (in *OrphanedStorageCleanupConfig) DeepCopy() *OrphanedStorageCleanupConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedStorageCleanupConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OverrideConfig) DeepCopyInto(out *OverrideConfig) {
 	*out = *in
@@ -649,6 +833,31 @@ func (in *ProjectCloneConfig) DeepCopyInto(out *ProjectCloneConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SSH != nil {
+		in, out := &in.SSH, &out.SSH
+		*out = new(ProjectCloneSSHConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Depth != nil {
+		in, out := &in.Depth, &out.Depth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SingleBranch != nil {
+		in, out := &in.SingleBranch, &out.SingleBranch
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GitCache != nil {
+		in, out := &in.GitCache, &out.GitCache
+		*out = new(ProjectCloneGitCacheConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectCloneConfig.
@@ -661,6 +870,56 @@ func (in *ProjectCloneConfig) DeepCopy() *ProjectCloneConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectCloneGitCacheConfig) DeepCopyInto(out *ProjectCloneGitCacheConfig) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinWorkspaces != nil {
+		in, out := &in.MinWorkspaces, &out.MinWorkspaces
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRepositories != nil {
+		in, out := &in.MaxRepositories, &out.MaxRepositories
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectCloneGitCacheConfig.
+func (in *ProjectCloneGitCacheConfig) DeepCopy() *ProjectCloneGitCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectCloneGitCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectCloneSSHConfig) DeepCopyInto(out *ProjectCloneSSHConfig) {
+	*out = *in
+	if in.KnownHosts != nil {
+		in, out := &in.KnownHosts, &out.KnownHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectCloneSSHConfig.
+func (in *ProjectCloneSSHConfig) DeepCopy() *ProjectCloneSSHConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectCloneSSHConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Proxy) DeepCopyInto(out *Proxy) {
 	*out = *in
@@ -691,6 +950,93 @@ func (in *Proxy) DeepCopy() *Proxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrunedDevWorkspace) DeepCopyInto(out *PrunedDevWorkspace) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrunedDevWorkspace.
+func (in *PrunedDevWorkspace) DeepCopy() *PrunedDevWorkspace {
+	if in == nil {
+		return nil
+	}
+	out := new(PrunedDevWorkspace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruningNotificationConfig) DeepCopyInto(out *PruningNotificationConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruningNotificationConfig.
+func (in *PruningNotificationConfig) DeepCopy() *PruningNotificationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PruningNotificationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruningPolicy) DeepCopyInto(out *PruningPolicy) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruningPolicy.
+func (in *PruningPolicy) DeepCopy() *PruningPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PruningPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruningRunReport) DeepCopyInto(out *PruningRunReport) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Deleted != nil {
+		in, out := &in.Deleted, &out.Deleted
+		*out = make([]PrunedDevWorkspace, len(*in))
+		copy(*out, *in)
+	}
+	if in.Skipped != nil {
+		in, out := &in.Skipped, &out.Skipped
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruningRunReport.
+func (in *PruningRunReport) DeepCopy() *PruningRunReport {
+	if in == nil {
+		return nil
+	}
+	out := new(PruningRunReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryConfig) DeepCopyInto(out *RegistryConfig) {
 	*out = *in
@@ -758,6 +1104,46 @@ func (in *RoutingConfig) DeepCopy() *RoutingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunningWorkspacesBackupConfig) DeepCopyInto(out *RunningWorkspacesBackupConfig) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VolumeSnapshotClassName != nil {
+		in, out := &in.VolumeSnapshotClassName, &out.VolumeSnapshotClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunningWorkspacesBackupConfig.
+func (in *RunningWorkspacesBackupConfig) DeepCopy() *RunningWorkspacesBackupConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RunningWorkspacesBackupConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Config) DeepCopyInto(out *S3Config) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Config.
+func (in *S3Config) DeepCopy() *S3Config {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountConfig) DeepCopyInto(out *ServiceAccountConfig) {
 	*out = *in
@@ -798,6 +1184,91 @@ func (in *ServiceAccountToken) DeepCopy() *ServiceAccountToken {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedCachePVCSource) DeepCopyInto(out *SharedCachePVCSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedCachePVCSource.
+func (in *SharedCachePVCSource) DeepCopy() *SharedCachePVCSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedCachePVCSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedCacheVolume) DeepCopyInto(out *SharedCacheVolume) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(SharedCachePVCSource)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(v1.ImageVolumeSource)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkspaceSelector != nil {
+		in, out := &in.WorkspaceSelector, &out.WorkspaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedCacheVolume.
+func (in *SharedCacheVolume) DeepCopy() *SharedCacheVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedCacheVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageProfile) DeepCopyInto(out *StorageProfile) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.StorageAccessMode != nil {
+		in, out := &in.StorageAccessMode, &out.StorageAccessMode
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultSize != nil {
+		in, out := &in.DefaultSize, &out.DefaultSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(v1.PersistentVolumeMode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageProfile.
+func (in *StorageProfile) DeepCopy() *StorageProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageSizes) DeepCopyInto(out *StorageSizes) {
 	*out = *in
@@ -823,6 +1294,36 @@ func (in *StorageSizes) DeepCopy() *StorageSizes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageUsageConfig) DeepCopyInto(out *StorageUsageConfig) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinimumUsageForPruning != nil {
+		in, out := &in.MinimumUsageForPruning, &out.MinimumUsageForPruning
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.KubeletStats != nil {
+		in, out := &in.KubeletStats, &out.KubeletStats
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageUsageConfig.
+func (in *StorageUsageConfig) DeepCopy() *StorageUsageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageUsageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookConfig) DeepCopyInto(out *WebhookConfig) {
 	*out = *in
@@ -880,6 +1381,11 @@ func (in *WorkspaceConfig) DeepCopyInto(out *WorkspaceConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.VolumeSnapshotClassName != nil {
+		in, out := &in.VolumeSnapshotClassName, &out.VolumeSnapshotClassName
+		*out = new(string)
+		**out = **in
+	}
 	if in.DefaultStorageSize != nil {
 		in, out := &in.DefaultStorageSize, &out.DefaultStorageSize
 		*out = new(StorageSizes)
@@ -969,6 +1475,20 @@ func (in *WorkspaceConfig) DeepCopyInto(out *WorkspaceConfig) {
 		*out = new(OverrideConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SharedCacheVolumes != nil {
+		in, out := &in.SharedCacheVolumes, &out.SharedCacheVolumes
+		*out = make([]SharedCacheVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StorageProfiles != nil {
+		in, out := &in.StorageProfiles, &out.StorageProfiles
+		*out = make([]StorageProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceConfig.
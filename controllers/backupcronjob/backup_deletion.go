@@ -0,0 +1,277 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/internal/images"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/infrastructure"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/pkg/secrets"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// shouldDeleteBackupsOnWorkspaceDeletion returns whether backups of a DevWorkspace should be removed from the
+// registry when the DevWorkspace is deleted.
+func shouldDeleteBackupsOnWorkspaceDeletion(backUpConfig *controllerv1alpha1.BackupCronJobConfig) bool {
+	return backUpConfig != nil && backUpConfig.Retention != nil &&
+		backUpConfig.Retention.DeleteOnWorkspaceDeletion != nil && *backUpConfig.Retention.DeleteOnWorkspaceDeletion
+}
+
+// ensureBackupCleanupFinalizer adds the backup cleanup finalizer to a DevWorkspace that is being backed up, if backups
+// should be removed when the DevWorkspace is deleted.
+func (r *BackupCronJobReconciler) ensureBackupCleanupFinalizer(ctx context.Context, workspace *dw.DevWorkspace, backUpConfig *controllerv1alpha1.BackupCronJobConfig) error {
+	if !shouldDeleteBackupsOnWorkspaceDeletion(backUpConfig) || controllerutil.ContainsFinalizer(workspace, constants.BackupCleanupFinalizer) {
+		return nil
+	}
+	origWorkspace := workspace.DeepCopy()
+	controllerutil.AddFinalizer(workspace, constants.BackupCleanupFinalizer)
+	if err := r.Patch(ctx, workspace, client.MergeFromWithOptions(origWorkspace, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("adding backup cleanup finalizer: %w", err)
+	}
+	return nil
+}
+
+func (r *BackupCronJobReconciler) getWorkspaceDeletionPredicate() predicate.Funcs {
+	isDeletingWithFinalizer := func(object client.Object) bool {
+		return object.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(object, constants.BackupCleanupFinalizer)
+	}
+	return predicate.Funcs{
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isDeletingWithFinalizer(e.ObjectNew) },
+		CreateFunc:  func(e event.CreateEvent) bool { return isDeletingWithFinalizer(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+func (r *BackupCronJobReconciler) getWorkspaceDeletionEventHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		workspace, ok := object.(*dw.DevWorkspace)
+		if !ok {
+			return []ctrl.Request{}
+		}
+
+		dwOperatorConfig, err := r.getOperatorConfig(ctx)
+		if err != nil {
+			r.Log.Error(err, "Failed to read DevWorkspaceOperatorConfig")
+			return []ctrl.Request{}
+		}
+
+		if err := r.finalizeBackups(ctx, workspace, dwOperatorConfig); err != nil {
+			r.Log.Error(err, "Failed to remove backups of deleted DevWorkspace", "namespace", workspace.Namespace, "devworkspace", workspace.Name)
+		}
+
+		// Don't enqueue any reconcile requests for the main reconcile loop
+		return []ctrl.Request{}
+	})
+}
+
+// finalizeBackups removes the backups of a DevWorkspace that is being deleted from the backup location by running a backup
+// deletion job. Once the job is finished (see handleBackupDeletionJobStatus), the backup cleanup finalizer is removed.
+// If backups should no longer be removed on DevWorkspace deletion, the finalizer is removed immediately.
+func (r *BackupCronJobReconciler) finalizeBackups(ctx context.Context, workspace *dw.DevWorkspace, dwOperatorConfig *controllerv1alpha1.DevWorkspaceOperatorConfig) error {
+	if !controllerutil.ContainsFinalizer(workspace, constants.BackupCleanupFinalizer) {
+		return nil
+	}
+	var backUpConfig *controllerv1alpha1.BackupCronJobConfig
+	if dwOperatorConfig.Config != nil && dwOperatorConfig.Config.Workspace != nil {
+		backUpConfig = dwOperatorConfig.Config.Workspace.BackupCronJob
+	}
+	if !r.isBackupEnabled(dwOperatorConfig) || !shouldDeleteBackupsOnWorkspaceDeletion(backUpConfig) ||
+		backup.GetBackupLocation(backUpConfig) == "" {
+		r.Log.Info("Removal of backups on DevWorkspace deletion is disabled, keeping backups", "namespace", workspace.Namespace, "devworkspace", workspace.Name)
+		return r.removeBackupCleanupFinalizer(ctx, workspace)
+	}
+
+	jobs := &batchv1.JobList{}
+	err := r.List(ctx, jobs, client.InNamespace(workspace.Namespace), client.MatchingLabels{
+		constants.DevWorkspaceIDLabel:                workspace.Status.DevWorkspaceId,
+		constants.DevWorkspaceBackupDeletionJobLabel: "true",
+	})
+	if err != nil {
+		return err
+	}
+	if len(jobs.Items) > 0 {
+		// Deletion job is already running, or has finished and its status will be handled by the job event handler
+		for _, job := range jobs.Items {
+			if err := r.handleBackupJobStatus(ctx, &job); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return r.createBackupDeletionJob(ctx, workspace, dwOperatorConfig)
+}
+
+// createBackupDeletionJob creates a Kubernetes Job that removes all backups of a DevWorkspace from the backup location.
+func (r *BackupCronJobReconciler) createBackupDeletionJob(ctx context.Context, workspace *dw.DevWorkspace, dwOperatorConfig *controllerv1alpha1.DevWorkspaceOperatorConfig) error {
+	dwID := workspace.Status.DevWorkspaceId
+	backUpConfig := dwOperatorConfig.Config.Workspace.BackupCronJob
+	log := r.Log.WithValues("namespace", workspace.Namespace, "devworkspace", workspace.Name)
+
+	registryAuthSecret, err := secrets.HandleRegistryAuthSecret(ctx, r.Client, workspace, dwOperatorConfig.Config, dwOperatorConfig.Namespace, r.Scheme, log)
+	if err != nil {
+		return fmt.Errorf("handling registry auth secret: %w", err)
+	}
+	s3CredentialsSecret, err := secrets.HandleS3CredentialsSecret(ctx, r.Client, workspace, dwOperatorConfig.Config, dwOperatorConfig.Namespace, log)
+	if err != nil {
+		return fmt.Errorf("handling S3 credentials secret: %w", err)
+	}
+	orasExtraArgs := ""
+	if backUpConfig.OrasConfig != nil {
+		orasExtraArgs = backUpConfig.OrasConfig.ExtraArgs
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: constants.DevWorkspaceBackupDeletionJobNamePrefix,
+			Namespace:    workspace.Namespace,
+			Labels: map[string]string{
+				constants.DevWorkspaceIDLabel:                dwID,
+				constants.DevWorkspaceNameLabel:              workspace.Name,
+				constants.DevWorkspaceBackupDeletionJobLabel: "true",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			TTLSecondsAfterFinished: ptr.To[int32](120),
+			BackoffLimit:            backUpConfig.BackoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						constants.DevWorkspaceIDLabel: dwID,
+					},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: JobRunnerSAName + "-" + dwID,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					SecurityContext:    dwOperatorConfig.Config.Workspace.PodSecurityContext,
+					Containers: []corev1.Container{
+						{
+							Name: "delete-backups",
+							Env: []corev1.EnvVar{
+								{Name: "DEVWORKSPACE_NAME", Value: workspace.Name},
+								{Name: "DEVWORKSPACE_NAMESPACE", Value: workspace.Namespace},
+								{Name: "DEVWORKSPACE_BACKUP_REGISTRY", Value: backup.GetBackupLocation(backUpConfig)},
+								{Name: "ORAS_EXTRA_ARGS", Value: orasExtraArgs},
+							},
+							Image:           images.GetProjectBackupImage(),
+							ImagePullPolicy: getImagePullPolicy(dwOperatorConfig),
+							Args: []string{
+								backup.WorkspaceRecoveryCommand,
+								"--delete",
+							},
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: ptr.To[bool](false),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if backUpConfig.Retention.DryRun != nil && *backUpConfig.Retention.DryRun {
+		job.Spec.Template.Spec.Containers[0].Env = append(job.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: backup.RetentionDryRunEnvVar, Value: "true"})
+	}
+	job.Spec.Template.Spec.Containers[0].Env = append(job.Spec.Template.Spec.Containers[0].Env, backup.GetS3Env(backUpConfig, s3CredentialsSecret)...)
+	addRegistryAuthSecret(job, registryAuthSecret)
+	if err := controllerutil.SetControllerReference(workspace, job, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, job); err != nil {
+		return fmt.Errorf("creating backup deletion job: %w", err)
+	}
+	log.Info("Created job to remove backups of deleted DevWorkspace", "jobName", job.Name)
+	return nil
+}
+
+// handleBackupDeletionJobStatus removes the backup cleanup finalizer from a DevWorkspace once the job removing its
+// backups has finished. Failing to remove backups does not block the deletion of the DevWorkspace.
+func (r *BackupCronJobReconciler) handleBackupDeletionJobStatus(ctx context.Context, job *batchv1.Job, condition batchv1.JobCondition) error {
+	workspace, err := r.getWorkspaceFromJob(ctx, job)
+	if err != nil {
+		return err
+	}
+	if condition.Type == batchv1.JobFailed {
+		r.Log.Error(fmt.Errorf("%s", r.getBackupFailureMessage(ctx, job, condition)), "Failed to remove backups of deleted DevWorkspace",
+			"namespace", workspace.Namespace, "devworkspace", workspace.Name)
+	} else {
+		r.Log.Info("Removed backups of deleted DevWorkspace", "namespace", workspace.Namespace, "devworkspace", workspace.Name)
+	}
+
+	if infrastructure.IsOpenShift() && !isDryRunJob(job) {
+		if err := r.deleteBackupImageStream(ctx, workspace); err != nil {
+			r.Log.Error(err, "Failed to delete backup ImageStream", "namespace", workspace.Namespace, "devworkspace", workspace.Name)
+		}
+	}
+	return r.removeBackupCleanupFinalizer(ctx, workspace)
+}
+
+// isDryRunJob returns whether a backup deletion job was run in dry-run mode.
+func isDryRunJob(job *batchv1.Job) bool {
+	for _, container := range job.Spec.Template.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name == backup.RetentionDryRunEnvVar && env.Value == "true" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deleteBackupImageStream deletes the ImageStream created for backups of a DevWorkspace in the OpenShift internal
+// registry (see ensureImageStreamForBackup).
+func (r *BackupCronJobReconciler) deleteBackupImageStream(ctx context.Context, workspace *dw.DevWorkspace) error {
+	imageStream := &unstructured.Unstructured{}
+	imageStream.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "image.openshift.io",
+		Version: "v1",
+		Kind:    "ImageStream",
+	})
+	err := r.Get(ctx, client.ObjectKey{Name: workspace.Name, Namespace: workspace.Namespace}, imageStream)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if imageStream.GetLabels()[constants.DevWorkspaceIDLabel] != workspace.Status.DevWorkspaceId {
+		// ImageStream was not created for backups of this DevWorkspace
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, imageStream))
+}
+
+func (r *BackupCronJobReconciler) removeBackupCleanupFinalizer(ctx context.Context, workspace *dw.DevWorkspace) error {
+	origWorkspace := workspace.DeepCopy()
+	controllerutil.RemoveFinalizer(workspace, constants.BackupCleanupFinalizer)
+	return r.Patch(ctx, workspace, client.MergeFromWithOptions(origWorkspace, client.MergeFromWithOptimisticLock{}))
+}
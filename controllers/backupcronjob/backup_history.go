@@ -0,0 +1,159 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/conditions"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	backupSucceededReason = "BackupSucceeded"
+	backupFailedReason    = "BackupFailed"
+)
+
+// recordBackupStatus records the outcome of a backup in the BackupSucceeded condition, backup history and backup
+// metrics of a DevWorkspace. If availableBackups is not nil, it replaces the list of backups available for restore.
+func (r *BackupCronJobReconciler) recordBackupStatus(ctx context.Context, workspace *dw.DevWorkspace, entry backup.HistoryEntry,
+	availableBackups []backup.AvailableBackup) error {
+	recordBackupOutcome(workspace.Namespace, entry.Successful, float64(entry.FinishedAt.Unix()))
+
+	if err := r.setBackupCondition(ctx, workspace, entry); err != nil {
+		return fmt.Errorf("failed to update BackupSucceeded condition: %w", err)
+	}
+	if workspace.Status.DevWorkspaceId == "" {
+		// The history ConfigMap is named after the DevWorkspace ID; a DevWorkspace that has never started has no backups
+		return nil
+	}
+	if err := r.addBackupHistoryEntry(ctx, workspace, entry, availableBackups); err != nil {
+		return fmt.Errorf("failed to update backup history: %w", err)
+	}
+	return nil
+}
+
+// setBackupCondition sets the BackupSucceeded condition of a DevWorkspace according to the outcome of its most recent
+// backup.
+func (r *BackupCronJobReconciler) setBackupCondition(ctx context.Context, workspace *dw.DevWorkspace, entry backup.HistoryEntry) error {
+	backupCondition := dw.DevWorkspaceCondition{
+		Type:               conditions.BackupSucceeded,
+		LastTransitionTime: metav1.NewTime(entry.FinishedAt),
+	}
+	if entry.Successful {
+		backupCondition.Status = corev1.ConditionTrue
+		backupCondition.Reason = backupSucceededReason
+		backupCondition.Message = "Backup completed successfully"
+		if entry.Image != "" {
+			backupCondition.Message = fmt.Sprintf("Backup completed successfully: %s@%s", entry.Image, entry.Digest)
+		}
+	} else {
+		backupCondition.Status = corev1.ConditionFalse
+		backupCondition.Reason = backupFailedReason
+		backupCondition.Message = entry.Error
+	}
+
+	var newConditions []dw.DevWorkspaceCondition
+	for _, condition := range workspace.Status.Conditions {
+		if condition.Type != conditions.BackupSucceeded {
+			newConditions = append(newConditions, condition)
+		}
+	}
+	newConditions = append(newConditions, backupCondition)
+
+	// Use optimistic locking, as conditions are also updated by the DevWorkspace controller
+	statusPatch := client.MergeFromWithOptions(workspace.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	workspace.Status.Conditions = newConditions
+	return r.Status().Patch(ctx, workspace, statusPatch)
+}
+
+// addBackupHistoryEntry adds an entry to the backup history of a DevWorkspace, which is stored in a ConfigMap owned by
+// the DevWorkspace. Only the most recent backup.MaxHistoryEntries entries are kept. If availableBackups is not nil, the
+// list of backups available in the registry is updated as well.
+func (r *BackupCronJobReconciler) addBackupHistoryEntry(ctx context.Context, workspace *dw.DevWorkspace, entry backup.HistoryEntry,
+	availableBackups []backup.AvailableBackup) error {
+	cm := &corev1.ConfigMap{}
+	cmKey := client.ObjectKey{Name: common.BackupHistoryConfigMapName(workspace.Status.DevWorkspaceId), Namespace: workspace.Namespace}
+	err := r.Get(ctx, cmKey, cm)
+	switch {
+	case k8sErrors.IsNotFound(err):
+		cm = getBackupHistoryConfigMap(workspace)
+		if err := controllerutil.SetOwnerReference(workspace, cm, r.Scheme); err != nil {
+			return err
+		}
+		if err := setBackupHistory(cm, []backup.HistoryEntry{entry}, availableBackups); err != nil {
+			return err
+		}
+		return r.Create(ctx, cm)
+	case err != nil:
+		return err
+	}
+
+	history, err := backup.ParseHistory(cm.Data[backup.HistoryConfigMapKey])
+	if err != nil {
+		// An invalid history is replaced rather than blocking new entries from being recorded
+		r.Log.Error(err, "Discarding invalid backup history", "namespace", cm.Namespace, "configmap", cm.Name)
+		history = nil
+	}
+	if err := setBackupHistory(cm, backup.AddHistoryEntry(history, entry), availableBackups); err != nil {
+		return err
+	}
+	return r.Update(ctx, cm)
+}
+
+func setBackupHistory(cm *corev1.ConfigMap, history []backup.HistoryEntry, availableBackups []backup.AvailableBackup) error {
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	historyBytes, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	cm.Data[backup.HistoryConfigMapKey] = string(historyBytes)
+	if availableBackups != nil {
+		backupsBytes, err := json.Marshal(availableBackups)
+		if err != nil {
+			return err
+		}
+		cm.Data[backup.AvailableBackupsConfigMapKey] = string(backupsBytes)
+	}
+	return nil
+}
+
+func getBackupHistoryConfigMap(workspace *dw.DevWorkspace) *corev1.ConfigMap {
+	cmLabels := constants.ControllerAppLabels()
+	cmLabels[constants.DevWorkspaceWatchConfigMapLabel] = "true"
+	cmLabels[constants.DevWorkspaceIDLabel] = workspace.Status.DevWorkspaceId
+	cmLabels[constants.DevWorkspaceNameLabel] = workspace.Name
+	cmLabels[constants.DevWorkspaceBackupHistoryLabel] = "true"
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.BackupHistoryConfigMapName(workspace.Status.DevWorkspaceId),
+			Namespace: workspace.Namespace,
+			Labels:    cmLabels,
+		},
+	}
+}
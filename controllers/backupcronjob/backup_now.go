@@ -0,0 +1,135 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func (r *BackupCronJobReconciler) getBackupNowPredicate() predicate.Funcs {
+	isBackupRequested := func(object client.Object) bool {
+		return object.GetDeletionTimestamp() == nil && object.GetAnnotations()[constants.DevWorkspaceBackupNowAnnotation] == "true"
+	}
+	return predicate.Funcs{
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isBackupRequested(e.ObjectNew) },
+		CreateFunc:  func(e event.CreateEvent) bool { return isBackupRequested(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+func (r *BackupCronJobReconciler) getBackupNowEventHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+		workspace, ok := object.(*dw.DevWorkspace)
+		if !ok {
+			return []ctrl.Request{}
+		}
+
+		if err := r.handleBackupNow(ctx, workspace.DeepCopy()); err != nil {
+			r.Log.Error(err, "Failed to handle on-demand backup request", "namespace", workspace.Namespace, "devworkspace", workspace.Name)
+		}
+
+		// Don't enqueue any reconcile requests for the main reconcile loop
+		return []ctrl.Request{}
+	})
+}
+
+// handleBackupNow creates a backup job for a DevWorkspace that requested an on-demand backup through the
+// backup-now annotation, and removes the annotation. If the backup job cannot be created, the failure is recorded
+// in the last-backup-* annotations of the DevWorkspace.
+func (r *BackupCronJobReconciler) handleBackupNow(ctx context.Context, workspace *dw.DevWorkspace) error {
+	log := r.Log.WithValues("namespace", workspace.Namespace, "devworkspace", workspace.Name)
+	log.Info("On-demand backup requested for DevWorkspace")
+
+	dwOperatorConfig, err := r.getOperatorConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	var backupErr error
+	switch {
+	case !r.isBackupEnabled(dwOperatorConfig):
+		backupErr = fmt.Errorf("backups are not enabled in the DevWorkspace Operator configuration")
+	case backup.GetBackupLocation(dwOperatorConfig.Config.Workspace.BackupCronJob) == "":
+		backupErr = fmt.Errorf("backup location is not configured in the DevWorkspace Operator configuration")
+	case workspace.Status.DevWorkspaceId == "":
+		backupErr = fmt.Errorf("DevWorkspace has not been started yet")
+	default:
+		running, err := r.isBackupJobRunning(ctx, workspace)
+		if err != nil {
+			return err
+		}
+		if running {
+			log.Info("Backup job is already running for DevWorkspace, skipping on-demand backup")
+			break
+		}
+		if err := r.ensureJobRunnerRBAC(ctx, workspace); err != nil {
+			backupErr = fmt.Errorf("failed to ensure Job runner RBAC: %w", err)
+		} else if err := r.createBackupJob(workspace, ctx, dwOperatorConfig, log); err != nil {
+			backupErr = fmt.Errorf("failed to create backup job: %w", err)
+		}
+	}
+
+	if backupErr != nil {
+		log.Error(backupErr, "Failed to start on-demand backup for DevWorkspace")
+		condition := batchv1.JobCondition{LastTransitionTime: metav1.Now()}
+		if err := r.recordBackupFailure(ctx, workspace, condition, backupErr.Error()); err != nil {
+			return err
+		}
+	}
+
+	origWorkspace := workspace.DeepCopy()
+	delete(workspace.Annotations, constants.DevWorkspaceBackupNowAnnotation)
+	return r.Patch(ctx, workspace, client.MergeFrom(origWorkspace))
+}
+
+// isBackupJobRunning returns whether a backup job for the DevWorkspace exists and has not finished yet.
+func (r *BackupCronJobReconciler) isBackupJobRunning(ctx context.Context, workspace *dw.DevWorkspace) (bool, error) {
+	jobs := &batchv1.JobList{}
+	err := r.List(ctx, jobs, client.InNamespace(workspace.Namespace), client.MatchingLabels{
+		constants.DevWorkspaceIDLabel:        workspace.Status.DevWorkspaceId,
+		constants.DevWorkspaceBackupJobLabel: "true",
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, job := range jobs.Items {
+		finished := false
+		for _, condition := range job.Status.Conditions {
+			if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+				finished = true
+			}
+		}
+		if !finished {
+			return true, nil
+		}
+	}
+	return false, nil
+}
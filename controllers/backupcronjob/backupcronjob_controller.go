@@ -17,7 +17,12 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path"
 	"reflect"
+	"sort"
+	"strconv"
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -26,18 +31,22 @@ import (
 	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
 	"github.com/devfile/devworkspace-operator/internal/images"
+	"github.com/devfile/devworkspace-operator/pkg/common"
 	"github.com/devfile/devworkspace-operator/pkg/conditions"
 	"github.com/devfile/devworkspace-operator/pkg/config"
 	"github.com/devfile/devworkspace-operator/pkg/constants"
 	"github.com/devfile/devworkspace-operator/pkg/infrastructure"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
 	"github.com/devfile/devworkspace-operator/pkg/library/storage"
 	"github.com/devfile/devworkspace-operator/pkg/secrets"
 	"github.com/go-logr/logr"
 	"github.com/robfig/cron/v3"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -124,15 +133,28 @@ func (r *BackupCronJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			r.getBackupJobEventHandler(),
 			builder.WithPredicates(r.getBackupJobPredicate()),
 		).
+		Watches(
+			&dw.DevWorkspace{},
+			r.getWorkspaceDeletionEventHandler(),
+			builder.WithPredicates(r.getWorkspaceDeletionPredicate()),
+		).
+		Watches(
+			&dw.DevWorkspace{},
+			r.getBackupNowEventHandler(),
+			builder.WithPredicates(r.getBackupNowPredicate()),
+		).
 		Complete(r)
 }
 
-// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;create
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=create
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=serviceaccounts;,verbs=get;list;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;update;patch;delete;watch
 // +kubebuilder:rbac:groups=controller.devfile.io,resources=devworkspaceoperatorconfigs,verbs=get;list;update;patch;watch
 // +kubebuilder:rbac:groups=workspace.devfile.io,resources=devworkspaces,verbs=get;list;update;patch
+// +kubebuilder:rbac:groups=workspace.devfile.io,resources=devworkspaces/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;create;update;patch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=builds,verbs=get
 // +kubebuilder:rbac:groups="",resources=builds/details,verbs=update
@@ -168,6 +190,21 @@ func (r *BackupCronJobReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	return ctrl.Result{}, nil
 }
 
+// getOperatorConfig returns the global DevWorkspaceOperatorConfig. If it does not exist, an empty configuration
+// is returned.
+func (r *BackupCronJobReconciler) getOperatorConfig(ctx context.Context) (*controllerv1alpha1.DevWorkspaceOperatorConfig, error) {
+	operatorNamespace, err := infrastructure.GetNamespace()
+	if err != nil {
+		return nil, err
+	}
+	dwOperatorConfig := &controllerv1alpha1.DevWorkspaceOperatorConfig{}
+	err = r.Get(ctx, client.ObjectKey{Name: config.OperatorConfigName, Namespace: operatorNamespace}, dwOperatorConfig)
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, err
+	}
+	return dwOperatorConfig, nil
+}
+
 // isBackupEnabled checks if the backup cron job is enabled in the configuration.
 func (r *BackupCronJobReconciler) isBackupEnabled(config *controllerv1alpha1.DevWorkspaceOperatorConfig) bool {
 	if config.Config != nil && config.Config.Workspace != nil && config.Config.Workspace.BackupCronJob != nil {
@@ -226,7 +263,7 @@ func (r *BackupCronJobReconciler) stopCron(log logr.Logger) {
 }
 
 // executeBackupSync executes the backup job for all DevWorkspaces in the cluster that
-// have been stopped since their last backup.
+// have been stopped since their last backup and, if enabled, for all running DevWorkspaces.
 func (r *BackupCronJobReconciler) executeBackupSync(ctx context.Context, dwOperatorConfig *controllerv1alpha1.DevWorkspaceOperatorConfig, log logr.Logger) error {
 	log.Info("Executing backup sync for all DevWorkspaces")
 
@@ -240,14 +277,51 @@ func (r *BackupCronJobReconciler) executeBackupSync(ctx context.Context, dwOpera
 	if dwOperatorConfig.Status != nil && dwOperatorConfig.Status.LastBackupTime != nil {
 		lastBackupTime = dwOperatorConfig.Status.LastBackupTime
 	}
+	encryptionErr := backup.ValidateEncryptionConfig(dwOperatorConfig.Config.Workspace.BackupCronJob)
+	if encryptionErr != nil {
+		log.Error(encryptionErr, "Refusing to back up DevWorkspaces")
+	}
 	for _, dw := range devWorkspaces.Items {
-		if !r.wasStoppedSinceLastBackup(&dw, lastBackupTime, log) {
-			log.Info("Skipping backup for DevWorkspace that wasn't stopped recently", "namespace", dw.Namespace, "name", dw.Name)
+		if dw.DeletionTimestamp != nil {
+			// Retry removing backups of deleted DevWorkspaces in case handling the deletion event failed
+			if err := r.finalizeBackups(ctx, &dw, dwOperatorConfig); err != nil {
+				log.Error(err, "Failed to remove backups of deleted DevWorkspace", "namespace", dw.Namespace, "name", dw.Name)
+			}
 			continue
 		}
+		if !r.wasStoppedSinceLastBackup(&dw, lastBackupTime, log) {
+			if !isRunningWorkspaceBackupEnabled(&dw, dwOperatorConfig.Config.Workspace.BackupCronJob) {
+				log.Info("Skipping backup for DevWorkspace that wasn't stopped recently", "namespace", dw.Namespace, "name", dw.Name)
+				continue
+			}
+			if !canBackupFromSnapshot(&dw, dwOperatorConfig.Config) {
+				log.Info("Skipping backup for running DevWorkspace, as only DevWorkspaces using per-workspace storage can be backed up from a VolumeSnapshot",
+					"namespace", dw.Namespace, "name", dw.Name)
+				continue
+			}
+			running, err := r.isBackupJobRunning(ctx, &dw)
+			if err != nil {
+				log.Error(err, "Failed to check for running backup Job for DevWorkspace", "namespace", dw.Namespace, "name", dw.Name)
+				continue
+			}
+			if running {
+				log.Info("Skipping backup for running DevWorkspace, as a backup Job is already running", "namespace", dw.Namespace, "name", dw.Name)
+				continue
+			}
+		}
 		dwID := dw.Status.DevWorkspaceId
 		log.Info("Found DevWorkspace", "namespace", dw.Namespace, "devworkspace", dw.Name, "id", dwID)
 
+		if encryptionErr != nil {
+			// Record the failure so that it is visible on the DevWorkspace and the backup is retried once encryption
+			// is configured
+			condition := batchv1.JobCondition{LastTransitionTime: metav1.Now()}
+			if err := r.recordBackupFailure(ctx, &dw, condition, encryptionErr.Error()); err != nil {
+				log.Error(err, "Failed to record backup failure for DevWorkspace", "id", dwID)
+			}
+			continue
+		}
+
 		err = r.ensureJobRunnerRBAC(ctx, &dw)
 		if err != nil {
 			log.Error(err, "Failed to ensure Job runner RBAC for DevWorkspace", "id", dwID)
@@ -256,6 +330,13 @@ func (r *BackupCronJobReconciler) executeBackupSync(ctx context.Context, dwOpera
 
 		if err = r.createBackupJob(&dw, ctx, dwOperatorConfig, log); err != nil {
 			log.Error(err, "Failed to create backup Job for DevWorkspace", "id", dwID)
+			if errors.Is(err, secrets.ErrSharedSymmetricEncryptionKey) {
+				// The namespace needs its own encryption key, which is recorded so that it is visible on the DevWorkspace
+				condition := batchv1.JobCondition{LastTransitionTime: metav1.Now()}
+				if err := r.recordBackupFailure(ctx, &dw, condition, err.Error()); err != nil {
+					log.Error(err, "Failed to record backup failure for DevWorkspace", "id", dwID)
+				}
+			}
 			continue
 		}
 		log.Info("Backup Job created for DevWorkspace", "id", dwID)
@@ -349,11 +430,30 @@ func (r *BackupCronJobReconciler) createBackupJob(
 	dwID := workspace.Status.DevWorkspaceId
 	backUpConfig := dwOperatorConfig.Config.Workspace.BackupCronJob
 
+	if err := backup.ValidateEncryptionConfig(backUpConfig); err != nil {
+		return err
+	}
+
 	registryAuthSecret, err := secrets.HandleRegistryAuthSecret(ctx, r.Client, workspace, dwOperatorConfig.Config, dwOperatorConfig.Namespace, r.Scheme, log)
 	if err != nil {
 		log.Error(err, "Failed to handle registry auth secret for DevWorkspace", "devworkspace", workspace.Name)
 		return err
 	}
+	s3CredentialsSecret, err := secrets.HandleS3CredentialsSecret(ctx, r.Client, workspace, dwOperatorConfig.Config, dwOperatorConfig.Namespace, log)
+	if err != nil {
+		log.Error(err, "Failed to handle S3 credentials secret for DevWorkspace", "devworkspace", workspace.Name)
+		return err
+	}
+	encryptionKeySecret, err := secrets.HandleEncryptionKeySecret(ctx, r.Client, workspace, dwOperatorConfig.Config, dwOperatorConfig.Namespace, log)
+	if err != nil {
+		log.Error(err, "Failed to handle encryption key secret for DevWorkspace", "devworkspace", workspace.Name)
+		return err
+	}
+	if encryptionKeySecret != nil {
+		if err := backup.ValidateEncryptionKeySecret(encryptionKeySecret); err != nil {
+			return err
+		}
+	}
 
 	// Find a PVC with used by the workspace
 	pvcName, workspacePath, err := storage.GetWorkspacePVCInfo(ctx, workspace, dwOperatorConfig.Config, r.Client, log)
@@ -373,6 +473,17 @@ func (r *BackupCronJobReconciler) createBackupJob(
 		log.Error(err, "Failed to get PVC for DevWorkspace", "id", dwID)
 		return err
 	}
+	// The data of a DevWorkspace that is not stopped may change during the backup, so it is backed up from a snapshot
+	// of its PVC instead
+	claimName := pvc.Name
+	backupFromSnapshot := workspace.Status.Phase != dw.DevWorkspaceStatusStopped && isSnapshotBackupEnabled(backUpConfig)
+	if backupFromSnapshot && !canBackupFromSnapshot(workspace, dwOperatorConfig.Config) {
+		return fmt.Errorf("DevWorkspace is not stopped and does not use %s storage, which is required to back it up from a VolumeSnapshot",
+			constants.PerWorkspaceStorageClassType)
+	}
+	if backupFromSnapshot {
+		claimName = common.BackupSourceVolumeSnapshotName(dwID, utilrand.String(5))
+	}
 	orasExtraArgs := ""
 	if backUpConfig.OrasConfig != nil {
 		orasExtraArgs = backUpConfig.OrasConfig.ExtraArgs
@@ -393,6 +504,11 @@ func (r *BackupCronJobReconciler) createBackupJob(
 			BackoffLimit:            backUpConfig.BackoffLimit,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
+					// The DevWorkspace ID label makes the job's pod visible to the controller's cache, which
+					// is required to read the exit code of failed backups
+					Labels: map[string]string{
+						constants.DevWorkspaceIDLabel: dwID,
+					},
 					Annotations: map[string]string{
 						"io.kubernetes.cri-o.Devices": "/dev/fuse",
 					},
@@ -412,15 +528,16 @@ func (r *BackupCronJobReconciler) createBackupJob(
 									Name:  "BACKUP_SOURCE_PATH",
 									Value: "/workspace/" + workspacePath,
 								},
-								{Name: "DEVWORKSPACE_BACKUP_REGISTRY", Value: backUpConfig.Registry.Path},
+								{Name: "DEVWORKSPACE_BACKUP_REGISTRY", Value: backup.GetBackupLocation(backUpConfig)},
 								{Name: "ORAS_EXTRA_ARGS", Value: orasExtraArgs},
 							},
 							Image:           images.GetProjectBackupImage(),
 							ImagePullPolicy: getImagePullPolicy(dwOperatorConfig),
 							Args: []string{
-								"/workspace-recovery.sh",
+								backup.WorkspaceRecoveryCommand,
 								"--backup",
 							},
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									MountPath: "/workspace",
@@ -441,7 +558,7 @@ func (r *BackupCronJobReconciler) createBackupJob(
 							Name: "workspace-data",
 							VolumeSource: corev1.VolumeSource{
 								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-									ClaimName: pvc.Name,
+									ClaimName: claimName,
 								},
 							},
 						},
@@ -456,38 +573,90 @@ func (r *BackupCronJobReconciler) createBackupJob(
 			},
 		},
 	}
-	if registryAuthSecret != nil {
-		job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, corev1.Volume{
-			Name: constants.RegistryAuthVolumeName,
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: registryAuthSecret.Name,
-				},
-			},
-		})
-		job.Spec.Template.Spec.Containers[0].VolumeMounts = append(job.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
-			Name:      constants.RegistryAuthVolumeName,
-			MountPath: "/tmp/.docker",
-			ReadOnly:  true,
-		})
-		job.Spec.Template.Spec.Containers[0].Env = append(job.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
-			Name:  "REGISTRY_AUTH_FILE",
-			Value: "/tmp/.docker/.dockerconfigjson",
-		})
-
-	}
+	job.Spec.Template.Spec.Containers[0].Env = append(job.Spec.Template.Spec.Containers[0].Env, getRetentionEnv(backUpConfig.Retention)...)
+	job.Spec.Template.Spec.Containers[0].Env = append(job.Spec.Template.Spec.Containers[0].Env, backup.GetS3Env(backUpConfig, s3CredentialsSecret)...)
+	job.Spec.Template.Spec.Containers[0].Env = append(job.Spec.Template.Spec.Containers[0].Env, backup.GetBackupEncryptionEnv(backUpConfig, encryptionKeySecret)...)
+	// The data of devfile volumes is stored next to the projects in the workspace PVC
+	volumesPath := path.Join("/workspace", path.Dir(workspacePath))
+	job.Spec.Template.Spec.Containers[0].Env = append(job.Spec.Template.Spec.Containers[0].Env, backup.GetVolumesEnv(backup.GetIncludedVolumes(backUpConfig), volumesPath)...)
+	addRegistryAuthSecret(job, registryAuthSecret)
 	if err := controllerutil.SetControllerReference(workspace, job, r.Scheme); err != nil {
 		return err
 	}
+	if err := r.ensureBackupCleanupFinalizer(ctx, workspace, backUpConfig); err != nil {
+		return err
+	}
 	err = r.Create(ctx, job)
 	if err != nil {
 		log.Error(err, "Failed to create backup Job for DevWorkspace", "devworkspace", workspace.Name)
 		return err
 	}
+	if backupFromSnapshot {
+		if err := r.createBackupSource(ctx, workspace, job, pvc, claimName, getBackupSnapshotClassName(dwOperatorConfig.Config.Workspace)); err != nil {
+			// Without the PVC restored from the snapshot, the backup job would never start
+			if deleteErr := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); deleteErr != nil {
+				log.Error(deleteErr, "Failed to delete backup Job for DevWorkspace", "jobName", job.Name, "devworkspace", workspace.Name)
+			}
+			return err
+		}
+		log.Info("Created VolumeSnapshot of running DevWorkspace for backup", "snapshotName", claimName, "devworkspace", workspace.Name)
+	}
 	log.Info("Created backup Job for DevWorkspace", "jobName", job.Name, "devworkspace", workspace.Name)
 	return nil
 }
 
+// addRegistryAuthSecret mounts the registry auth secret, if any, into the first container of a job and configures
+// the workspace-recovery binary to use it.
+func addRegistryAuthSecret(job *batchv1.Job, registryAuthSecret *corev1.Secret) {
+	if registryAuthSecret == nil {
+		return
+	}
+	job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: constants.RegistryAuthVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: registryAuthSecret.Name,
+			},
+		},
+	})
+	job.Spec.Template.Spec.Containers[0].VolumeMounts = append(job.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      constants.RegistryAuthVolumeName,
+		MountPath: "/tmp/.docker",
+		ReadOnly:  true,
+	})
+	job.Spec.Template.Spec.Containers[0].Env = append(job.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+		Name:  "REGISTRY_AUTH_FILE",
+		Value: "/tmp/.docker/.dockerconfigjson",
+	})
+}
+
+// getRetentionEnv returns the environment variables used to pass the backup retention policy to the
+// workspace-recovery binary.
+func getRetentionEnv(retention *controllerv1alpha1.BackupRetentionConfig) []corev1.EnvVar {
+	if retention == nil {
+		return nil
+	}
+	var env []corev1.EnvVar
+	for envVar, value := range map[string]*int32{
+		backup.RetentionKeepLastEnvVar:   retention.KeepLast,
+		backup.RetentionKeepDailyEnvVar:  retention.KeepDaily,
+		backup.RetentionKeepWeeklyEnvVar: retention.KeepWeekly,
+		backup.RetentionMaxAgeEnvVar:     retention.MaxAge,
+	} {
+		if value != nil {
+			env = append(env, corev1.EnvVar{Name: envVar, Value: strconv.Itoa(int(*value))})
+		}
+	}
+	// Sort environment variables to avoid depending on map iteration order
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Name < env[j].Name
+	})
+	if retention.DryRun != nil && *retention.DryRun {
+		env = append(env, corev1.EnvVar{Name: backup.RetentionDryRunEnvVar, Value: "true"})
+	}
+	return env
+}
+
 func getImagePullPolicy(dwOperatorConfig *controllerv1alpha1.DevWorkspaceOperatorConfig) corev1.PullPolicy {
 	if dwOperatorConfig.Config.Workspace.ImagePullPolicy != "" {
 		return corev1.PullPolicy(dwOperatorConfig.Config.Workspace.ImagePullPolicy)
@@ -17,16 +17,21 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/robfig/cron/v3"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -39,10 +44,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
 	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
 	"github.com/devfile/devworkspace-operator/pkg/conditions"
 	"github.com/devfile/devworkspace-operator/pkg/constants"
 	"github.com/devfile/devworkspace-operator/pkg/infrastructure"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	storageprovision "github.com/devfile/devworkspace-operator/pkg/provision/storage"
 )
 
 var _ = Describe("BackupCronJobReconciler", func() {
@@ -66,7 +75,7 @@ var _ = Describe("BackupCronJobReconciler", func() {
 		Expect(corev1.AddToScheme(scheme)).To(Succeed())
 		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
 		Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
-		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&controllerv1alpha1.DevWorkspaceOperatorConfig{}).Build()
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&controllerv1alpha1.DevWorkspaceOperatorConfig{}, &dwv2.DevWorkspace{}).Build()
 		log = zap.New(zap.UseDevMode(true)).WithName("BackupCronJobReconcilerTest")
 
 		reconciler = BackupCronJobReconciler{
@@ -748,7 +757,498 @@ var _ = Describe("BackupCronJobReconciler", func() {
 			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
 			Expect(jobList.Items).To(HaveLen(0))
 		})
+		It("refuses to create a Job when encryption is required but no key is configured", func() {
+			dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nameNamespace.Name, Namespace: nameNamespace.Namespace},
+				Config: &controllerv1alpha1.OperatorConfiguration{
+					Workspace: &controllerv1alpha1.WorkspaceConfig{
+						BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+							Enable:     pointer.Bool(true),
+							Schedule:   "* * * * *",
+							Registry:   &controllerv1alpha1.RegistryConfig{Path: "fake-registry"},
+							Encryption: &controllerv1alpha1.BackupEncryptionConfig{Required: pointer.Bool(true)},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, dwoc)).To(Succeed())
+			dw := createDevWorkspace("dw-recent", "ns-a", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.Phase = dwv2.DevWorkspaceStatusStopped
+			dw.Status.DevWorkspaceId = "id-recent"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "claim-devworkspace", Namespace: dw.Namespace}}
+			Expect(fakeClient.Create(ctx, pvc)).To(Succeed())
+
+			Expect(reconciler.executeBackupSync(ctx, dwoc, log)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(BeEmpty())
+
+			updatedDw := &dwv2.DevWorkspace{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, updatedDw)).To(Succeed())
+			Expect(updatedDw.Annotations).To(HaveKeyWithValue(constants.DevWorkspaceLastBackupSuccessfulAnnotation, "false"))
+			Expect(updatedDw.Annotations[constants.DevWorkspaceLastBackupErrorAnnotation]).To(ContainSubstring("backup encryption is required"))
+		})
+		It("creates a Job that encrypts backups with the configured key", func() {
+			dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nameNamespace.Name, Namespace: nameNamespace.Namespace},
+				Config: &controllerv1alpha1.OperatorConfiguration{
+					Workspace: &controllerv1alpha1.WorkspaceConfig{
+						BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+							Enable:   pointer.Bool(true),
+							Schedule: "* * * * *",
+							Registry: &controllerv1alpha1.RegistryConfig{Path: "fake-registry"},
+							Encryption: &controllerv1alpha1.BackupEncryptionConfig{
+								Required:  pointer.Bool(true),
+								KeySecret: "backup-key",
+							},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, dwoc)).To(Succeed())
+			dw := createDevWorkspace("dw-recent", "ns-a", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.Phase = dwv2.DevWorkspaceStatusStopped
+			dw.Status.DevWorkspaceId = "id-recent"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "claim-devworkspace", Namespace: dw.Namespace}}
+			Expect(fakeClient.Create(ctx, pvc)).To(Succeed())
+			keySecret := createAuthSecret("backup-key", nameNamespace.Namespace, map[string][]byte{
+				backup.EncryptionPublicKeySecretKey:  []byte("public"),
+				backup.EncryptionPrivateKeySecretKey: []byte("private"),
+			})
+			Expect(fakeClient.Create(ctx, keySecret)).To(Succeed())
+
+			Expect(reconciler.executeBackupSync(ctx, dwoc, log)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1))
+			env := jobList.Items[0].Spec.Template.Spec.Containers[0].Env
+			Expect(env).To(ContainElement(corev1.EnvVar{Name: backup.EncryptionRequiredEnvVar, Value: "true"}))
+			Expect(env).To(ContainElement(HaveField("Name", backup.EncryptionPublicKeyEnvVar)))
+			Expect(env).NotTo(ContainElement(HaveField("Name", backup.EncryptionPrivateKeyEnvVar)))
+		})
+		It("refuses to create a Job when the shared encryption key secret contains a symmetric key", func() {
+			dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nameNamespace.Name, Namespace: nameNamespace.Namespace},
+				Config: &controllerv1alpha1.OperatorConfiguration{
+					Workspace: &controllerv1alpha1.WorkspaceConfig{
+						BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+							Enable:     pointer.Bool(true),
+							Schedule:   "* * * * *",
+							Registry:   &controllerv1alpha1.RegistryConfig{Path: "fake-registry"},
+							Encryption: &controllerv1alpha1.BackupEncryptionConfig{KeySecret: "backup-key"},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, dwoc)).To(Succeed())
+			dw := createDevWorkspace("dw-recent", "ns-a", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.Phase = dwv2.DevWorkspaceStatusStopped
+			dw.Status.DevWorkspaceId = "id-recent"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "claim-devworkspace", Namespace: dw.Namespace}}
+			Expect(fakeClient.Create(ctx, pvc)).To(Succeed())
+			keySecret := createAuthSecret("backup-key", nameNamespace.Namespace, map[string][]byte{
+				backup.EncryptionKeySecretKey: []byte("c2VjcmV0"),
+			})
+			Expect(fakeClient.Create(ctx, keySecret)).To(Succeed())
+
+			Expect(reconciler.executeBackupSync(ctx, dwoc, log)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(BeEmpty())
+
+			updatedDw := &dwv2.DevWorkspace{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, updatedDw)).To(Succeed())
+			Expect(updatedDw.Annotations).To(HaveKeyWithValue(constants.DevWorkspaceLastBackupSuccessfulAnnotation, "false"))
+			Expect(updatedDw.Annotations[constants.DevWorkspaceLastBackupErrorAnnotation]).To(ContainSubstring("backup-key-ns-a"))
+		})
+		It("creates a Job that stores backups in S3-compatible object storage", func() {
+			dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nameNamespace.Name, Namespace: nameNamespace.Namespace},
+				Config: &controllerv1alpha1.OperatorConfiguration{
+					Workspace: &controllerv1alpha1.WorkspaceConfig{
+						BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+							Enable:   pointer.Bool(true),
+							Schedule: "* * * * *",
+							S3: &controllerv1alpha1.S3Config{
+								Endpoint:          "http://minio.minio.svc:9000",
+								Bucket:            "backups",
+								Prefix:            "cluster-a",
+								CredentialsSecret: "s3-credentials",
+							},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, dwoc)).To(Succeed())
+			dw := createDevWorkspace("dw-recent", "ns-a", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.Phase = dwv2.DevWorkspaceStatusStopped
+			dw.Status.DevWorkspaceId = "id-recent"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "claim-devworkspace", Namespace: dw.Namespace}}
+			Expect(fakeClient.Create(ctx, pvc)).To(Succeed())
+
+			credentialsSecret := createAuthSecret("s3-credentials", nameNamespace.Namespace, map[string][]byte{
+				backup.S3AccessKeyIDSecretKey:     []byte("access-key"),
+				backup.S3SecretAccessKeySecretKey: []byte("secret-key"),
+			})
+			Expect(fakeClient.Create(ctx, credentialsSecret)).To(Succeed())
+
+			Expect(reconciler.executeBackupSync(ctx, dwoc, log)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1))
+			container := jobList.Items[0].Spec.Template.Spec.Containers[0]
+			Expect(container.Env).To(ContainElements(
+				corev1.EnvVar{Name: "DEVWORKSPACE_BACKUP_REGISTRY", Value: "s3://backups/cluster-a"},
+				corev1.EnvVar{Name: backup.S3EndpointEnvVar, Value: "http://minio.minio.svc:9000"},
+			))
+			Expect(container.Env).To(ContainElement(HaveField("ValueFrom.SecretKeyRef.Name", constants.DevWorkspaceBackupS3CredentialsSecretName)))
+			Expect(container.VolumeMounts).NotTo(ContainElement(HaveField("Name", constants.RegistryAuthVolumeName)))
+
+			copiedSecret := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: constants.DevWorkspaceBackupS3CredentialsSecretName, Namespace: dw.Namespace}, copiedSecret)).To(Succeed())
+		})
+
+		It("creates a Job that backs up a running DevWorkspace from a VolumeSnapshot when enabled", func() {
+			dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nameNamespace.Name, Namespace: nameNamespace.Namespace},
+				Config: &controllerv1alpha1.OperatorConfiguration{
+					Workspace: &controllerv1alpha1.WorkspaceConfig{
+						BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+							Enable:   pointer.Bool(true),
+							Schedule: "* * * * *",
+							Registry: &controllerv1alpha1.RegistryConfig{
+								Path: "fake-registry",
+							},
+							RunningWorkspaces: &controllerv1alpha1.RunningWorkspacesBackupConfig{
+								Enable:                  pointer.Bool(true),
+								VolumeSnapshotClassName: pointer.String("csi-snapclass"),
+							},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, dwoc)).To(Succeed())
+			dw := createDevWorkspace("dw-running", "ns-a", true, metav1.NewTime(time.Now().Add(-5*time.Minute)))
+			dw.Spec.Template.Attributes = attributes.Attributes{}.PutString(constants.DevWorkspaceStorageTypeAttribute, constants.PerWorkspaceStorageClassType)
+			dw.Status.Phase = dwv2.DevWorkspaceStatusRunning
+			dw.Status.DevWorkspaceId = "id-running"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "storage-id-running", Namespace: dw.Namespace},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					StorageClassName: pointer.String("csi-storage"),
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, pvc)).To(Succeed())
+
+			Expect(reconciler.executeBackupSync(ctx, dwoc, log)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1))
+			job := jobList.Items[0]
+			claimName := job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName
+			Expect(claimName).To(HavePrefix("id-running-backup-source-"))
+
+			snapshot := storageprovision.NewVolumeSnapshot()
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: claimName, Namespace: dw.Namespace}, snapshot)).To(Succeed())
+			Expect(snapshot.Object["spec"]).To(Equal(map[string]interface{}{
+				"volumeSnapshotClassName": "csi-snapclass",
+				"source": map[string]interface{}{
+					"persistentVolumeClaimName": "storage-id-running",
+				},
+			}))
+			Expect(snapshot.GetOwnerReferences()).To(ContainElement(HaveField("Name", job.Name)))
+
+			sourcePVC := &corev1.PersistentVolumeClaim{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: claimName, Namespace: dw.Namespace}, sourcePVC)).To(Succeed())
+			Expect(sourcePVC.Spec.DataSource).NotTo(BeNil())
+			Expect(sourcePVC.Spec.DataSource.Kind).To(Equal(storageprovision.VolumeSnapshotKind))
+			Expect(sourcePVC.Spec.DataSource.Name).To(Equal(claimName))
+			Expect(sourcePVC.Spec.StorageClassName).To(Equal(pointer.String("csi-storage")))
+			Expect(sourcePVC.Spec.Resources.Requests.Storage().String()).To(Equal("10Gi"))
+			Expect(sourcePVC.GetOwnerReferences()).To(ContainElement(HaveField("Name", job.Name)))
+		})
+
+		It("does not back up a running DevWorkspace that uses common storage from a VolumeSnapshot", func() {
+			dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nameNamespace.Name, Namespace: nameNamespace.Namespace},
+				Config: &controllerv1alpha1.OperatorConfiguration{
+					Workspace: &controllerv1alpha1.WorkspaceConfig{
+						BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+							Enable:   pointer.Bool(true),
+							Schedule: "* * * * *",
+							Registry: &controllerv1alpha1.RegistryConfig{
+								Path: "fake-registry",
+							},
+							RunningWorkspaces: &controllerv1alpha1.RunningWorkspacesBackupConfig{
+								Enable: pointer.Bool(true),
+							},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, dwoc)).To(Succeed())
+			dw := createDevWorkspace("dw-running", "ns-a", true, metav1.NewTime(time.Now().Add(-5*time.Minute)))
+			dw.Status.Phase = dwv2.DevWorkspaceStatusRunning
+			dw.Status.DevWorkspaceId = "id-running"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "claim-devworkspace", Namespace: dw.Namespace}}
+			Expect(fakeClient.Create(ctx, pvc)).To(Succeed())
+
+			Expect(reconciler.executeBackupSync(ctx, dwoc, log)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(BeEmpty())
+			pvcList := &corev1.PersistentVolumeClaimList{}
+			Expect(fakeClient.List(ctx, pvcList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(pvcList.Items).To(HaveLen(1))
+		})
+
+		It("does not create a Job for a running DevWorkspace while a backup Job is running", func() {
+			dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nameNamespace.Name, Namespace: nameNamespace.Namespace},
+				Config: &controllerv1alpha1.OperatorConfiguration{
+					Workspace: &controllerv1alpha1.WorkspaceConfig{
+						BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+							Enable:   pointer.Bool(true),
+							Schedule: "* * * * *",
+							Registry: &controllerv1alpha1.RegistryConfig{
+								Path: "fake-registry",
+							},
+							RunningWorkspaces: &controllerv1alpha1.RunningWorkspacesBackupConfig{
+								Enable: pointer.Bool(true),
+							},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, dwoc)).To(Succeed())
+			dw := createDevWorkspace("dw-running", "ns-a", true, metav1.NewTime(time.Now().Add(-5*time.Minute)))
+			dw.Spec.Template.Attributes = attributes.Attributes{}.PutString(constants.DevWorkspaceStorageTypeAttribute, constants.PerWorkspaceStorageClassType)
+			dw.Status.Phase = dwv2.DevWorkspaceStatusRunning
+			dw.Status.DevWorkspaceId = "id-running"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "storage-id-running", Namespace: dw.Namespace}}
+			Expect(fakeClient.Create(ctx, pvc)).To(Succeed())
+			runningJob := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "devworkspace-backup-running",
+					Namespace: dw.Namespace,
+					Labels: map[string]string{
+						constants.DevWorkspaceIDLabel:        "id-running",
+						constants.DevWorkspaceBackupJobLabel: "true",
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, runningJob)).To(Succeed())
+
+			Expect(reconciler.executeBackupSync(ctx, dwoc, log)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1))
+			Expect(jobList.Items[0].Name).To(Equal("devworkspace-backup-running"))
+		})
+	})
+	Context("backup retention", func() {
+		var dwoc *controllerv1alpha1.DevWorkspaceOperatorConfig
+
+		BeforeEach(func() {
+			dwoc = &controllerv1alpha1.DevWorkspaceOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nameNamespace.Name, Namespace: nameNamespace.Namespace},
+				Config: &controllerv1alpha1.OperatorConfiguration{
+					Workspace: &controllerv1alpha1.WorkspaceConfig{
+						BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+							Enable:   pointer.Bool(true),
+							Schedule: "* * * * *",
+							Registry: &controllerv1alpha1.RegistryConfig{
+								Path: "fake-registry",
+							},
+							Retention: &controllerv1alpha1.BackupRetentionConfig{
+								KeepLast:                  pointer.Int32(3),
+								MaxAge:                    pointer.Int32(86400),
+								DeleteOnWorkspaceDeletion: pointer.Bool(true),
+								DryRun:                    pointer.Bool(true),
+							},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, dwoc)).To(Succeed())
+		})
+
+		It("passes retention policy to backup Job and adds backup cleanup finalizer", func() {
+			dw := createDevWorkspace("dw-retention", "ns-retention", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.DevWorkspaceId = "id-retention"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "claim-devworkspace", Namespace: dw.Namespace}}
+			Expect(fakeClient.Create(ctx, pvc)).To(Succeed())
+
+			Expect(reconciler.executeBackupSync(ctx, dwoc, log)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1))
+			Expect(jobList.Items[0].Spec.Template.Spec.Containers[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: backup.RetentionKeepLastEnvVar, Value: "3"},
+				corev1.EnvVar{Name: backup.RetentionMaxAgeEnvVar, Value: "86400"},
+				corev1.EnvVar{Name: backup.RetentionDryRunEnvVar, Value: "true"},
+			))
+
+			updatedDw := &dwv2.DevWorkspace{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, updatedDw)).To(Succeed())
+			Expect(updatedDw.Finalizers).To(ContainElement(constants.BackupCleanupFinalizer))
+		})
+
+		It("removes backups of deleted DevWorkspace before removing finalizer", func() {
+			dw := createDevWorkspace("dw-deleted", "ns-deleted", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.DevWorkspaceId = "id-deleted"
+			dw.Finalizers = []string{constants.BackupCleanupFinalizer}
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+			Expect(fakeClient.Delete(ctx, dw)).To(Succeed())
+
+			deletingDw := &dwv2.DevWorkspace{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, deletingDw)).To(Succeed())
+			Expect(reconciler.finalizeBackups(ctx, deletingDw, dwoc)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1))
+			job := jobList.Items[0]
+			Expect(job.Labels[constants.DevWorkspaceBackupDeletionJobLabel]).To(Equal("true"))
+			Expect(job.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{backup.WorkspaceRecoveryCommand, "--delete"}))
+			Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: backup.RetentionDryRunEnvVar, Value: "true"}))
+
+			// DevWorkspace deletion is blocked until the job finishes
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, deletingDw)).To(Succeed())
+			Expect(reconciler.finalizeBackups(ctx, deletingDw, dwoc)).To(Succeed())
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1), "backup deletion job should not be created twice")
+
+			job.Status.Conditions = []batchv1.JobCondition{
+				{
+					Type:               batchv1.JobFailed,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Now(),
+					Message:            "Job has reached the specified backoff limit",
+				},
+			}
+			Expect(fakeClient.Status().Update(ctx, &job)).To(Succeed())
+			Expect(reconciler.handleBackupJobStatus(ctx, &job)).To(Succeed())
+
+			err := fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, deletingDw)
+			Expect(k8sErrors.IsNotFound(err)).To(BeTrue(), "DevWorkspace should be deleted once backup deletion job finishes")
+		})
+
+		It("removes finalizer without removing backups when disabled", func() {
+			dwoc.Config.Workspace.BackupCronJob.Retention.DeleteOnWorkspaceDeletion = pointer.Bool(false)
+			dw := createDevWorkspace("dw-keep", "ns-keep", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.DevWorkspaceId = "id-keep"
+			dw.Finalizers = []string{constants.BackupCleanupFinalizer}
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+			Expect(fakeClient.Delete(ctx, dw)).To(Succeed())
+
+			deletingDw := &dwv2.DevWorkspace{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, deletingDw)).To(Succeed())
+			Expect(reconciler.finalizeBackups(ctx, deletingDw, dwoc)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(BeEmpty())
+			err := fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, deletingDw)
+			Expect(k8sErrors.IsNotFound(err)).To(BeTrue())
+		})
 	})
+
+	Context("on-demand backup", func() {
+		BeforeEach(func() {
+			origWatchNamespace := os.Getenv(infrastructure.WatchNamespaceEnvVar)
+			Expect(os.Setenv(infrastructure.WatchNamespaceEnvVar, nameNamespace.Namespace)).To(Succeed())
+			DeferCleanup(os.Setenv, infrastructure.WatchNamespaceEnvVar, origWatchNamespace)
+		})
+
+		It("creates a backup Job and removes the backup-now annotation", func() {
+			dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: nameNamespace.Name, Namespace: nameNamespace.Namespace},
+				Config: &controllerv1alpha1.OperatorConfiguration{
+					Workspace: &controllerv1alpha1.WorkspaceConfig{
+						BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+							Enable:   pointer.Bool(true),
+							Schedule: "0 0 1 * *",
+							Registry: &controllerv1alpha1.RegistryConfig{
+								Path: "fake-registry",
+							},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, dwoc)).To(Succeed())
+			dw := createDevWorkspace("dw-backup-now", "ns-backup-now", true, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.DevWorkspaceId = "id-backup-now"
+			dw.Annotations = map[string]string{constants.DevWorkspaceBackupNowAnnotation: "true"}
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "claim-devworkspace", Namespace: dw.Namespace}}
+			Expect(fakeClient.Create(ctx, pvc)).To(Succeed())
+
+			Expect(reconciler.getBackupNowPredicate().Create(event.CreateEvent{Object: dw})).To(BeTrue())
+			Expect(reconciler.handleBackupNow(ctx, dw)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1))
+			Expect(jobList.Items[0].Labels[constants.DevWorkspaceBackupJobLabel]).To(Equal("true"))
+
+			updatedDw := &dwv2.DevWorkspace{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, updatedDw)).To(Succeed())
+			Expect(updatedDw.Annotations).ToNot(HaveKey(constants.DevWorkspaceBackupNowAnnotation))
+			Expect(reconciler.getBackupNowPredicate().Create(event.CreateEvent{Object: updatedDw})).To(BeFalse())
+
+			// A second request while the backup job is running does not create another job
+			updatedDw.Annotations = map[string]string{}
+			updatedDw.Annotations[constants.DevWorkspaceBackupNowAnnotation] = "true"
+			Expect(reconciler.handleBackupNow(ctx, updatedDw)).To(Succeed())
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1))
+		})
+
+		It("records failure when backups are not enabled", func() {
+			dw := createDevWorkspace("dw-backup-disabled", "ns-backup-disabled", true, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.DevWorkspaceId = "id-backup-disabled"
+			dw.Annotations = map[string]string{constants.DevWorkspaceBackupNowAnnotation: "true"}
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+
+			Expect(reconciler.handleBackupNow(ctx, dw)).To(Succeed())
+
+			jobList := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobList, &client.ListOptions{Namespace: dw.Namespace})).To(Succeed())
+			Expect(jobList.Items).To(BeEmpty())
+
+			updatedDw := &dwv2.DevWorkspace{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, updatedDw)).To(Succeed())
+			Expect(updatedDw.Annotations).ToNot(HaveKey(constants.DevWorkspaceBackupNowAnnotation))
+			Expect(updatedDw.Annotations[constants.DevWorkspaceLastBackupSuccessfulAnnotation]).To(Equal("false"))
+			Expect(updatedDw.Annotations[constants.DevWorkspaceLastBackupErrorAnnotation]).To(ContainSubstring("backups are not enabled"))
+		})
+	})
+
 	Context("ensureJobRunnerRBAC", func() {
 		It("creates ServiceAccount for Job runner", func() {
 			dw := createDevWorkspace("dw-rbac", "ns-rbac", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
@@ -834,6 +1334,149 @@ var _ = Describe("BackupCronJobReconciler", func() {
 			Expect(updatedDw.Annotations).ToNot(HaveKey(constants.DevWorkspaceLastBackupErrorAnnotation))
 		})
 
+		It("records metrics reported by successful backup pod", func() {
+			dw := createDevWorkspace("dw-metrics", "ns-metrics", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.DevWorkspaceId = "id-metrics"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "backup-job-metrics",
+					Namespace: dw.Namespace,
+					Labels: map[string]string{
+						constants.DevWorkspaceIDLabel:        dw.Status.DevWorkspaceId,
+						constants.DevWorkspaceNameLabel:      dw.Name,
+						constants.DevWorkspaceBackupJobLabel: "true",
+					},
+				},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{
+							Type:               batchv1.JobComplete,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.Now(),
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, job)).To(Succeed())
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "backup-job-metrics-pod",
+					Namespace: dw.Namespace,
+					Labels: map[string]string{
+						"job-name":                    job.Name,
+						constants.DevWorkspaceIDLabel: dw.Status.DevWorkspaceId,
+					},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name: "backup-workspace",
+							State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									ExitCode: backup.ExitCodeSuccess,
+									Message:  `{"image":"registry/ns-metrics/dw-metrics:backup-20261018T010000Z","digest":"sha256:abc","size":3000,"uploadedSize":1000,"layers":3,"reusedLayers":2,"durationSeconds":42,"backups":[{"tag":"backup-20261018T010000Z","digest":"sha256:abc"}]}`,
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, pod)).To(Succeed())
+
+			uploadedBytesBefore := testutil.ToFloat64(backupUploadedBytes)
+			reusedLayersBefore := testutil.ToFloat64(backupLayers.WithLabelValues("true"))
+			uploadedLayersBefore := testutil.ToFloat64(backupLayers.WithLabelValues("false"))
+
+			err := reconciler.handleBackupJobStatus(ctx, job)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(testutil.ToFloat64(backupUploadedBytes) - uploadedBytesBefore).To(Equal(float64(1000)))
+			Expect(testutil.ToFloat64(backupLayers.WithLabelValues("true")) - reusedLayersBefore).To(Equal(float64(2)))
+			Expect(testutil.ToFloat64(backupLayers.WithLabelValues("false")) - uploadedLayersBefore).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(backupsTotal.WithLabelValues(dw.Namespace, metricsResultSuccess))).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(backupLastSuccess.WithLabelValues(dw.Namespace))).To(Equal(float64(job.Status.Conditions[0].LastTransitionTime.Unix())))
+			updatedDw := &dwv2.DevWorkspace{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, updatedDw)).To(Succeed())
+			Expect(updatedDw.Annotations[constants.DevWorkspaceLastBackupSuccessfulAnnotation]).To(Equal("true"))
+
+			backupCondition := conditions.GetConditionByType(updatedDw.Status.Conditions, conditions.BackupSucceeded)
+			Expect(backupCondition).ToNot(BeNil())
+			Expect(backupCondition.Status).To(Equal(corev1.ConditionTrue))
+			Expect(backupCondition.Message).To(ContainSubstring("registry/ns-metrics/dw-metrics:backup-20261018T010000Z@sha256:abc"))
+
+			cm := &corev1.ConfigMap{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: common.BackupHistoryConfigMapName(dw.Status.DevWorkspaceId), Namespace: dw.Namespace}, cm)).To(Succeed())
+			Expect(cm.Labels).To(HaveKeyWithValue(constants.DevWorkspaceWatchConfigMapLabel, "true"))
+			Expect(cm.OwnerReferences).To(HaveLen(1))
+			history, err := backup.ParseHistory(cm.Data[backup.HistoryConfigMapKey])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].Successful).To(BeTrue())
+			Expect(history[0].Image).To(Equal("registry/ns-metrics/dw-metrics:backup-20261018T010000Z"))
+			Expect(history[0].Digest).To(Equal("sha256:abc"))
+			Expect(history[0].Size).To(Equal(int64(3000)))
+			Expect(cm.Data[backup.AvailableBackupsConfigMapKey]).To(Equal(`[{"tag":"backup-20261018T010000Z","digest":"sha256:abc"}]`))
+
+			// Handling the same job again, e.g. when it is removed, does not record the backup twice
+			Expect(reconciler.handleBackupJobStatus(ctx, job)).To(Succeed())
+			Expect(testutil.ToFloat64(backupsTotal.WithLabelValues(dw.Namespace, metricsResultSuccess))).To(Equal(float64(1)))
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, cm)).To(Succeed())
+			history, err = backup.ParseHistory(cm.Data[backup.HistoryConfigMapKey])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(1))
+		})
+
+		It("records failed backups in BackupSucceeded condition and bounded backup history", func() {
+			dw := createDevWorkspace("dw-history", "ns-history", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.DevWorkspaceId = "id-history"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+
+			for i := 0; i < backup.MaxHistoryEntries+2; i++ {
+				job := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("backup-job-history-%d", i),
+						Namespace: dw.Namespace,
+						Labels: map[string]string{
+							constants.DevWorkspaceIDLabel:        dw.Status.DevWorkspaceId,
+							constants.DevWorkspaceNameLabel:      dw.Name,
+							constants.DevWorkspaceBackupJobLabel: "true",
+						},
+					},
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{
+								Type:               batchv1.JobFailed,
+								Status:             corev1.ConditionTrue,
+								LastTransitionTime: metav1.NewTime(time.Now().Add(time.Duration(i) * time.Minute)),
+								Message:            fmt.Sprintf("backup %d failed", i),
+							},
+						},
+					},
+				}
+				Expect(fakeClient.Create(ctx, job)).To(Succeed())
+				Expect(reconciler.handleBackupJobStatus(ctx, job)).To(Succeed())
+			}
+
+			Expect(testutil.ToFloat64(backupsTotal.WithLabelValues(dw.Namespace, metricsResultFailure))).To(Equal(float64(backup.MaxHistoryEntries + 2)))
+			updatedDw := &dwv2.DevWorkspace{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, updatedDw)).To(Succeed())
+			backupCondition := conditions.GetConditionByType(updatedDw.Status.Conditions, conditions.BackupSucceeded)
+			Expect(backupCondition).ToNot(BeNil())
+			Expect(backupCondition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(backupCondition.Message).To(Equal(fmt.Sprintf("backup %d failed", backup.MaxHistoryEntries+1)))
+
+			cm := &corev1.ConfigMap{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: common.BackupHistoryConfigMapName(dw.Status.DevWorkspaceId), Namespace: dw.Namespace}, cm)).To(Succeed())
+			history, err := backup.ParseHistory(cm.Data[backup.HistoryConfigMapKey])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(backup.MaxHistoryEntries))
+			Expect(history[0].Error).To(Equal(fmt.Sprintf("backup %d failed", backup.MaxHistoryEntries+1)))
+			Expect(history[backup.MaxHistoryEntries-1].Error).To(Equal("backup 2 failed"))
+		})
+
 		It("updates DevWorkspace annotations on failed backup job", func() {
 			dw := createDevWorkspace("dw-fail", "ns-fail", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
 			dw.Status.DevWorkspaceId = "id-fail"
@@ -874,6 +1517,69 @@ var _ = Describe("BackupCronJobReconciler", func() {
 			Expect(updatedDw.Annotations[constants.DevWorkspaceLastBackupErrorAnnotation]).To(Equal(errorMessage))
 		})
 
+		It("records exit code and termination message of failed backup pod", func() {
+			dw := createDevWorkspace("dw-exit-code", "ns-exit-code", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
+			dw.Status.DevWorkspaceId = "id-exit-code"
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "backup-job-exit-code",
+					Namespace: dw.Namespace,
+					Labels: map[string]string{
+						constants.DevWorkspaceIDLabel:        dw.Status.DevWorkspaceId,
+						constants.DevWorkspaceNameLabel:      dw.Name,
+						constants.DevWorkspaceBackupJobLabel: "true",
+					},
+				},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{
+						{
+							Type:               batchv1.JobFailed,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.Now(),
+							Message:            "Job has reached the specified backoff limit",
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, job)).To(Succeed())
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "backup-job-exit-code-pod",
+					Namespace: dw.Namespace,
+					Labels: map[string]string{
+						"job-name":                    job.Name,
+						constants.DevWorkspaceIDLabel: dw.Status.DevWorkspaceId,
+					},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name: "backup-workspace",
+							State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									ExitCode: backup.ExitCodeAuthenticationFailed,
+									Message:  "unauthorized: authentication required\n",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, pod)).To(Succeed())
+
+			err := reconciler.handleBackupJobStatus(ctx, job)
+			Expect(err).ToNot(HaveOccurred())
+
+			updatedDw := &dwv2.DevWorkspace{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: dw.Name, Namespace: dw.Namespace}, updatedDw)).To(Succeed())
+			Expect(updatedDw.Annotations[constants.DevWorkspaceLastBackupSuccessfulAnnotation]).To(Equal("false"))
+			Expect(updatedDw.Annotations[constants.DevWorkspaceLastBackupErrorAnnotation]).To(
+				Equal("authentication to the backup registry failed: unauthorized: authentication required"))
+		})
+
 		It("truncates error message if it exceeds maximum length", func() {
 			dw := createDevWorkspace("dw-long-error", "ns-long-error", false, metav1.NewTime(time.Now().Add(-10*time.Minute)))
 			dw.Status.DevWorkspaceId = "id-long-error"
@@ -18,10 +18,13 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -42,7 +45,7 @@ func (r *BackupCronJobReconciler) getBackupJobPredicate() predicate.Funcs {
 			}
 
 			// Only reconcile if job related to DevWorkspace backup
-			return job.Labels[constants.DevWorkspaceBackupJobLabel] == "true" &&
+			return (job.Labels[constants.DevWorkspaceBackupJobLabel] == "true" || job.Labels[constants.DevWorkspaceBackupDeletionJobLabel] == "true") &&
 				job.Labels[constants.DevWorkspaceNameLabel] != ""
 		},
 		CreateFunc:  func(e event.CreateEvent) bool { return false },
@@ -78,26 +81,47 @@ func (r *BackupCronJobReconciler) handleBackupJobStatus(ctx context.Context, job
 			continue
 		}
 
+		if job.Labels[constants.DevWorkspaceBackupDeletionJobLabel] == "true" {
+			return r.handleBackupDeletionJobStatus(ctx, job, condition)
+		}
+
 		devWorkspace, err := r.getWorkspaceFromJob(ctx, job)
 		if err != nil {
 			return err
 		}
 
+		if isBackupRecorded(devWorkspace, condition) {
+			// The job was updated after it finished, e.g. when it is removed; its result was already recorded
+			return nil
+		}
+
 		switch condition.Type {
 		case batchv1.JobComplete:
-			return r.recordBackupSuccess(ctx, devWorkspace, condition)
+			result := r.getBackupResult(ctx, job)
+			recordBackupJobMetrics(job, result)
+			return r.recordBackupSuccess(ctx, devWorkspace, condition, result)
 		case batchv1.JobFailed:
-			return r.recordBackupFailure(ctx, devWorkspace, condition)
+			return r.recordBackupFailure(ctx, devWorkspace, condition, r.getBackupFailureMessage(ctx, job, condition))
 		}
 	}
 
 	return nil
 }
 
+// isBackupRecorded returns whether the outcome of a backup that finished with the given job condition is already
+// recorded in the last-backup-* annotations of the DevWorkspace.
+func isBackupRecorded(devWorkspace *dw.DevWorkspace, condition batchv1.JobCondition) bool {
+	return devWorkspace.Annotations[constants.DevWorkspaceLastBackupFinishedAtAnnotation] == condition.LastTransitionTime.Format(time.RFC3339Nano) &&
+		devWorkspace.Annotations[constants.DevWorkspaceLastBackupSuccessfulAnnotation] == strconv.FormatBool(condition.Type == batchv1.JobComplete)
+}
+
+// recordBackupSuccess records a successful backup in the last-backup-* annotations, BackupSucceeded condition and
+// backup history of the DevWorkspace. The result of the backup may be nil if it could not be read from the backup job.
 func (r *BackupCronJobReconciler) recordBackupSuccess(
 	ctx context.Context,
 	devWorkspace *dw.DevWorkspace,
 	condition batchv1.JobCondition,
+	result *backup.Result,
 ) error {
 	origDevWorkspace := devWorkspace.DeepCopy()
 
@@ -109,13 +133,31 @@ func (r *BackupCronJobReconciler) recordBackupSuccess(
 	devWorkspace.Annotations[constants.DevWorkspaceLastBackupFinishedAtAnnotation] = condition.LastTransitionTime.Format(time.RFC3339Nano)
 	delete(devWorkspace.Annotations, constants.DevWorkspaceLastBackupErrorAnnotation)
 
-	return r.Patch(ctx, devWorkspace, client.MergeFrom(origDevWorkspace))
+	if err := r.Patch(ctx, devWorkspace, client.MergeFrom(origDevWorkspace)); err != nil {
+		return err
+	}
+
+	entry := backup.HistoryEntry{
+		FinishedAt: condition.LastTransitionTime.UTC(),
+		Successful: true,
+	}
+	var availableBackups []backup.AvailableBackup
+	if result != nil {
+		entry.Image = result.Image
+		entry.Digest = result.Digest
+		entry.Size = result.Size
+		availableBackups = result.Backups
+	}
+	return r.recordBackupStatus(ctx, devWorkspace, entry, availableBackups)
 }
 
+// recordBackupFailure records a failed backup in the last-backup-* annotations, BackupSucceeded condition and backup
+// history of the DevWorkspace.
 func (r *BackupCronJobReconciler) recordBackupFailure(
 	ctx context.Context,
 	devWorkspace *dw.DevWorkspace,
 	condition batchv1.JobCondition,
+	message string,
 ) error {
 	origDevWorkspace := devWorkspace.DeepCopy()
 
@@ -124,7 +166,7 @@ func (r *BackupCronJobReconciler) recordBackupFailure(
 	}
 
 	// Truncate error message if it's too long (max 1024 chars for annotation values)
-	errorMsg := condition.Message
+	errorMsg := message
 	const maxLength = 1024
 	if len(errorMsg) > maxLength {
 		errorMsg = errorMsg[:maxLength-3] + "..."
@@ -134,7 +176,68 @@ func (r *BackupCronJobReconciler) recordBackupFailure(
 	devWorkspace.Annotations[constants.DevWorkspaceLastBackupFinishedAtAnnotation] = condition.LastTransitionTime.Format(time.RFC3339Nano)
 	devWorkspace.Annotations[constants.DevWorkspaceLastBackupErrorAnnotation] = errorMsg
 
-	return r.Patch(ctx, devWorkspace, client.MergeFrom(origDevWorkspace))
+	if err := r.Patch(ctx, devWorkspace, client.MergeFrom(origDevWorkspace)); err != nil {
+		return err
+	}
+
+	return r.recordBackupStatus(ctx, devWorkspace, backup.HistoryEntry{
+		FinishedAt: condition.LastTransitionTime.UTC(),
+		Successful: false,
+		Error:      errorMsg,
+	}, nil)
+}
+
+// getBackupFailureMessage returns a description of why a backup job failed. If the job's pod terminated with an exit
+// code of the workspace-recovery binary, the message describes the exit code and includes the pod's termination
+// message; otherwise, the message of the job's failed condition is used.
+func (r *BackupCronJobReconciler) getBackupFailureMessage(ctx context.Context, job *batchv1.Job, condition batchv1.JobCondition) string {
+	terminated := r.getTerminatedBackupContainer(ctx, job, false)
+	if terminated == nil {
+		return condition.Message
+	}
+	message := backup.DescribeExitCode(terminated.ExitCode)
+	if terminated.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, strings.TrimSpace(terminated.Message))
+	}
+	return message
+}
+
+// getBackupResult returns the result of a successful backup job, which is reported by the workspace-recovery binary in
+// the termination message of the job's pod. Returns nil if the result is not available.
+func (r *BackupCronJobReconciler) getBackupResult(ctx context.Context, job *batchv1.Job) *backup.Result {
+	terminated := r.getTerminatedBackupContainer(ctx, job, true)
+	if terminated == nil || terminated.Message == "" {
+		return nil
+	}
+	result, err := backup.ParseResult(terminated.Message)
+	if err != nil {
+		r.Log.Error(err, "Failed to read result of backup job", "namespace", job.Namespace, "job", job.Name)
+		return nil
+	}
+	if result.PrunedBackups > 0 {
+		r.Log.Info("Pruned old backups of DevWorkspace", "namespace", job.Namespace, "devworkspace", job.Labels[constants.DevWorkspaceNameLabel],
+			"count", result.PrunedBackups, "dryRun", result.DryRun)
+	}
+	return result
+}
+
+// getTerminatedBackupContainer returns the state of the backup container of a job's pod that terminated successfully
+// (if succeeded is true) or with an error (if succeeded is false). Returns nil if no such container is found.
+func (r *BackupCronJobReconciler) getTerminatedBackupContainer(ctx context.Context, job *batchv1.Job, succeeded bool) *corev1.ContainerStateTerminated {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		r.Log.Error(err, "Failed to list pods for backup job", "namespace", job.Namespace, "job", job.Name)
+		return nil
+	}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if terminated != nil && (terminated.ExitCode == backup.ExitCodeSuccess) == succeeded {
+				return terminated
+			}
+		}
+	}
+	return nil
 }
 
 func (r *BackupCronJobReconciler) getWorkspaceFromJob(
@@ -0,0 +1,134 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+)
+
+const (
+	metricsReusedLabel    = "reused"
+	metricsDryRunLabel    = "dry_run"
+	metricsNamespaceLabel = "namespace"
+	metricsResultLabel    = "result"
+
+	metricsResultSuccess = "success"
+	metricsResultFailure = "failure"
+)
+
+var (
+	backupsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "backups_total",
+			Help:      "Number of finished DevWorkspace backups, by namespace and result",
+		},
+		[]string{metricsNamespaceLabel, metricsResultLabel},
+	)
+	backupLastSuccess = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "devworkspace",
+			Name:      "backup_last_success_timestamp_seconds",
+			Help:      "Time of the most recent successful DevWorkspace backup in each namespace, as a Unix timestamp",
+		},
+		[]string{metricsNamespaceLabel},
+	)
+	backupDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "devworkspace",
+			Name:      "backup_duration_seconds",
+			Help:      "Duration of successful DevWorkspace backups, in seconds, by namespace",
+			Buckets:   prometheus.ExponentialBuckets(10, 2, 10),
+		},
+		[]string{metricsNamespaceLabel},
+	)
+	backupSize = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "devworkspace",
+			Name:      "backup_size_bytes",
+			Help:      "Total size of successful DevWorkspace backups, in bytes",
+			Buckets:   prometheus.ExponentialBuckets(1024*1024, 4, 10),
+		},
+	)
+	backupUploadedBytes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "backup_uploaded_bytes_total",
+			Help:      "Total size of backup layers uploaded to the backup registry, in bytes. Layers that are unchanged since a previous backup are not uploaded",
+		},
+	)
+	backupLayers = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "backup_layers_total",
+			Help:      "Number of layers in successful DevWorkspace backups, by whether the layer was reused from a previous backup",
+		},
+		[]string{metricsReusedLabel},
+	)
+	backupsPruned = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "backups_pruned_total",
+			Help:      "Number of DevWorkspace backups removed from the backup registry by the retention policy. In dry-run mode, the number of backups that would have been removed",
+		},
+		[]string{metricsDryRunLabel},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		backupsTotal,
+		backupLastSuccess,
+		backupDuration,
+		backupSize,
+		backupUploadedBytes,
+		backupLayers,
+		backupsPruned,
+	)
+}
+
+// recordBackupOutcome records a finished backup of a DevWorkspace in the given namespace.
+func recordBackupOutcome(namespace string, successful bool, finishedAt float64) {
+	if !successful {
+		backupsTotal.WithLabelValues(namespace, metricsResultFailure).Inc()
+		return
+	}
+	backupsTotal.WithLabelValues(namespace, metricsResultSuccess).Inc()
+	backupLastSuccess.WithLabelValues(namespace).Set(finishedAt)
+}
+
+// recordBackupJobMetrics records the size and duration of a successful backup job. If the result of the backup is not
+// available, only the duration of the job is recorded.
+func recordBackupJobMetrics(job *batchv1.Job, result *backup.Result) {
+	if result == nil {
+		if job.Status.StartTime != nil && job.Status.CompletionTime != nil {
+			backupDuration.WithLabelValues(job.Namespace).Observe(job.Status.CompletionTime.Sub(job.Status.StartTime.Time).Seconds())
+		}
+		return
+	}
+	backupDuration.WithLabelValues(job.Namespace).Observe(result.DurationSeconds)
+	backupSize.Observe(float64(result.Size))
+	backupUploadedBytes.Add(float64(result.UploadedSize))
+	backupLayers.WithLabelValues("true").Add(float64(result.ReusedLayers))
+	backupLayers.WithLabelValues("false").Add(float64(result.Layers - result.ReusedLayers))
+	backupsPruned.WithLabelValues(strconv.FormatBool(result.DryRun)).Add(float64(result.PrunedBackups))
+}
@@ -0,0 +1,133 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	storageprovision "github.com/devfile/devworkspace-operator/pkg/provision/storage"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// isSnapshotBackupEnabled returns whether DevWorkspaces that are not stopped are backed up from VolumeSnapshots of
+// their PVC.
+func isSnapshotBackupEnabled(backUpConfig *controllerv1alpha1.BackupCronJobConfig) bool {
+	return backUpConfig != nil && backUpConfig.RunningWorkspaces != nil && ptr.Deref(backUpConfig.RunningWorkspaces.Enable, false)
+}
+
+// isRunningWorkspaceBackupEnabled returns whether the DevWorkspace is running and should be backed up on every scheduled
+// backup.
+func isRunningWorkspaceBackupEnabled(workspace *dw.DevWorkspace, backUpConfig *controllerv1alpha1.BackupCronJobConfig) bool {
+	return workspace.Status.Phase == dw.DevWorkspaceStatusRunning && isSnapshotBackupEnabled(backUpConfig)
+}
+
+// canBackupFromSnapshot returns whether the DevWorkspace can be backed up from a VolumeSnapshot of its PVC. Only
+// DevWorkspaces using per-workspace storage can: with common and per-user storage, the PVC is shared by all
+// DevWorkspaces in the namespace, so a snapshot of it would copy the data of every DevWorkspace for each backup.
+func canBackupFromSnapshot(workspace *dw.DevWorkspace, config *controllerv1alpha1.OperatorConfiguration) bool {
+	provisioner, err := storageprovision.GetProvisioner(&common.DevWorkspaceWithConfig{DevWorkspace: workspace, Config: config})
+	if err != nil {
+		return false
+	}
+	_, ok := provisioner.(*storageprovision.PerWorkspaceStorageProvisioner)
+	return ok
+}
+
+// getBackupSnapshotClassName returns the VolumeSnapshotClass used to create snapshots of the PVCs of running DevWorkspaces.
+// An empty string means that the cluster's default VolumeSnapshotClass is used.
+func getBackupSnapshotClassName(workspaceConfig *controllerv1alpha1.WorkspaceConfig) string {
+	if running := workspaceConfig.BackupCronJob.RunningWorkspaces; running != nil && running.VolumeSnapshotClassName != nil {
+		return *running.VolumeSnapshotClassName
+	}
+	if workspaceConfig.VolumeSnapshotClassName != nil {
+		return *workspaceConfig.VolumeSnapshotClassName
+	}
+	return ""
+}
+
+// createBackupSource creates a VolumeSnapshot of the DevWorkspace PVC pvc and a PVC restored from that snapshot, both
+// named name. The restored PVC is mounted by the backup job instead of the PVC of the running DevWorkspace, so that the
+// backup is consistent while the DevWorkspace keeps running. The VolumeSnapshot and the restored PVC are owned by the
+// backup job and are removed together with it.
+func (r *BackupCronJobReconciler) createBackupSource(
+	ctx context.Context,
+	workspace *dw.DevWorkspace,
+	job *batchv1.Job,
+	pvc *corev1.PersistentVolumeClaim,
+	name, snapshotClassName string,
+) error {
+	labels := map[string]string{
+		constants.DevWorkspaceIDLabel:   workspace.Status.DevWorkspaceId,
+		constants.DevWorkspaceNameLabel: workspace.Name,
+	}
+
+	snapshot := storageprovision.GetVolumeSnapshotSpec(name, workspace.Namespace, pvc.Name, snapshotClassName, labels)
+	if err := controllerutil.SetControllerReference(job, snapshot, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, snapshot); err != nil {
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("backing up running DevWorkspaces requires VolumeSnapshots to be supported on the cluster: %w", err)
+		}
+		return fmt.Errorf("failed to create VolumeSnapshot of DevWorkspace PVC: %w", err)
+	}
+
+	// The restored PVC must use the same storage class as the source PVC and be at least as large as the snapshot
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok && capacity.Cmp(size) > 0 {
+		size = capacity
+	}
+	apiGroup := storageprovision.VolumeSnapshotAPIGroup
+	sourcePVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: workspace.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      pvc.Spec.AccessModes,
+			StorageClassName: pvc.Spec.StorageClassName,
+			VolumeMode:       pvc.Spec.VolumeMode,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     storageprovision.VolumeSnapshotKind,
+				Name:     name,
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(job, sourcePVC, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, sourcePVC); err != nil {
+		return fmt.Errorf("failed to create PVC from VolumeSnapshot of DevWorkspace PVC: %w", err)
+	}
+	return nil
+}
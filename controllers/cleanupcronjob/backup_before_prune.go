@@ -0,0 +1,100 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const skipReasonBackupPending = "BackupPending"
+
+// isBackupBeforePruneEnabled returns whether DevWorkspaces must be backed up before they are pruned. This requires
+// the backup cron job to be enabled, as DevWorkspaces could otherwise never be backed up.
+func isBackupBeforePruneEnabled(cleanupConfig *controllerv1alpha1.CleanupCronJobConfig, backupConfig *controllerv1alpha1.BackupCronJobConfig) bool {
+	return cleanupConfig.RequireBackup != nil && *cleanupConfig.RequireBackup &&
+		backupConfig != nil && backupConfig.Enable != nil && *backupConfig.Enable
+}
+
+// filterByBackup filters out DevWorkspaces that were not successfully backed up since they were last stopped, and
+// requests a backup of them through the backup-now annotation so that they can be pruned by a later run. In dry-run
+// mode, no backups are requested.
+func (r *CleanupCronJobReconciler) filterByBackup(ctx context.Context, objs []client.Object, opts *pruneOptions, run *pruningRun, log logr.Logger) []client.Object {
+	var filteredObjs []client.Object
+	for _, obj := range objs {
+		devWorkspace, ok := obj.(*dwv2.DevWorkspace)
+		if !ok {
+			log.Error(nil, fmt.Sprintf("failed to convert %v to DevWorkspace", obj))
+			continue
+		}
+		if hasBackupSinceLastStop(devWorkspace) {
+			filteredObjs = append(filteredObjs, devWorkspace)
+			continue
+		}
+		run.skip(devWorkspace, skipReasonBackupPending)
+		switch {
+		case devWorkspace.Annotations[constants.DevWorkspaceBackupNowAnnotation] == "true":
+			log.Info(fmt.Sprintf("Skipping DevWorkspace '%s/%s': waiting for requested backup", devWorkspace.Namespace, devWorkspace.Name))
+		case opts.dryRun:
+			log.Info(fmt.Sprintf("Dry run mode: skipping DevWorkspace '%s/%s': a backup would be requested as it was not backed up since it was last stopped",
+				devWorkspace.Namespace, devWorkspace.Name))
+			run.backupRequested()
+		default:
+			log.Info(fmt.Sprintf("Skipping DevWorkspace '%s/%s': requesting backup as it was not backed up since it was last stopped",
+				devWorkspace.Namespace, devWorkspace.Name))
+			if err := r.requestBackup(ctx, devWorkspace); err != nil {
+				log.Error(err, fmt.Sprintf("Failed to request backup of DevWorkspace '%s/%s'", devWorkspace.Namespace, devWorkspace.Name))
+				run.addError(fmt.Errorf("failed to request backup of DevWorkspace '%s/%s': %w", devWorkspace.Namespace, devWorkspace.Name, err))
+				continue
+			}
+			run.backupRequested()
+		}
+	}
+	return filteredObjs
+}
+
+// hasBackupSinceLastStop returns whether the last backup of the DevWorkspace succeeded and finished after the
+// DevWorkspace was last stopped, according to the last-backup-* annotations.
+func hasBackupSinceLastStop(dw *dwv2.DevWorkspace) bool {
+	if dw.Annotations[constants.DevWorkspaceLastBackupSuccessfulAnnotation] != "true" {
+		return false
+	}
+	lastBackupFinishedAt, err := time.Parse(time.RFC3339Nano, dw.Annotations[constants.DevWorkspaceLastBackupFinishedAtAnnotation])
+	if err != nil {
+		return false
+	}
+	lastActivity, ok := getLastActivity(dw)
+	if !ok {
+		return false
+	}
+	return lastBackupFinishedAt.After(lastActivity)
+}
+
+// requestBackup requests an on-demand backup of the DevWorkspace through the backup-now annotation, which is handled
+// by the backup cron job controller.
+func (r *CleanupCronJobReconciler) requestBackup(ctx context.Context, dw *dwv2.DevWorkspace) error {
+	origDevWorkspace := dw.DeepCopy()
+	if dw.Annotations == nil {
+		dw.Annotations = map[string]string{}
+	}
+	dw.Annotations[constants.DevWorkspaceBackupNowAnnotation] = "true"
+	return r.Patch(ctx, dw, client.MergeFrom(origDevWorkspace))
+}
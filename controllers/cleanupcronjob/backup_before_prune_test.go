@@ -0,0 +1,154 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+)
+
+var _ = Describe("Backup before pruning", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		reconciler CleanupCronJobReconciler
+		log        logr.Logger
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(controllerv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(dwv2.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		log = zap.New(zap.UseDevMode(true)).WithName("backupBeforePrune")
+		reconciler = CleanupCronJobReconciler{
+			Client: fakeClient,
+			Log:    log,
+			Scheme: scheme,
+		}
+	})
+
+	// createStoppedWorkspace creates a DevWorkspace stopped 2 hours ago. If backupAge is not zero, the DevWorkspace's
+	// last backup finished backupAge ago.
+	createStoppedWorkspace := func(name string, backupAge time.Duration, backupSuccessful bool) *dwv2.DevWorkspace {
+		workspace := createDevWorkspace(name, "test-ns", false, metav1.NewTime(time.Now().Add(-2*time.Hour)))
+		if backupAge != 0 {
+			workspace.Annotations = map[string]string{
+				constants.DevWorkspaceLastBackupFinishedAtAnnotation: time.Now().Add(-backupAge).Format(time.RFC3339Nano),
+				constants.DevWorkspaceLastBackupSuccessfulAnnotation: "false",
+			}
+			if backupSuccessful {
+				workspace.Annotations[constants.DevWorkspaceLastBackupSuccessfulAnnotation] = "true"
+			}
+		}
+		Expect(fakeClient.Create(ctx, workspace)).To(Succeed())
+		return workspace
+	}
+
+	getWorkspace := func(name string) *dwv2.DevWorkspace {
+		workspace := &dwv2.DevWorkspace{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "test-ns"}, workspace)).To(Succeed())
+		return workspace
+	}
+
+	It("Requires backups to be enabled", func() {
+		cleanupConfig := &controllerv1alpha1.CleanupCronJobConfig{RequireBackup: pointer.Bool(true)}
+		Expect(isBackupBeforePruneEnabled(cleanupConfig, nil)).To(BeFalse())
+		Expect(isBackupBeforePruneEnabled(cleanupConfig, &controllerv1alpha1.BackupCronJobConfig{Enable: pointer.Bool(false)})).To(BeFalse())
+		Expect(isBackupBeforePruneEnabled(cleanupConfig, &controllerv1alpha1.BackupCronJobConfig{Enable: pointer.Bool(true)})).To(BeTrue())
+		Expect(isBackupBeforePruneEnabled(&controllerv1alpha1.CleanupCronJobConfig{}, &controllerv1alpha1.BackupCronJobConfig{Enable: pointer.Bool(true)})).To(BeFalse())
+	})
+
+	It("Prunes only DevWorkspaces backed up since they were last stopped", func() {
+		createStoppedWorkspace("backed-up", time.Hour, true)
+		createStoppedWorkspace("backup-before-stop", 3*time.Hour, true)
+		createStoppedWorkspace("backup-failed", time.Hour, false)
+		createStoppedWorkspace("never-backed-up", 0, false)
+		opts := &pruneOptions{retainTime: time.Hour, requireBackup: true}
+
+		Expect(reconciler.pruneDevWorkspaces(ctx, opts, log)).To(Succeed())
+
+		workspaces := &dwv2.DevWorkspaceList{}
+		Expect(fakeClient.List(ctx, workspaces)).To(Succeed())
+		Expect(workspaces.Items).To(HaveLen(3))
+		for _, workspace := range workspaces.Items {
+			Expect(workspace.Name).ToNot(Equal("backed-up"))
+			Expect(workspace.Annotations).To(HaveKeyWithValue(constants.DevWorkspaceBackupNowAnnotation, "true"))
+		}
+	})
+
+	It("Only schedules DevWorkspaces for deletion once they are backed up", func() {
+		createStoppedWorkspace("backed-up", time.Hour, true)
+		createStoppedWorkspace("never-backed-up", 0, false)
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour, requireBackup: true}
+
+		Expect(reconciler.pruneDevWorkspaces(ctx, opts, log)).To(Succeed())
+
+		Expect(getWorkspace("backed-up").Annotations).To(HaveKey(constants.DevWorkspaceScheduledForDeletionAtAnnotation))
+		Expect(getWorkspace("backed-up").Annotations).ToNot(HaveKey(constants.DevWorkspaceBackupNowAnnotation))
+		Expect(getWorkspace("never-backed-up").Annotations).ToNot(HaveKey(constants.DevWorkspaceScheduledForDeletionAtAnnotation))
+		Expect(getWorkspace("never-backed-up").Annotations).ToNot(HaveKey(constants.DevWorkspaceDeletionNotifiedAtAnnotation))
+		Expect(getWorkspace("never-backed-up").Annotations).To(HaveKeyWithValue(constants.DevWorkspaceBackupNowAnnotation, "true"))
+	})
+
+	It("Selects the same DevWorkspaces in dry-run mode without deleting them", func() {
+		createStoppedWorkspace("backed-up", time.Hour, true)
+		createStoppedWorkspace("never-backed-up", 0, false)
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour, requireBackup: true, dryRun: true}
+		run := newPruningRun(true)
+
+		objs := []client.Object{getWorkspace("backed-up"), getWorkspace("never-backed-up")}
+		toDelete, err := reconciler.dryRunPruneStrategy(opts, run, log)(ctx, objs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(toDelete).To(BeEmpty())
+		Expect(run.report(nil).ScheduledForDeletion).To(Equal(int32(1)))
+		Expect(run.report(nil).Skipped).To(Equal(map[string]int32{skipReasonBackupPending: 1, skipReasonGracePeriod: 1}))
+
+		workspaces := &dwv2.DevWorkspaceList{}
+		Expect(fakeClient.List(ctx, workspaces)).To(Succeed())
+		Expect(workspaces.Items).To(HaveLen(2))
+		for _, workspace := range workspaces.Items {
+			Expect(workspace.Annotations).ToNot(HaveKey(constants.DevWorkspaceScheduledForDeletionAtAnnotation))
+			Expect(workspace.Annotations).ToNot(HaveKey(constants.DevWorkspaceBackupNowAnnotation))
+		}
+	})
+
+	It("Does not request backups in dry-run mode", func() {
+		workspace := createStoppedWorkspace("never-backed-up", 0, false)
+		run := newPruningRun(true)
+
+		filtered := reconciler.filterByBackup(ctx, []client.Object{workspace}, &pruneOptions{requireBackup: true, dryRun: true}, run, log)
+		Expect(filtered).To(BeEmpty())
+		Expect(getWorkspace("never-backed-up").Annotations).ToNot(HaveKey(constants.DevWorkspaceBackupNowAnnotation))
+		Expect(run.report(nil).Skipped).To(Equal(map[string]int32{skipReasonBackupPending: 1}))
+		Expect(run.report(nil).BackupsRequested).To(Equal(int32(1)))
+	})
+})
@@ -28,8 +28,11 @@ import (
 	"github.com/devfile/devworkspace-operator/pkg/config"
 	"github.com/devfile/devworkspace-operator/pkg/infrastructure"
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -47,8 +50,11 @@ import (
 // CleanupCronJobReconciler reconciles `CleanupCronJob` configuration for the purpose of pruning stale DevWorkspaces.
 type CleanupCronJobReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	NonCachingClient client.Client
+	NodeStatsClient  NodeStatsClient
+	Log              logr.Logger
+	Scheme           *runtime.Scheme
+	Recorder         events.EventRecorder
 
 	cron *cron.Cron
 }
@@ -103,6 +109,47 @@ func shouldReconcileOnUpdate(e event.UpdateEvent, log logr.Logger) bool {
 	if differentInt32(oldCleanup.RetainTime, newCleanup.RetainTime) {
 		return true
 	}
+	oldOrphaned := oldCleanup.OrphanedStorageCleanup
+	newOrphaned := newCleanup.OrphanedStorageCleanup
+	if (oldOrphaned == nil) != (newOrphaned == nil) {
+		return true
+	}
+	if oldOrphaned != nil && newOrphaned != nil {
+		if differentBool(oldOrphaned.Enable, newOrphaned.Enable) {
+			return true
+		}
+		if differentBool(oldOrphaned.DryRun, newOrphaned.DryRun) {
+			return true
+		}
+	}
+	oldStorageUsage := oldCleanup.StorageUsage
+	newStorageUsage := newCleanup.StorageUsage
+	if (oldStorageUsage == nil) != (newStorageUsage == nil) {
+		return true
+	}
+	if oldStorageUsage != nil && newStorageUsage != nil {
+		if differentBool(oldStorageUsage.Enable, newStorageUsage.Enable) {
+			return true
+		}
+		if !equality.Semantic.DeepEqual(oldStorageUsage.MinimumUsageForPruning, newStorageUsage.MinimumUsageForPruning) {
+			return true
+		}
+	}
+	if !equality.Semantic.DeepEqual(oldCleanup.Policies, newCleanup.Policies) {
+		return true
+	}
+	if differentInt32(oldCleanup.MaxStoppedWorkspacesPerUser, newCleanup.MaxStoppedWorkspacesPerUser) {
+		return true
+	}
+	if differentInt32(oldCleanup.GracePeriod, newCleanup.GracePeriod) {
+		return true
+	}
+	if !equality.Semantic.DeepEqual(oldCleanup.Notification, newCleanup.Notification) {
+		return true
+	}
+	if differentBool(oldCleanup.RequireBackup, newCleanup.RequireBackup) {
+		return true
+	}
 	return oldCleanup.Schedule != newCleanup.Schedule
 }
 
@@ -151,8 +198,11 @@ func (r *CleanupCronJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-// +kubebuilder:rbac:groups=workspace.devfile.io,resources=devworkspaces,verbs=get;list;delete
+// +kubebuilder:rbac:groups=workspace.devfile.io,resources=devworkspaces,verbs=get;list;patch;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=controller.devfile.io,resources=devworkspaceoperatorconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=controller.devfile.io,resources=devworkspaceoperatorconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;delete
 
 // Reconcile is the main reconciliation loop for the CleanupCronJob controller.
 func (r *CleanupCronJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -186,8 +236,8 @@ func (r *CleanupCronJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	cleanupConfig := dwOperatorConfig.Config.Workspace.CleanupCronJob
 	log = log.WithValues("CleanupCronJob", cleanupConfig)
 
-	if cleanupConfig.Enable == nil || !*cleanupConfig.Enable {
-		log.Info("DevWorkspace pruning is disabled, stopping cron scheduler and skipping reconciliation")
+	if !isPruningEnabled(cleanupConfig) && !isOrphanedStorageCleanupEnabled(cleanupConfig) && !isStorageUsageCollectionEnabled(cleanupConfig) {
+		log.Info("DevWorkspace pruning, orphaned storage cleanup and storage usage collection are disabled, stopping cron scheduler and skipping reconciliation")
 		r.stopCron(log)
 		return ctrl.Result{}, nil
 	}
@@ -213,27 +263,65 @@ func (r *CleanupCronJobReconciler) startCron(ctx context.Context, cleanupConfig
 		r.cron.Remove(entry.ID)
 	}
 
-	// add cronjob task
-	_, err := r.cron.AddFunc(cleanupConfig.Schedule, func() {
-		taskLog := logger.WithName("cronTask")
-
-		// define pruning parameters
-		retainTime := time.Duration(*cleanupConfig.RetainTime) * time.Second
+	// add cronjob tasks
+	if isPruningEnabled(cleanupConfig) {
+		_, err := r.cron.AddFunc(cleanupConfig.Schedule, func() {
+			taskLog := logger.WithName("cronTask")
+
+			// define pruning parameters
+			opts, err := getPruneOptions(cleanupConfig, config.GetGlobalConfig().Workspace.BackupCronJob)
+			if err != nil {
+				taskLog.Error(err, "Invalid DevWorkspace pruning configuration, skipping pruning job")
+				return
+			}
+
+			taskLog.Info("Starting DevWorkspace pruning job")
+			if err := r.pruneDevWorkspaces(ctx, opts, logger); err != nil {
+				taskLog.Error(err, "Failed to prune DevWorkspaces")
+			}
+			taskLog.Info("DevWorkspace pruning job finished")
+		})
+		if err != nil {
+			log.Error(err, "Failed to add cronjob function")
+			return
+		}
+	}
 
-		dryRun := false
-		if cleanupConfig.DryRun != nil {
-			dryRun = *cleanupConfig.DryRun
+	if isOrphanedStorageCleanupEnabled(cleanupConfig) {
+		_, err := r.cron.AddFunc(cleanupConfig.Schedule, func() {
+			taskLog := logger.WithName("cronTask")
+
+			dryRun := false
+			if cleanupConfig.OrphanedStorageCleanup.DryRun != nil {
+				dryRun = *cleanupConfig.OrphanedStorageCleanup.DryRun
+			}
+
+			taskLog.Info("Starting orphaned storage cleanup job")
+			if err := r.cleanupOrphanedStorage(ctx, dryRun, logger); err != nil {
+				taskLog.Error(err, "Failed to clean up orphaned storage")
+			}
+			taskLog.Info("Orphaned storage cleanup job finished")
+		})
+		if err != nil {
+			log.Error(err, "Failed to add cronjob function")
+			return
 		}
+	}
 
-		taskLog.Info("Starting DevWorkspace pruning job")
-		if err := r.pruneDevWorkspaces(ctx, retainTime, dryRun, logger); err != nil {
-			taskLog.Error(err, "Failed to prune DevWorkspaces")
+	if isStorageUsageCollectionEnabled(cleanupConfig) {
+		_, err := r.cron.AddFunc(cleanupConfig.Schedule, func() {
+			taskLog := logger.WithName("cronTask")
+
+			taskLog.Info("Starting storage usage collection job")
+			if err := r.collectStorageUsage(ctx, logger); err != nil {
+				taskLog.Error(err, "Failed to collect storage usage")
+			}
+			taskLog.Info("Storage usage collection job finished")
+		})
+		if err != nil {
+			log.Error(err, "Failed to add cronjob function")
+			return
 		}
-		taskLog.Info("DevWorkspace pruning job finished")
-	})
-	if err != nil {
-		log.Error(err, "Failed to add cronjob function")
-		return
 	}
 
 	r.cron.Start()
@@ -255,15 +343,16 @@ func (r *CleanupCronJobReconciler) stopCron(logger logr.Logger) {
 	log.Info("Cron scheduler stopped")
 }
 
-func (r *CleanupCronJobReconciler) pruneDevWorkspaces(ctx context.Context, retainTime time.Duration, dryRun bool, logger logr.Logger) error {
+func (r *CleanupCronJobReconciler) pruneDevWorkspaces(ctx context.Context, opts *pruneOptions, logger logr.Logger) error {
 	log := logger.WithName("pruner")
+	run := newPruningRun(opts.dryRun)
 
 	// create a prune strategy based on the configuration
 	var pruneStrategy prune.StrategyFunc
-	if dryRun {
-		pruneStrategy = r.dryRunPruneStrategy(retainTime, log)
+	if opts.dryRun {
+		pruneStrategy = r.dryRunPruneStrategy(opts, run, log)
 	} else {
-		pruneStrategy = r.pruneStrategy(retainTime, log)
+		pruneStrategy = r.pruneStrategy(opts, run, log)
 	}
 
 	gvk := schema.GroupVersionKind{
@@ -280,7 +369,21 @@ func (r *CleanupCronJobReconciler) pruneDevWorkspaces(ctx context.Context, retai
 
 	deletedObjects, err := pruner.Prune(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to prune objects: %w", err)
+		err = fmt.Errorf("failed to prune objects: %w", err)
+		run.addError(err)
+	}
+	reportedObjects := deletedObjects
+	if opts.dryRun {
+		// Nothing is deleted in dry-run mode; report the DevWorkspaces that would have been deleted instead
+		reportedObjects = run.selected
+	}
+	report := run.report(reportedObjects)
+	recordPruningRunMetrics(reportedObjects, opts.dryRun, len(report.Errors))
+	if recordErr := r.recordPruningRun(ctx, report); recordErr != nil {
+		log.Error(recordErr, "Failed to record pruning run in DevWorkspaceOperatorConfig status")
+	}
+	if err != nil {
+		return err
 	}
 	log.Info(fmt.Sprintf("Pruned %d DevWorkspaces", len(deletedObjects)))
 
@@ -297,25 +400,22 @@ func (r *CleanupCronJobReconciler) pruneDevWorkspaces(ctx context.Context, retai
 }
 
 // pruneStrategy returns a StrategyFunc that will return a list of
-// DevWorkspaces to prune based on the lastTransitionTime of the 'Started' condition.
-func (r *CleanupCronJobReconciler) pruneStrategy(retainTime time.Duration, logger logr.Logger) prune.StrategyFunc {
+// DevWorkspaces to prune, as selected by selectDevWorkspacesForDeletion.
+func (r *CleanupCronJobReconciler) pruneStrategy(opts *pruneOptions, run *pruningRun, logger logr.Logger) prune.StrategyFunc {
 	log := logger.WithName("pruneStrategy")
 
 	return func(ctx context.Context, objs []client.Object) ([]client.Object, error) {
-		filteredObjs := filterByInactivityTime(objs, retainTime, log)
-		log.Info(fmt.Sprintf("Found %d DevWorkspaces to prune", len(filteredObjs)))
-		return filteredObjs, nil
+		return r.selectDevWorkspacesForDeletion(ctx, objs, opts, run, log), nil
 	}
 }
 
 // dryRunPruneStrategy returns a StrategyFunc that will always return an empty list of DevWorkspaces to prune.
 // This is used for dry-run mode.
-func (r *CleanupCronJobReconciler) dryRunPruneStrategy(retainTime time.Duration, logger logr.Logger) prune.StrategyFunc {
+func (r *CleanupCronJobReconciler) dryRunPruneStrategy(opts *pruneOptions, run *pruningRun, logger logr.Logger) prune.StrategyFunc {
 	log := logger.WithName("dryRunPruneStrategy")
 
 	return func(ctx context.Context, objs []client.Object) ([]client.Object, error) {
-		filteredObjs := filterByInactivityTime(objs, retainTime, log)
-		log.Info(fmt.Sprintf("Found %d DevWorkspaces to prune", len(filteredObjs)))
+		r.selectDevWorkspacesForDeletion(ctx, objs, opts, run, log)
 
 		// Return an empty list of DevWorkspaces because this is a dry-run
 		log.Info("Dry run mode: no DevWorkspaces will be pruned")
@@ -323,8 +423,28 @@ func (r *CleanupCronJobReconciler) dryRunPruneStrategy(retainTime time.Duration,
 	}
 }
 
+// selectDevWorkspacesForDeletion returns the DevWorkspaces to delete based on the lastTransitionTime of the 'Started'
+// condition, the pruning policies and, if set, the minimum storage usage of DevWorkspaces to prune and the maximum
+// number of stopped DevWorkspaces per user. If backups are required before pruning, only DevWorkspaces that were backed
+// up since they were last stopped are selected, and backups of the others are requested. If a grace period is
+// configured, the remaining DevWorkspaces are scheduled for deletion and are only selected once the grace period has
+// passed; as backups are checked first, users are only notified about DevWorkspaces that are backed up.
+func (r *CleanupCronJobReconciler) selectDevWorkspacesForDeletion(ctx context.Context, objs []client.Object, opts *pruneOptions, run *pruningRun, log logr.Logger) []client.Object {
+	filteredObjs := selectDevWorkspacesToPrune(objs, opts, run, log)
+	if opts.requireBackup {
+		filteredObjs = r.filterByBackup(ctx, filteredObjs, opts, run, log)
+	}
+	if opts.gracePeriod > 0 {
+		filteredObjs = r.applyGracePeriod(ctx, objs, filteredObjs, opts, run, log)
+	}
+	run.setSelected(filteredObjs)
+	log.Info(fmt.Sprintf("Found %d DevWorkspaces to prune", len(filteredObjs)))
+	return filteredObjs
+}
+
 // filterByInactivityTime filters DevWorkspaces based on the lastTransitionTime of the 'Started' condition.
-func filterByInactivityTime(objs []client.Object, retainTime time.Duration, log logr.Logger) []client.Object {
+// The retain time of each DevWorkspace is determined by the first pruning policy that matches it.
+func filterByInactivityTime(objs []client.Object, opts *pruneOptions, run *pruningRun, log logr.Logger) []client.Object {
 	var filteredObjs []client.Object
 	for _, obj := range objs {
 		devWorkspace, ok := obj.(*dwv2.DevWorkspace)
@@ -333,19 +453,65 @@ func filterByInactivityTime(objs []client.Object, retainTime time.Duration, log
 			continue
 		}
 
-		if canPrune(*devWorkspace, retainTime, log) {
-			filteredObjs = append(filteredObjs, devWorkspace)
+		if reason := getPruneSkipReason(*devWorkspace, opts.getRetainTime(devWorkspace), log); reason != "" {
+			run.skip(devWorkspace, reason)
+			continue
+		}
+		filteredObjs = append(filteredObjs, devWorkspace)
+	}
+	return filteredObjs
+}
+
+// filterByStorageUsage filters out DevWorkspaces whose last recorded storage usage is below minimumStorageUsage.
+// DevWorkspaces without recorded storage usage are not filtered out.
+func filterByStorageUsage(objs []client.Object, minimumStorageUsage *resource.Quantity, run *pruningRun, log logr.Logger) []client.Object {
+	if minimumStorageUsage == nil {
+		return objs
+	}
+	var filteredObjs []client.Object
+	for _, obj := range objs {
+		devWorkspace, ok := obj.(*dwv2.DevWorkspace)
+		if !ok {
+			log.Error(nil, fmt.Sprintf("failed to convert %v to DevWorkspace", obj))
+			continue
+		}
+		if usage, ok := getRecordedStorageUsage(devWorkspace); ok && usage < minimumStorageUsage.Value() {
+			log.Info(fmt.Sprintf("Skipping DevWorkspace '%s/%s': storage usage is below minimum usage for pruning", devWorkspace.Namespace, devWorkspace.Name))
+			run.skip(devWorkspace, skipReasonBelowMinimumStorageUsage)
+			continue
 		}
+		filteredObjs = append(filteredObjs, devWorkspace)
 	}
 	return filteredObjs
 }
 
+func isPruningEnabled(cleanupConfig *controllerv1alpha1.CleanupCronJobConfig) bool {
+	return cleanupConfig.Enable != nil && *cleanupConfig.Enable
+}
+
+func isOrphanedStorageCleanupEnabled(cleanupConfig *controllerv1alpha1.CleanupCronJobConfig) bool {
+	return cleanupConfig.OrphanedStorageCleanup != nil &&
+		cleanupConfig.OrphanedStorageCleanup.Enable != nil &&
+		*cleanupConfig.OrphanedStorageCleanup.Enable
+}
+
 // canPrune returns true if the DevWorkspace is eligible for pruning.
 func canPrune(dw dwv2.DevWorkspace, retainTime time.Duration, log logr.Logger) bool {
+	return getPruneSkipReason(dw, retainTime, log) == ""
+}
+
+// getPruneSkipReason returns the reason the DevWorkspace is not eligible for pruning, or an empty string if it is.
+func getPruneSkipReason(dw dwv2.DevWorkspace, retainTime time.Duration, log logr.Logger) string {
 	// Skip started and running DevWorkspaces
 	if dw.Spec.Started {
 		log.Info(fmt.Sprintf("Skipping DevWorkspace '%s/%s': already started", dw.Namespace, dw.Name))
-		return false
+		return skipReasonStarted
+	}
+
+	// Skip DevWorkspaces that are exempt from pruning
+	if isPruneProtected(&dw) {
+		log.Info(fmt.Sprintf("Skipping DevWorkspace '%s/%s': protected from pruning", dw.Namespace, dw.Name))
+		return skipReasonProtected
 	}
 
 	var startTime *metav1.Time
@@ -355,13 +521,13 @@ func canPrune(dw dwv2.DevWorkspace, retainTime time.Duration, log logr.Logger) b
 	}
 	if startTime == nil {
 		log.Info(fmt.Sprintf("Skipping DevWorkspace '%s/%s': missing 'Started' condition", dw.Namespace, dw.Name))
-		return false
+		return skipReasonMissingStartedCondition
 	}
 	if time.Since(startTime.Time) <= retainTime {
 		log.Info(fmt.Sprintf("Skipping DevWorkspace '%s/%s': last transition time is within retain time", dw.Namespace, dw.Name))
-		return false
+		return skipReasonWithinRetainTime
 	}
 
 	log.Info(fmt.Sprintf("DevWorkspace '%s/%s' is eligible for pruning", dw.Namespace, dw.Name))
-	return true
+	return ""
 }
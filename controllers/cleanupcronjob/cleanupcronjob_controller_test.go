@@ -133,7 +133,7 @@ var _ = Describe("CleanupCronJobReconciler", func() {
 					&dw2,
 					&dw3,
 				}
-				filteredObjs := filterByInactivityTime(objs, retainTime, log)
+				filteredObjs := filterByInactivityTime(objs, &pruneOptions{retainTime: retainTime}, nil, log)
 				Expect(filteredObjs).To(HaveLen(1))
 				Expect(filteredObjs[0].GetName()).To(Equal("dw2"))
 			})
@@ -375,7 +375,7 @@ var _ = Describe("CleanupCronJobReconciler", func() {
 		})
 
 		It("Should prune inactive DevWorkspaces", func() {
-			err := reconciler.pruneDevWorkspaces(ctx, retainTime, dryRun, log)
+			err := reconciler.pruneDevWorkspaces(ctx, &pruneOptions{retainTime: retainTime, dryRun: dryRun}, log)
 			Expect(err).ToNot(HaveOccurred())
 
 			// Check if dw2 is deleted
@@ -393,7 +393,7 @@ var _ = Describe("CleanupCronJobReconciler", func() {
 
 		It("Should not prune any DevWorkspaces in dryRun mode", func() {
 			dryRun := true
-			err := reconciler.pruneDevWorkspaces(ctx, retainTime, dryRun, log)
+			err := reconciler.pruneDevWorkspaces(ctx, &pruneOptions{retainTime: retainTime, dryRun: dryRun}, log)
 			Expect(err).ToNot(HaveOccurred())
 
 			// Check that all DevWorkspaces still exist
@@ -581,6 +581,21 @@ var _ = Describe("DevWorkspaceOperatorConfig UpdateFunc Tests", func() {
 			&controllerv1alpha1.CleanupCronJobConfig{Schedule: "1 * * * *"},
 			true,
 		),
+		Entry("OrphanedStorageCleanup added => changed",
+			&controllerv1alpha1.CleanupCronJobConfig{},
+			&controllerv1alpha1.CleanupCronJobConfig{OrphanedStorageCleanup: &controllerv1alpha1.OrphanedStorageCleanupConfig{}},
+			true,
+		),
+		Entry("OrphanedStorageCleanup.Enable differs => changed",
+			&controllerv1alpha1.CleanupCronJobConfig{OrphanedStorageCleanup: &controllerv1alpha1.OrphanedStorageCleanupConfig{Enable: pointer.Bool(false)}},
+			&controllerv1alpha1.CleanupCronJobConfig{OrphanedStorageCleanup: &controllerv1alpha1.OrphanedStorageCleanupConfig{Enable: pointer.Bool(true)}},
+			true,
+		),
+		Entry("OrphanedStorageCleanup.DryRun differs => changed",
+			&controllerv1alpha1.CleanupCronJobConfig{OrphanedStorageCleanup: &controllerv1alpha1.OrphanedStorageCleanupConfig{DryRun: pointer.Bool(false)}},
+			&controllerv1alpha1.CleanupCronJobConfig{OrphanedStorageCleanup: &controllerv1alpha1.OrphanedStorageCleanupConfig{DryRun: pointer.Bool(true)}},
+			true,
+		),
 		Entry("All fields match => no change",
 			&controllerv1alpha1.CleanupCronJobConfig{
 				Enable:     pointer.Bool(true),
@@ -0,0 +1,228 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	scheduledForDeletionEventReason = "ScheduledForDeletion"
+	scheduledForDeletionEventAction = "Prune"
+)
+
+// notificationClient is the HTTP client used to send webhook notifications about DevWorkspaces scheduled for deletion.
+var notificationClient = &http.Client{Timeout: 10 * time.Second}
+
+// deletionNotification is the payload sent to the notification webhook for a DevWorkspace scheduled for deletion.
+type deletionNotification struct {
+	Name                   string `json:"name"`
+	Namespace              string `json:"namespace"`
+	DevWorkspaceID         string `json:"devworkspaceId,omitempty"`
+	Creator                string `json:"creator,omitempty"`
+	LastActivity           string `json:"lastActivity,omitempty"`
+	ScheduledForDeletionAt string `json:"scheduledForDeletionAt"`
+}
+
+// applyGracePeriod implements the two-phase pruning of DevWorkspaces when a grace period is configured. Candidates
+// (DevWorkspaces eligible for pruning) that are not yet scheduled for deletion are scheduled for deletion after the
+// grace period, and the user is notified. Returns the candidates whose scheduled deletion time has passed, which are
+// deleted.
+//
+// DevWorkspaces that are scheduled for deletion but are no longer candidates (e.g. because they were started) are
+// unscheduled. Likewise, a schedule is ignored and renewed if the DevWorkspace was started or stopped after it was
+// scheduled for deletion. Candidates whose scheduled deletion was cancelled by the user are not scheduled again until
+// they are started or stopped. In dry-run mode, DevWorkspaces are neither scheduled nor unscheduled.
+func (r *CleanupCronJobReconciler) applyGracePeriod(ctx context.Context, objs, candidates []client.Object, opts *pruneOptions, run *pruningRun, log logr.Logger) []client.Object {
+	now := time.Now()
+	isCandidate := map[client.Object]bool{}
+	var toDelete []client.Object
+	for _, obj := range candidates {
+		isCandidate[obj] = true
+		devWorkspace := obj.(*dwv2.DevWorkspace)
+		if scheduledAt, ok := getScheduledDeletionTime(devWorkspace); ok {
+			if !now.Before(scheduledAt) {
+				toDelete = append(toDelete, devWorkspace)
+			} else {
+				log.Info(fmt.Sprintf("DevWorkspace '%s/%s' is scheduled for deletion at %s",
+					devWorkspace.Namespace, devWorkspace.Name, scheduledAt.Format(time.RFC3339)))
+				run.skip(devWorkspace, skipReasonGracePeriod)
+			}
+			continue
+		}
+		if isDeletionCancelled(devWorkspace) {
+			log.Info(fmt.Sprintf("Scheduled deletion of DevWorkspace '%s/%s' was cancelled by the user, not scheduling it for deletion again until it is started or stopped",
+				devWorkspace.Namespace, devWorkspace.Name))
+			run.skip(devWorkspace, skipReasonDeletionCancelled)
+			continue
+		}
+		scheduledAt := now.Add(opts.gracePeriod)
+		if opts.dryRun {
+			log.Info(fmt.Sprintf("Dry run mode: DevWorkspace '%s/%s' would be scheduled for deletion at %s",
+				devWorkspace.Namespace, devWorkspace.Name, scheduledAt.Format(time.RFC3339)))
+			run.scheduledForDeletion(devWorkspace)
+			continue
+		}
+		if err := r.scheduleDeletion(ctx, devWorkspace, scheduledAt, opts.notificationWebhookURL, run, log); err != nil {
+			log.Error(err, fmt.Sprintf("Failed to schedule DevWorkspace '%s/%s' for deletion", devWorkspace.Namespace, devWorkspace.Name))
+			run.skip(devWorkspace, skipReasonGracePeriod)
+			run.addError(fmt.Errorf("failed to schedule DevWorkspace '%s/%s' for deletion: %w", devWorkspace.Namespace, devWorkspace.Name, err))
+			continue
+		}
+		run.scheduledForDeletion(devWorkspace)
+	}
+
+	for _, obj := range objs {
+		devWorkspace, ok := obj.(*dwv2.DevWorkspace)
+		if !ok || isCandidate[obj] {
+			continue
+		}
+		if _, ok := devWorkspace.Annotations[constants.DevWorkspaceScheduledForDeletionAtAnnotation]; !ok || opts.dryRun {
+			continue
+		}
+		log.Info(fmt.Sprintf("DevWorkspace '%s/%s' is no longer eligible for pruning, cancelling scheduled deletion", devWorkspace.Namespace, devWorkspace.Name))
+		if err := r.unscheduleDeletion(ctx, devWorkspace); err != nil {
+			log.Error(err, fmt.Sprintf("Failed to cancel scheduled deletion of DevWorkspace '%s/%s'", devWorkspace.Namespace, devWorkspace.Name))
+			run.addError(fmt.Errorf("failed to cancel scheduled deletion of DevWorkspace '%s/%s': %w", devWorkspace.Namespace, devWorkspace.Name, err))
+		}
+	}
+	return toDelete
+}
+
+// getScheduledDeletionTime returns the time the DevWorkspace is scheduled to be deleted at. Returns false if the
+// DevWorkspace is not scheduled for deletion, or if the schedule is outdated as the DevWorkspace was started or stopped
+// after it was scheduled for deletion. A schedule without the time the user was notified of it is outdated, as the
+// user may not have been notified.
+func getScheduledDeletionTime(dw *dwv2.DevWorkspace) (time.Time, bool) {
+	value, ok := dw.Annotations[constants.DevWorkspaceScheduledForDeletionAtAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	scheduledAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	notifiedAt, err := time.Parse(time.RFC3339, dw.Annotations[constants.DevWorkspaceDeletionNotifiedAtAnnotation])
+	if err != nil {
+		return time.Time{}, false
+	}
+	if lastActivity, ok := getLastActivity(dw); ok && lastActivity.After(notifiedAt) {
+		return time.Time{}, false
+	}
+	return scheduledAt, true
+}
+
+// isDeletionCancelled returns whether the user cancelled the scheduled deletion of the DevWorkspace by removing the
+// scheduled-for-deletion-at annotation. The cancellation only applies until the DevWorkspace is started or stopped.
+func isDeletionCancelled(dw *dwv2.DevWorkspace) bool {
+	if _, ok := dw.Annotations[constants.DevWorkspaceScheduledForDeletionAtAnnotation]; ok {
+		return false
+	}
+	value, ok := dw.Annotations[constants.DevWorkspaceDeletionNotifiedAtAnnotation]
+	if !ok {
+		return false
+	}
+	notifiedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false
+	}
+	if lastActivity, ok := getLastActivity(dw); ok && lastActivity.After(notifiedAt) {
+		return false
+	}
+	return true
+}
+
+// scheduleDeletion annotates the DevWorkspace with the time it will be deleted at and the time it was scheduled for
+// deletion at, and notifies the user through an
+// Event and, if webhookURL is set, a webhook notification. Failing to send the webhook notification is logged but does
+// not fail scheduling the deletion.
+func (r *CleanupCronJobReconciler) scheduleDeletion(ctx context.Context, dw *dwv2.DevWorkspace, scheduledAt time.Time, webhookURL string, run *pruningRun, log logr.Logger) error {
+	scheduledAtValue := scheduledAt.UTC().Format(time.RFC3339)
+	origDevWorkspace := dw.DeepCopy()
+	if dw.Annotations == nil {
+		dw.Annotations = map[string]string{}
+	}
+	dw.Annotations[constants.DevWorkspaceScheduledForDeletionAtAnnotation] = scheduledAtValue
+	dw.Annotations[constants.DevWorkspaceDeletionNotifiedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Patch(ctx, dw, client.MergeFrom(origDevWorkspace)); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Scheduled DevWorkspace '%s/%s' for deletion at %s", dw.Namespace, dw.Name, scheduledAtValue))
+
+	if r.Recorder != nil {
+		r.Recorder.Eventf(dw, nil, corev1.EventTypeWarning, scheduledForDeletionEventReason, scheduledForDeletionEventAction,
+			"DevWorkspace is scheduled for deletion at %s as it has not been used recently. Start the DevWorkspace or "+
+				"annotate it with %s: \"true\" to keep it.", scheduledAtValue, constants.DevWorkspacePruneProtectedAnnotation)
+	}
+
+	if webhookURL != "" {
+		if err := sendDeletionNotification(ctx, webhookURL, dw, scheduledAtValue); err != nil {
+			log.Error(err, fmt.Sprintf("Failed to send deletion notification for DevWorkspace '%s/%s'", dw.Namespace, dw.Name))
+			run.addError(fmt.Errorf("failed to send deletion notification for DevWorkspace '%s/%s': %w", dw.Namespace, dw.Name, err))
+		}
+	}
+	return nil
+}
+
+// unscheduleDeletion removes the scheduled-for-deletion-at and deletion-notified-at annotations from the DevWorkspace.
+func (r *CleanupCronJobReconciler) unscheduleDeletion(ctx context.Context, dw *dwv2.DevWorkspace) error {
+	origDevWorkspace := dw.DeepCopy()
+	delete(dw.Annotations, constants.DevWorkspaceScheduledForDeletionAtAnnotation)
+	delete(dw.Annotations, constants.DevWorkspaceDeletionNotifiedAtAnnotation)
+	return r.Patch(ctx, dw, client.MergeFrom(origDevWorkspace))
+}
+
+// sendDeletionNotification sends a JSON description of a DevWorkspace scheduled for deletion to webhookURL in an HTTP
+// POST request.
+func sendDeletionNotification(ctx context.Context, webhookURL string, dw *dwv2.DevWorkspace, scheduledAt string) error {
+	notification := deletionNotification{
+		Name:                   dw.Name,
+		Namespace:              dw.Namespace,
+		DevWorkspaceID:         dw.Status.DevWorkspaceId,
+		Creator:                dw.Labels[constants.DevWorkspaceCreatorLabel],
+		ScheduledForDeletionAt: scheduledAt,
+	}
+	if lastActivity, ok := getLastActivity(dw); ok {
+		notification.LastActivity = lastActivity.UTC().Format(time.RFC3339)
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := notificationClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %s", resp.Status)
+	}
+	return nil
+}
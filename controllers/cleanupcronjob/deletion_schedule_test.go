@@ -0,0 +1,237 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+)
+
+var _ = Describe("Pruning grace period", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		recorder   *events.FakeRecorder
+		reconciler CleanupCronJobReconciler
+		log        logr.Logger
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(controllerv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(dwv2.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		recorder = events.NewFakeRecorder(10)
+		log = zap.New(zap.UseDevMode(true)).WithName("gracePeriod")
+		reconciler = CleanupCronJobReconciler{
+			Client:   fakeClient,
+			Log:      log,
+			Scheme:   scheme,
+			Recorder: recorder,
+		}
+	})
+
+	// createInactiveWorkspace creates a DevWorkspace that was last active inactivity ago. If scheduledAt is not nil, the
+	// DevWorkspace was scheduled for deletion at scheduledAt with a grace period of 24 hours.
+	createInactiveWorkspace := func(name string, inactivity time.Duration, scheduledAt *time.Time) *dwv2.DevWorkspace {
+		workspace := createDevWorkspace(name, "test-ns", false, metav1.NewTime(time.Now().Add(-inactivity)))
+		workspace.Labels = map[string]string{constants.DevWorkspaceCreatorLabel: "user1"}
+		if scheduledAt != nil {
+			workspace.Annotations = map[string]string{
+				constants.DevWorkspaceScheduledForDeletionAtAnnotation: scheduledAt.UTC().Format(time.RFC3339),
+				constants.DevWorkspaceDeletionNotifiedAtAnnotation:     scheduledAt.Add(-24 * time.Hour).UTC().Format(time.RFC3339),
+			}
+		}
+		Expect(fakeClient.Create(ctx, workspace)).To(Succeed())
+		return workspace
+	}
+
+	getWorkspace := func(name string) *dwv2.DevWorkspace {
+		workspace := &dwv2.DevWorkspace{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "test-ns"}, workspace)).To(Succeed())
+		return workspace
+	}
+
+	It("Schedules eligible DevWorkspaces for deletion and notifies the user", func() {
+		var notifications []deletionNotification
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			notification := deletionNotification{}
+			Expect(json.NewDecoder(r.Body).Decode(&notification)).To(Succeed())
+			notifications = append(notifications, notification)
+		}))
+		defer server.Close()
+
+		workspace := createInactiveWorkspace("dw", 2*time.Hour, nil)
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour, notificationWebhookURL: server.URL}
+
+		toDelete := reconciler.applyGracePeriod(ctx, []client.Object{workspace}, []client.Object{workspace}, opts, nil, log)
+		Expect(toDelete).To(BeEmpty())
+
+		scheduledAt, err := time.Parse(time.RFC3339, getWorkspace("dw").Annotations[constants.DevWorkspaceScheduledForDeletionAtAnnotation])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scheduledAt).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
+		Expect(getWorkspace("dw").Annotations).To(HaveKey(constants.DevWorkspaceDeletionNotifiedAtAnnotation))
+		Expect(recorder.Events).To(Receive(ContainSubstring(scheduledForDeletionEventReason)))
+		Expect(notifications).To(HaveLen(1))
+		Expect(notifications[0].Name).To(Equal("dw"))
+		Expect(notifications[0].Namespace).To(Equal("test-ns"))
+		Expect(notifications[0].Creator).To(Equal("user1"))
+		Expect(notifications[0].ScheduledForDeletionAt).To(Equal(scheduledAt.UTC().Format(time.RFC3339)))
+	})
+
+	It("Returns DevWorkspaces whose grace period has passed", func() {
+		scheduledAt := time.Now().Add(-time.Minute)
+		expired := createInactiveWorkspace("expired", 48*time.Hour, &scheduledAt)
+		scheduledAt = time.Now().Add(time.Hour)
+		pending := createInactiveWorkspace("pending", 48*time.Hour, &scheduledAt)
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour}
+
+		objs := []client.Object{expired, pending}
+		toDelete := reconciler.applyGracePeriod(ctx, objs, objs, opts, nil, log)
+		Expect(toDelete).To(ConsistOf(expired))
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("Renews the schedule of DevWorkspaces that were started after they were scheduled for deletion", func() {
+		scheduledAt := time.Now().Add(-time.Minute)
+		workspace := createInactiveWorkspace("dw", 2*time.Hour, &scheduledAt)
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour}
+
+		toDelete := reconciler.applyGracePeriod(ctx, []client.Object{workspace}, []client.Object{workspace}, opts, nil, log)
+		Expect(toDelete).To(BeEmpty())
+		newScheduledAt, err := time.Parse(time.RFC3339, getWorkspace("dw").Annotations[constants.DevWorkspaceScheduledForDeletionAtAnnotation])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(newScheduledAt).To(BeTemporally(">", time.Now()))
+	})
+
+	It("Keeps the schedule of DevWorkspaces when the grace period is changed", func() {
+		workspace := createInactiveWorkspace("dw", 2*time.Hour, nil)
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: time.Hour}
+		toDelete := reconciler.applyGracePeriod(ctx, []client.Object{workspace}, []client.Object{workspace}, opts, nil, log)
+		Expect(toDelete).To(BeEmpty())
+		Expect(recorder.Events).To(Receive(ContainSubstring(scheduledForDeletionEventReason)))
+		scheduled := getWorkspace("dw")
+		scheduledAt := scheduled.Annotations[constants.DevWorkspaceScheduledForDeletionAtAnnotation]
+		Expect(scheduledAt).ToNot(BeEmpty())
+
+		// The grace period is longer than the time since the DevWorkspace was last active, which must not be mistaken
+		// for the DevWorkspace having been started after it was scheduled for deletion
+		opts.gracePeriod = 48 * time.Hour
+		toDelete = reconciler.applyGracePeriod(ctx, []client.Object{scheduled}, []client.Object{scheduled}, opts, nil, log)
+		Expect(toDelete).To(BeEmpty())
+		Expect(getWorkspace("dw").Annotations).To(HaveKeyWithValue(constants.DevWorkspaceScheduledForDeletionAtAnnotation, scheduledAt))
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("Renews schedules without the time the user was notified at", func() {
+		scheduledAt := time.Now().Add(-time.Minute)
+		workspace := createInactiveWorkspace("dw", 48*time.Hour, &scheduledAt)
+		delete(workspace.Annotations, constants.DevWorkspaceDeletionNotifiedAtAnnotation)
+		Expect(fakeClient.Update(ctx, workspace)).To(Succeed())
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour}
+
+		toDelete := reconciler.applyGracePeriod(ctx, []client.Object{workspace}, []client.Object{workspace}, opts, nil, log)
+		Expect(toDelete).To(BeEmpty())
+		Expect(getWorkspace("dw").Annotations).To(HaveKey(constants.DevWorkspaceDeletionNotifiedAtAnnotation))
+		Expect(recorder.Events).To(Receive(ContainSubstring(scheduledForDeletionEventReason)))
+	})
+
+	It("Cancels the scheduled deletion of DevWorkspaces that are no longer eligible for pruning", func() {
+		scheduledAt := time.Now().Add(time.Hour)
+		workspace := createInactiveWorkspace("dw", time.Minute, &scheduledAt)
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour}
+
+		toDelete := reconciler.applyGracePeriod(ctx, []client.Object{workspace}, nil, opts, nil, log)
+		Expect(toDelete).To(BeEmpty())
+		Expect(getWorkspace("dw").Annotations).ToNot(HaveKey(constants.DevWorkspaceScheduledForDeletionAtAnnotation))
+		Expect(getWorkspace("dw").Annotations).ToNot(HaveKey(constants.DevWorkspaceDeletionNotifiedAtAnnotation))
+	})
+
+	It("Does not schedule DevWorkspaces again after the user cancelled their scheduled deletion", func() {
+		workspace := createInactiveWorkspace("dw", 2*time.Hour, nil)
+		workspace.Annotations = map[string]string{
+			constants.DevWorkspaceDeletionNotifiedAtAnnotation: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+		}
+		Expect(fakeClient.Update(ctx, workspace)).To(Succeed())
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour}
+		run := newPruningRun(false)
+
+		toDelete := reconciler.applyGracePeriod(ctx, []client.Object{workspace}, []client.Object{workspace}, opts, run, log)
+		Expect(toDelete).To(BeEmpty())
+		Expect(getWorkspace("dw").Annotations).ToNot(HaveKey(constants.DevWorkspaceScheduledForDeletionAtAnnotation))
+		Expect(run.skipped).To(HaveKeyWithValue(workspace, skipReasonDeletionCancelled))
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("Schedules DevWorkspaces whose scheduled deletion was cancelled again after they were started or stopped", func() {
+		workspace := createInactiveWorkspace("dw", 2*time.Hour, nil)
+		workspace.Annotations = map[string]string{
+			constants.DevWorkspaceDeletionNotifiedAtAnnotation: time.Now().Add(-3 * time.Hour).UTC().Format(time.RFC3339),
+		}
+		Expect(fakeClient.Update(ctx, workspace)).To(Succeed())
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour}
+
+		toDelete := reconciler.applyGracePeriod(ctx, []client.Object{workspace}, []client.Object{workspace}, opts, nil, log)
+		Expect(toDelete).To(BeEmpty())
+		Expect(getWorkspace("dw").Annotations).To(HaveKey(constants.DevWorkspaceScheduledForDeletionAtAnnotation))
+		Expect(recorder.Events).To(Receive(ContainSubstring(scheduledForDeletionEventReason)))
+	})
+
+	It("Does not schedule DevWorkspaces for deletion in dry-run mode", func() {
+		workspace := createInactiveWorkspace("dw", 2*time.Hour, nil)
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour, dryRun: true}
+
+		toDelete := reconciler.applyGracePeriod(ctx, []client.Object{workspace}, []client.Object{workspace}, opts, nil, log)
+		Expect(toDelete).To(BeEmpty())
+		Expect(getWorkspace("dw").Annotations).ToNot(HaveKey(constants.DevWorkspaceScheduledForDeletionAtAnnotation))
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("Only prunes DevWorkspaces after the grace period", func() {
+		scheduledAt := time.Now().Add(-time.Minute)
+		createInactiveWorkspace("expired", 48*time.Hour, &scheduledAt)
+		createInactiveWorkspace("new", 2*time.Hour, nil)
+		opts := &pruneOptions{retainTime: time.Hour, gracePeriod: 24 * time.Hour}
+
+		Expect(reconciler.pruneDevWorkspaces(ctx, opts, log)).To(Succeed())
+
+		workspaces := &dwv2.DevWorkspaceList{}
+		Expect(fakeClient.List(ctx, workspaces)).To(Succeed())
+		Expect(workspaces.Items).To(HaveLen(1))
+		Expect(workspaces.Items[0].Name).To(Equal("new"))
+		Expect(workspaces.Items[0].Annotations).To(HaveKey(constants.DevWorkspaceScheduledForDeletionAtAnnotation))
+	})
+})
@@ -0,0 +1,113 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Jobs started by the cleanup cron job (e.g. orphaned storage cleanup and storage usage jobs) report their
+// result through the termination message of their pod. As these jobs are not labelled with a DevWorkspace ID,
+// they are not visible to the cached client and must be managed using the non-caching client.
+
+const (
+	jobPollInterval = 10 * time.Second
+	jobTimeout      = 30 * time.Minute
+)
+
+// recreateJob creates job on the cluster, removing any leftover job with the same name from a previous run first
+// (e.g. if the controller restarted while the job was running).
+func (r *CleanupCronJobReconciler) recreateJob(ctx context.Context, job *batchv1.Job) error {
+	if err := r.deleteJob(ctx, job); err != nil {
+		return err
+	}
+	if err := wait.PollUntilContextTimeout(ctx, time.Second, time.Minute, true, func(ctx context.Context) (bool, error) {
+		err := r.NonCachingClient.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{})
+		if k8sErrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}); err != nil {
+		return fmt.Errorf("timed out waiting for previous job %s to be deleted: %w", job.Name, err)
+	}
+
+	return r.NonCachingClient.Create(ctx, job)
+}
+
+// waitForJobTerminationMessage waits for a job to finish and returns the termination message of its pod.
+func (r *CleanupCronJobReconciler) waitForJobTerminationMessage(ctx context.Context, job *batchv1.Job) (string, error) {
+	var message string
+	err := wait.PollUntilContextTimeout(ctx, jobPollInterval, jobTimeout, false, func(ctx context.Context) (bool, error) {
+		var err error
+		var done bool
+		done, message, err = r.getJobTerminationMessage(ctx, job)
+		return done, err
+	})
+	return message, err
+}
+
+// getJobTerminationMessage checks whether a job has finished. If the job completed successfully, the termination
+// message of the job's pod is returned. If the job failed, an error is returned.
+func (r *CleanupCronJobReconciler) getJobTerminationMessage(ctx context.Context, job *batchv1.Job) (done bool, message string, err error) {
+	clusterJob := &batchv1.Job{}
+	if err := r.NonCachingClient.Get(ctx, client.ObjectKeyFromObject(job), clusterJob); err != nil {
+		return false, "", err
+	}
+
+	for _, condition := range clusterJob.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobFailed:
+			return true, "", fmt.Errorf("job failed: %s", condition.Message)
+		case batchv1.JobComplete:
+			message, err := r.readJobTerminationMessage(ctx, clusterJob)
+			return true, message, err
+		}
+	}
+	return false, "", nil
+}
+
+func (r *CleanupCronJobReconciler) readJobTerminationMessage(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.NonCachingClient.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.State.Terminated != nil && containerStatus.State.Terminated.Message != "" {
+				return containerStatus.State.Terminated.Message, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("could not find result of job %s", job.Name)
+}
+
+func (r *CleanupCronJobReconciler) deleteJob(ctx context.Context, job *batchv1.Job) error {
+	err := r.NonCachingClient.Delete(ctx, job.DeepCopy(), client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return client.IgnoreNotFound(err)
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsDryRunLabel      = "dry_run"
+	metricsNamespaceLabel   = "namespace"
+	metricsStorageTypeLabel = "storage_type"
+)
+
+var (
+	orphanedStorageDirectories = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "orphaned_storage_directories_total",
+			Help:      "Number of orphaned workspace directories found in common PVCs",
+		},
+		[]string{metricsDryRunLabel},
+	)
+	orphanedStorageReclaimedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "orphaned_storage_reclaimed_bytes_total",
+			Help:      "Total size of orphaned workspace directories removed from common PVCs, in bytes. In dry-run mode, the size that would have been reclaimed",
+		},
+		[]string{metricsDryRunLabel},
+	)
+	orphanedStorageCleanupFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "orphaned_storage_cleanup_failures_total",
+			Help:      "Number of orphaned storage cleanup jobs that failed or did not complete in time",
+		},
+	)
+	storageUsageBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "devworkspace",
+			Name:      "storage_usage_bytes",
+			Help:      "Storage used by DevWorkspaces, in bytes, as last collected by the cleanup cron job",
+		},
+		[]string{metricsNamespaceLabel, metricsStorageTypeLabel},
+	)
+	storageUsageCollectionFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "storage_usage_collection_failures_total",
+			Help:      "Number of failures to collect storage usage from nodes, storage usage jobs or to record it on DevWorkspaces",
+		},
+	)
+	prunedDevWorkspaces = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "pruned_workspaces_total",
+			Help:      "Number of DevWorkspaces deleted by the cleanup cron job. In dry-run mode, the number of DevWorkspaces that would have been deleted",
+		},
+		[]string{metricsNamespaceLabel, metricsDryRunLabel},
+	)
+	pruningErrors = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "pruning_errors_total",
+			Help:      "Number of errors that occurred while pruning DevWorkspaces",
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		orphanedStorageDirectories,
+		orphanedStorageReclaimedBytes,
+		orphanedStorageCleanupFailures,
+		storageUsageBytes,
+		storageUsageCollectionFailures,
+		prunedDevWorkspaces,
+		pruningErrors,
+	)
+}
+
+func recordOrphanedStorageCleanup(orphanedDirectories int, reclaimedBytes int64, dryRun bool) {
+	dryRunLabel := strconv.FormatBool(dryRun)
+	orphanedStorageDirectories.WithLabelValues(dryRunLabel).Add(float64(orphanedDirectories))
+	orphanedStorageReclaimedBytes.WithLabelValues(dryRunLabel).Add(float64(reclaimedBytes))
+}
+
+// recordPruningRunMetrics records the DevWorkspaces deleted by a pruning run, or that would have been deleted in
+// dry-run mode, and the number of errors that occurred during the run.
+func recordPruningRunMetrics(deleted []client.Object, dryRun bool, errors int) {
+	dryRunLabel := strconv.FormatBool(dryRun)
+	for _, obj := range deleted {
+		prunedDevWorkspaces.WithLabelValues(obj.GetNamespace(), dryRunLabel).Inc()
+	}
+	pruningErrors.Add(float64(errors))
+}
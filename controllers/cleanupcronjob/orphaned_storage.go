@@ -0,0 +1,169 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/config"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/provision/storage"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+// cleanupOrphanedStorage removes workspace directories from common PVCs that do not belong to any existing
+// DevWorkspace. A cleanup job is started for each common PVC on the cluster, and the results of all jobs
+// are collected once they finish.
+func (r *CleanupCronJobReconciler) cleanupOrphanedStorage(ctx context.Context, dryRun bool, logger logr.Logger) error {
+	log := logger.WithName("orphanedStorage")
+
+	operatorConfig := config.GetGlobalConfig()
+
+	workspaceIds, err := r.getWorkspaceIdsByNamespace(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list DevWorkspaces: %w", err)
+	}
+
+	pvcs, err := r.getCommonPVCs(ctx, operatorConfig)
+	if err != nil {
+		return fmt.Errorf("failed to list PersistentVolumeClaims: %w", err)
+	}
+	log.Info(fmt.Sprintf("Found %d common PVCs to check for orphaned storage", len(pvcs)))
+
+	var jobs []*batchv1.Job
+	for _, pvc := range pvcs {
+		job, err := r.startOrphanedStorageCleanupJob(ctx, &pvc, workspaceIds[pvc.Namespace], dryRun, operatorConfig, log)
+		if err != nil {
+			log.Error(err, "Failed to start orphaned storage cleanup job", "namespace", pvc.Namespace, "pvc", pvc.Name)
+			orphanedStorageCleanupFailures.Inc()
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	for _, job := range jobs {
+		jobLog := log.WithValues("namespace", job.Namespace, "job", job.Name)
+		result, err := r.waitForOrphanedStorageCleanupJob(ctx, job)
+		if err != nil {
+			jobLog.Error(err, "Orphaned storage cleanup job did not succeed")
+			orphanedStorageCleanupFailures.Inc()
+		} else {
+			jobLog.Info(fmt.Sprintf("Found %d orphaned workspace directories (%d bytes)", result.OrphanedDirectories, result.ReclaimedBytes), "dryRun", dryRun)
+			recordOrphanedStorageCleanup(result.OrphanedDirectories, result.ReclaimedBytes, dryRun)
+		}
+		if err := r.deleteOrphanedStorageCleanupJob(ctx, job); err != nil {
+			jobLog.Error(err, "Failed to delete orphaned storage cleanup job")
+		}
+	}
+
+	return nil
+}
+
+// getWorkspaceIdsByNamespace returns the IDs of all DevWorkspaces on the cluster, grouped by namespace. For
+// DevWorkspaces that have not been assigned an ID yet, the ID override annotation is used if present.
+func (r *CleanupCronJobReconciler) getWorkspaceIdsByNamespace(ctx context.Context) (map[string][]string, error) {
+	workspaces := &dwv2.DevWorkspaceList{}
+	if err := r.Client.List(ctx, workspaces); err != nil {
+		return nil, err
+	}
+
+	workspaceIds := map[string][]string{}
+	for _, workspace := range workspaces.Items {
+		workspaceId := workspace.Status.DevWorkspaceId
+		if workspaceId == "" {
+			workspaceId = workspace.Annotations[constants.WorkspaceIdOverrideAnnotation]
+		}
+		if workspaceId == "" {
+			continue
+		}
+		workspaceIds[workspace.Namespace] = append(workspaceIds[workspace.Namespace], workspaceId)
+	}
+	return workspaceIds, nil
+}
+
+// getCommonPVCs returns all PVCs on the cluster that are used as common PVCs for DevWorkspaces and are not
+// being deleted.
+func (r *CleanupCronJobReconciler) getCommonPVCs(ctx context.Context, operatorConfig *controllerv1alpha1.OperatorConfiguration) ([]corev1.PersistentVolumeClaim, error) {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.Client.List(ctx, pvcList); err != nil {
+		return nil, err
+	}
+
+	var pvcs []corev1.PersistentVolumeClaim
+	for _, pvc := range pvcList.Items {
+		if pvc.DeletionTimestamp != nil {
+			continue
+		}
+		if storage.IsCommonPVCName(pvc.Name, operatorConfig) {
+			pvcs = append(pvcs, pvc)
+		}
+	}
+	return pvcs, nil
+}
+
+func (r *CleanupCronJobReconciler) startOrphanedStorageCleanupJob(ctx context.Context, pvc *corev1.PersistentVolumeClaim, workspaceIds []string, dryRun bool,
+	operatorConfig *controllerv1alpha1.OperatorConfiguration, log logr.Logger) (*batchv1.Job, error) {
+	clusterAPI := sync.ClusterAPI{
+		Ctx:    ctx,
+		Client: r.Client,
+		Scheme: r.Scheme,
+		Logger: log,
+	}
+	job, err := storage.GetOrphanedStorageCleanupJob(pvc, workspaceIds, dryRun, operatorConfig, clusterAPI)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.recreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// waitForOrphanedStorageCleanupJob waits for an orphaned storage cleanup job to finish and returns its result.
+func (r *CleanupCronJobReconciler) waitForOrphanedStorageCleanupJob(ctx context.Context, job *batchv1.Job) (*storage.OrphanedStorageCleanupResult, error) {
+	var result *storage.OrphanedStorageCleanupResult
+	err := wait.PollUntilContextTimeout(ctx, jobPollInterval, jobTimeout, false, func(ctx context.Context) (bool, error) {
+		var err error
+		var done bool
+		done, result, err = r.getOrphanedStorageCleanupJobResult(ctx, job)
+		return done, err
+	})
+	return result, err
+}
+
+// getOrphanedStorageCleanupJobResult checks whether an orphaned storage cleanup job has finished. If the job completed
+// successfully, the result reported in the termination message of the job's pod is returned. If the job failed,
+// an error is returned.
+func (r *CleanupCronJobReconciler) getOrphanedStorageCleanupJobResult(ctx context.Context, job *batchv1.Job) (done bool, result *storage.OrphanedStorageCleanupResult, err error) {
+	done, message, err := r.getJobTerminationMessage(ctx, job)
+	if !done || err != nil {
+		return done, nil, err
+	}
+	result, err = storage.ParseOrphanedStorageCleanupResult(message)
+	return true, result, err
+}
+
+func (r *CleanupCronJobReconciler) deleteOrphanedStorageCleanupJob(ctx context.Context, job *batchv1.Job) error {
+	return r.deleteJob(ctx, job)
+}
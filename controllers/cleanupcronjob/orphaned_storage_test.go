@@ -0,0 +1,228 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/robfig/cron/v3"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+)
+
+var _ = Describe("Orphaned storage cleanup", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		reconciler CleanupCronJobReconciler
+		log        logr.Logger
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(controllerv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(dwv2.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		log = zap.New(zap.UseDevMode(true)).WithName("cleanupCronJobController")
+
+		reconciler = CleanupCronJobReconciler{
+			Client:           fakeClient,
+			NonCachingClient: fakeClient,
+			Log:              log,
+			Scheme:           scheme,
+			cron:             cron.New(),
+		}
+	})
+
+	AfterEach(func() {
+		reconciler.stopCron(log)
+	})
+
+	It("Should start cron if only orphaned storage cleanup is enabled", func() {
+		dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "devworkspace-operator-config", Namespace: "devworkspace-controller"},
+			Config: &controllerv1alpha1.OperatorConfiguration{
+				Workspace: &controllerv1alpha1.WorkspaceConfig{
+					CleanupCronJob: &controllerv1alpha1.CleanupCronJobConfig{
+						Enable:   pointer.Bool(false),
+						Schedule: "* * * * *",
+						OrphanedStorageCleanup: &controllerv1alpha1.OrphanedStorageCleanupConfig{
+							Enable: pointer.Bool(true),
+						},
+					},
+				},
+			},
+		}
+		Expect(fakeClient.Create(ctx, dwoc)).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dwoc)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(reconciler.cron.Entries()).To(HaveLen(1))
+	})
+
+	It("Should add separate cron tasks for pruning and orphaned storage cleanup", func() {
+		cleanupConfig := &controllerv1alpha1.CleanupCronJobConfig{
+			Enable:   pointer.Bool(true),
+			Schedule: "* * * * *",
+			OrphanedStorageCleanup: &controllerv1alpha1.OrphanedStorageCleanupConfig{
+				Enable: pointer.Bool(true),
+			},
+		}
+		reconciler.startCron(ctx, cleanupConfig, log)
+		Expect(reconciler.cron.Entries()).To(HaveLen(2))
+	})
+
+	It("Should collect workspace IDs by namespace", func() {
+		dw1 := createDevWorkspace("dw1", "ns-a", false, metav1.Now())
+		dw1.Status.DevWorkspaceId = "workspace-1"
+		dw2 := createDevWorkspace("dw2", "ns-a", false, metav1.Now())
+		dw2.Status.DevWorkspaceId = "workspace-2"
+		dw3 := createDevWorkspace("dw3", "ns-b", false, metav1.Now())
+		dw3.Annotations = map[string]string{constants.WorkspaceIdOverrideAnnotation: "workspace-override"}
+		for _, dw := range []*dwv2.DevWorkspace{dw1, dw2, dw3} {
+			Expect(fakeClient.Create(ctx, dw)).To(Succeed())
+		}
+
+		workspaceIds, err := reconciler.getWorkspaceIdsByNamespace(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(workspaceIds).To(HaveKeyWithValue("ns-a", ConsistOf("workspace-1", "workspace-2")))
+		Expect(workspaceIds).To(HaveKeyWithValue("ns-b", ConsistOf("workspace-override")))
+	})
+
+	It("Should only return common PVCs", func() {
+		pvcNames := []string{"claim-devworkspace", constants.CheCommonPVCName, "storage-workspace-1"}
+		for _, name := range pvcNames {
+			Expect(fakeClient.Create(ctx, &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns-a"},
+			})).To(Succeed())
+		}
+		operatorConfig := &controllerv1alpha1.OperatorConfiguration{
+			Workspace: &controllerv1alpha1.WorkspaceConfig{
+				PVCName: "claim-devworkspace",
+			},
+		}
+
+		pvcs, err := reconciler.getCommonPVCs(ctx, operatorConfig)
+		Expect(err).ToNot(HaveOccurred())
+		var names []string
+		for _, pvc := range pvcs {
+			names = append(names, pvc.Name)
+		}
+		Expect(names).To(ConsistOf("claim-devworkspace", constants.CheCommonPVCName))
+	})
+
+	Describe("getOrphanedStorageCleanupJobResult", func() {
+		var job *batchv1.Job
+
+		BeforeEach(func() {
+			job = &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cleanup-orphaned-claim-devworkspace",
+					Namespace: "ns-a",
+				},
+			}
+			Expect(fakeClient.Create(ctx, job)).To(Succeed())
+		})
+
+		It("Should not be done if job is running", func() {
+			done, result, err := reconciler.getOrphanedStorageCleanupJobResult(ctx, job)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(done).To(BeFalse())
+			Expect(result).To(BeNil())
+		})
+
+		It("Should return an error if job failed", func() {
+			job.Status.Conditions = []batchv1.JobCondition{{
+				Type:    batchv1.JobFailed,
+				Status:  corev1.ConditionTrue,
+				Message: "BackoffLimitExceeded",
+			}}
+			Expect(fakeClient.Status().Update(ctx, job)).To(Succeed())
+
+			done, _, err := reconciler.getOrphanedStorageCleanupJobResult(ctx, job)
+			Expect(done).To(BeTrue())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should read result from pod termination message if job completed", func() {
+			job.Status.Conditions = []batchv1.JobCondition{{
+				Type:   batchv1.JobComplete,
+				Status: corev1.ConditionTrue,
+			}}
+			Expect(fakeClient.Status().Update(ctx, job)).To(Succeed())
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cleanup-orphaned-claim-devworkspace-abcde",
+					Namespace: "ns-a",
+					Labels:    map[string]string{"job-name": job.Name},
+				},
+			}
+			Expect(fakeClient.Create(ctx, pod)).To(Succeed())
+			pod.Status = corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "cleanup-orphaned-storage",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `{"orphanedDirectories":3,"reclaimedBytes":1048576}`,
+						},
+					},
+				}},
+			}
+			Expect(fakeClient.Status().Update(ctx, pod)).To(Succeed())
+
+			done, result, err := reconciler.getOrphanedStorageCleanupJobResult(ctx, job)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(done).To(BeTrue())
+			Expect(result.OrphanedDirectories).To(Equal(3))
+			Expect(result.ReclaimedBytes).To(Equal(int64(1048576)))
+		})
+	})
+
+	It("Should delete orphaned storage cleanup job", func() {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cleanup-orphaned-claim-devworkspace",
+				Namespace: "ns-a",
+			},
+		}
+		Expect(fakeClient.Create(ctx, job)).To(Succeed())
+		Expect(reconciler.deleteOrphanedStorageCleanupJob(ctx, job)).To(Succeed())
+		err := fakeClient.Get(ctx, types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, &batchv1.Job{})
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+		Expect(err).To(HaveOccurred())
+		// Deleting a job that does not exist is not an error
+		Expect(reconciler.deleteOrphanedStorageCleanupJob(ctx, job)).To(Succeed())
+	})
+})
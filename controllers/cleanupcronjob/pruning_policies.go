@@ -0,0 +1,194 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/conditions"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pruneOptions determines which DevWorkspaces are pruned by the cleanup cron job.
+type pruneOptions struct {
+	// retainTime is the retain time of DevWorkspaces that do not match any pruning policy
+	retainTime time.Duration
+	// policies are the pruning policies, in the order in which they are matched against DevWorkspaces
+	policies []pruningPolicy
+	// maxStoppedPerUser is the maximum number of stopped DevWorkspaces kept for each user. Zero means no limit.
+	maxStoppedPerUser int
+	// minimumStorageUsage, if set, is the minimum storage usage of DevWorkspaces that are pruned because they
+	// exceeded their retain time
+	minimumStorageUsage *resource.Quantity
+	// gracePeriod, if not zero, is the time between scheduling DevWorkspaces for deletion and deleting them
+	gracePeriod time.Duration
+	// notificationWebhookURL, if set, is notified about DevWorkspaces scheduled for deletion
+	notificationWebhookURL string
+	// requireBackup is true if DevWorkspaces must be backed up since they were last stopped before they are pruned
+	requireBackup bool
+	dryRun        bool
+}
+
+type pruningPolicy struct {
+	namespace  string
+	selector   labels.Selector
+	retainTime time.Duration
+}
+
+// getPruneOptions reads the pruning configuration from the cleanup cron job configuration, and the backup cron job
+// configuration if backups are required before pruning. Returns an error if a pruning policy has an invalid selector.
+func getPruneOptions(cleanupConfig *controllerv1alpha1.CleanupCronJobConfig, backupConfig *controllerv1alpha1.BackupCronJobConfig) (*pruneOptions, error) {
+	opts := &pruneOptions{
+		retainTime:    time.Duration(*cleanupConfig.RetainTime) * time.Second,
+		requireBackup: isBackupBeforePruneEnabled(cleanupConfig, backupConfig),
+		dryRun:        cleanupConfig.DryRun != nil && *cleanupConfig.DryRun,
+	}
+	if isStorageUsageCollectionEnabled(cleanupConfig) {
+		opts.minimumStorageUsage = cleanupConfig.StorageUsage.MinimumUsageForPruning
+	}
+	if cleanupConfig.MaxStoppedWorkspacesPerUser != nil {
+		opts.maxStoppedPerUser = int(*cleanupConfig.MaxStoppedWorkspacesPerUser)
+	}
+	if cleanupConfig.GracePeriod != nil {
+		opts.gracePeriod = time.Duration(*cleanupConfig.GracePeriod) * time.Second
+	}
+	if cleanupConfig.Notification != nil {
+		opts.notificationWebhookURL = cleanupConfig.Notification.WebhookURL
+	}
+	for idx, policy := range cleanupConfig.Policies {
+		selector := labels.Everything()
+		if policy.Selector != nil {
+			var err error
+			selector, err = metav1.LabelSelectorAsSelector(policy.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector in pruning policy %d: %w", idx, err)
+			}
+		}
+		opts.policies = append(opts.policies, pruningPolicy{
+			namespace:  policy.Namespace,
+			selector:   selector,
+			retainTime: time.Duration(policy.RetainTime) * time.Second,
+		})
+	}
+	return opts, nil
+}
+
+// getRetainTime returns the retain time of the first pruning policy that matches the DevWorkspace, or the default
+// retain time if no policy matches.
+func (opts *pruneOptions) getRetainTime(dw *dwv2.DevWorkspace) time.Duration {
+	for _, policy := range opts.policies {
+		if policy.namespace != "" && policy.namespace != dw.Namespace {
+			continue
+		}
+		if !policy.selector.Matches(labels.Set(dw.Labels)) {
+			continue
+		}
+		return policy.retainTime
+	}
+	return opts.retainTime
+}
+
+// selectDevWorkspacesToPrune returns the DevWorkspaces that exceeded their retain time, as well as the least recently
+// active stopped DevWorkspaces of users that exceed the maximum number of stopped DevWorkspaces. The returned
+// DevWorkspaces are ordered by last activity, starting with the least recently active.
+func selectDevWorkspacesToPrune(objs []client.Object, opts *pruneOptions, run *pruningRun, log logr.Logger) []client.Object {
+	filteredObjs := filterByInactivityTime(objs, opts, run, log)
+	filteredObjs = filterByStorageUsage(filteredObjs, opts.minimumStorageUsage, run, log)
+	filteredObjs = append(filteredObjs, selectExcessStoppedDevWorkspaces(objs, filteredObjs, opts.maxStoppedPerUser, log)...)
+	run.setCandidates(filteredObjs)
+	sort.SliceStable(filteredObjs, func(i, j int) bool {
+		iActivity, _ := getLastActivity(filteredObjs[i].(*dwv2.DevWorkspace))
+		jActivity, _ := getLastActivity(filteredObjs[j].(*dwv2.DevWorkspace))
+		return iActivity.Before(jActivity)
+	})
+	return filteredObjs
+}
+
+// selectExcessStoppedDevWorkspaces returns, for each user with more than maxStoppedPerUser stopped DevWorkspaces, the
+// least recently active stopped DevWorkspaces beyond the limit. DevWorkspaces in alreadySelected are pruned anyway and
+// are not counted. Users are identified by the creator label of DevWorkspaces; DevWorkspaces without creator label,
+// protected DevWorkspaces and DevWorkspaces without a 'Started' condition are not counted either.
+func selectExcessStoppedDevWorkspaces(objs, alreadySelected []client.Object, maxStoppedPerUser int, log logr.Logger) []client.Object {
+	if maxStoppedPerUser <= 0 {
+		return nil
+	}
+	selected := map[client.Object]bool{}
+	for _, obj := range alreadySelected {
+		selected[obj] = true
+	}
+
+	stoppedByUser := map[string][]*dwv2.DevWorkspace{}
+	var users []string
+	for _, obj := range objs {
+		devWorkspace, ok := obj.(*dwv2.DevWorkspace)
+		if !ok || selected[obj] || devWorkspace.Spec.Started || isPruneProtected(devWorkspace) {
+			continue
+		}
+		creator := devWorkspace.Labels[constants.DevWorkspaceCreatorLabel]
+		if creator == "" {
+			continue
+		}
+		if _, ok := getLastActivity(devWorkspace); !ok {
+			continue
+		}
+		if _, ok := stoppedByUser[creator]; !ok {
+			users = append(users, creator)
+		}
+		stoppedByUser[creator] = append(stoppedByUser[creator], devWorkspace)
+	}
+
+	var excess []client.Object
+	for _, user := range users {
+		stopped := stoppedByUser[user]
+		if len(stopped) <= maxStoppedPerUser {
+			continue
+		}
+		// Keep the most recently active DevWorkspaces
+		sort.SliceStable(stopped, func(i, j int) bool {
+			iActivity, _ := getLastActivity(stopped[i])
+			jActivity, _ := getLastActivity(stopped[j])
+			return iActivity.After(jActivity)
+		})
+		for _, devWorkspace := range stopped[maxStoppedPerUser:] {
+			log.Info(fmt.Sprintf("DevWorkspace '%s/%s' is eligible for pruning: user has more than %d stopped DevWorkspaces",
+				devWorkspace.Namespace, devWorkspace.Name, maxStoppedPerUser))
+			excess = append(excess, devWorkspace)
+		}
+	}
+	return excess
+}
+
+// getLastActivity returns the last time the DevWorkspace was started or stopped, i.e. the lastTransitionTime of its
+// 'Started' condition.
+func getLastActivity(dw *dwv2.DevWorkspace) (time.Time, bool) {
+	startedCondition := conditions.GetConditionByType(dw.Status.Conditions, conditions.Started)
+	if startedCondition == nil {
+		return time.Time{}, false
+	}
+	return startedCondition.LastTransitionTime.Time, true
+}
+
+// isPruneProtected returns whether the DevWorkspace is exempt from pruning through the prune-protected annotation.
+func isPruneProtected(dw *dwv2.DevWorkspace) bool {
+	return dw.Annotations[constants.DevWorkspacePruneProtectedAnnotation] == "true"
+}
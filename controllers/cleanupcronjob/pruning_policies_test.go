@@ -0,0 +1,180 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+)
+
+var _ = Describe("Pruning policies", func() {
+	var log logr.Logger
+
+	BeforeEach(func() {
+		log = zap.New(zap.UseDevMode(true)).WithName("pruningPolicies")
+	})
+
+	inactiveFor := func(name, namespace string, inactivity time.Duration) *dwv2.DevWorkspace {
+		return createDevWorkspace(name, namespace, false, metav1.NewTime(time.Now().Add(-inactivity)))
+	}
+
+	names := func(objs []client.Object) []string {
+		var result []string
+		for _, obj := range objs {
+			result = append(result, obj.GetName())
+		}
+		return result
+	}
+
+	Describe("getPruneOptions", func() {
+		It("Converts the pruning configuration", func() {
+			opts, err := getPruneOptions(&controllerv1alpha1.CleanupCronJobConfig{
+				RetainTime:                  pointer.Int32(3600),
+				DryRun:                      pointer.Bool(true),
+				MaxStoppedWorkspacesPerUser: pointer.Int32(2),
+				Policies: []controllerv1alpha1.PruningPolicy{
+					{Namespace: "ns", RetainTime: 60},
+				},
+			}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(opts.retainTime).To(Equal(time.Hour))
+			Expect(opts.dryRun).To(BeTrue())
+			Expect(opts.maxStoppedPerUser).To(Equal(2))
+			Expect(opts.policies).To(HaveLen(1))
+			Expect(opts.policies[0].retainTime).To(Equal(time.Minute))
+		})
+
+		It("Returns an error for an invalid selector", func() {
+			_, err := getPruneOptions(&controllerv1alpha1.CleanupCronJobConfig{
+				RetainTime: pointer.Int32(3600),
+				Policies: []controllerv1alpha1.PruningPolicy{{
+					Selector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Invalid"}},
+					},
+					RetainTime: 60,
+				}},
+			}, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("getRetainTime", func() {
+		It("Uses the first matching policy", func() {
+			opts, err := getPruneOptions(&controllerv1alpha1.CleanupCronJobConfig{
+				RetainTime: pointer.Int32(3600),
+				Policies: []controllerv1alpha1.PruningPolicy{
+					{Namespace: "ci", RetainTime: 60},
+					{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}, RetainTime: 120},
+					{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}, RetainTime: 180},
+				},
+			}, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			ciWorkspace := inactiveFor("ci-dw", "ci", 0)
+			ciWorkspace.Labels = map[string]string{"team": "a"}
+			teamWorkspace := inactiveFor("team-dw", "user-ns", 0)
+			teamWorkspace.Labels = map[string]string{"team": "a"}
+			otherWorkspace := inactiveFor("other-dw", "user-ns", 0)
+
+			Expect(opts.getRetainTime(ciWorkspace)).To(Equal(time.Minute))
+			Expect(opts.getRetainTime(teamWorkspace)).To(Equal(2 * time.Minute))
+			Expect(opts.getRetainTime(otherWorkspace)).To(Equal(time.Hour))
+		})
+	})
+
+	Describe("selectDevWorkspacesToPrune", func() {
+		It("Applies per-policy retain times", func() {
+			opts := &pruneOptions{
+				retainTime: time.Hour,
+				policies:   []pruningPolicy{{namespace: "ci", selector: labels.Everything(), retainTime: time.Minute}},
+			}
+			objs := []client.Object{
+				inactiveFor("ci-dw", "ci", 5*time.Minute),
+				inactiveFor("user-dw", "user-ns", 5*time.Minute),
+			}
+			Expect(names(selectDevWorkspacesToPrune(objs, opts, nil, log))).To(Equal([]string{"ci-dw"}))
+		})
+
+		It("Does not prune protected DevWorkspaces", func() {
+			protected := inactiveFor("protected-dw", "test-ns", 2*time.Hour)
+			protected.Annotations = map[string]string{constants.DevWorkspacePruneProtectedAnnotation: "true"}
+			objs := []client.Object{protected, inactiveFor("dw", "test-ns", 2*time.Hour)}
+
+			Expect(names(selectDevWorkspacesToPrune(objs, &pruneOptions{retainTime: time.Hour}, nil, log))).To(Equal([]string{"dw"}))
+		})
+
+		It("Prunes the least recently active stopped DevWorkspaces of users above the limit", func() {
+			owned := func(name, creator string, inactivity time.Duration) *dwv2.DevWorkspace {
+				workspace := inactiveFor(name, "test-ns", inactivity)
+				workspace.Labels = map[string]string{constants.DevWorkspaceCreatorLabel: creator}
+				return workspace
+			}
+			protected := owned("user1-protected", "user1", 4*time.Minute)
+			protected.Annotations = map[string]string{constants.DevWorkspacePruneProtectedAnnotation: "true"}
+			running := createDevWorkspace("user1-running", "test-ns", true, metav1.Now())
+			running.Labels = map[string]string{constants.DevWorkspaceCreatorLabel: "user1"}
+			objs := []client.Object{
+				owned("user1-recent", "user1", time.Minute),
+				owned("user1-older", "user1", 2*time.Minute),
+				owned("user1-oldest", "user1", 3*time.Minute),
+				protected,
+				running,
+				owned("user2-old", "user2", 3*time.Minute),
+				owned("user2-recent", "user2", time.Minute),
+				inactiveFor("no-creator", "test-ns", 5*time.Minute),
+			}
+			opts := &pruneOptions{retainTime: time.Hour, maxStoppedPerUser: 1}
+
+			Expect(names(selectDevWorkspacesToPrune(objs, opts, nil, log))).To(Equal([]string{"user1-oldest", "user2-old", "user1-older"}))
+		})
+
+		It("Does not count DevWorkspaces that exceeded their retain time towards the limit", func() {
+			owned := func(name string, inactivity time.Duration) *dwv2.DevWorkspace {
+				workspace := inactiveFor(name, "test-ns", inactivity)
+				workspace.Labels = map[string]string{constants.DevWorkspaceCreatorLabel: "user1"}
+				return workspace
+			}
+			objs := []client.Object{
+				owned("expired", 2*time.Hour),
+				owned("recent", time.Minute),
+				owned("older", 2*time.Minute),
+			}
+			opts := &pruneOptions{retainTime: time.Hour, maxStoppedPerUser: 2}
+
+			Expect(names(selectDevWorkspacesToPrune(objs, opts, nil, log))).To(Equal([]string{"expired"}))
+		})
+
+		It("Orders DevWorkspaces to prune by last activity", func() {
+			objs := []client.Object{
+				inactiveFor("dw1", "test-ns", 2*time.Hour),
+				inactiveFor("dw2", "test-ns", 4*time.Hour),
+				inactiveFor("dw3", "test-ns", 3*time.Hour),
+			}
+			Expect(names(selectDevWorkspacesToPrune(objs, &pruneOptions{retainTime: time.Hour}, nil, log))).To(Equal([]string{"dw2", "dw3", "dw1"}))
+		})
+	})
+})
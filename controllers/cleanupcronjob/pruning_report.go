@@ -0,0 +1,175 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/config"
+	"github.com/devfile/devworkspace-operator/pkg/infrastructure"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxPruningRunReports is the number of pruning runs kept in the status of the DevWorkspaceOperatorConfig
+	maxPruningRunReports = 10
+	// maxReportedDeletedDevWorkspaces is the number of deleted DevWorkspaces listed in a pruning run report
+	maxReportedDeletedDevWorkspaces = 100
+)
+
+// Reasons for not deleting a DevWorkspace, as reported in pruning run reports
+const (
+	skipReasonStarted                  = "Started"
+	skipReasonMissingStartedCondition  = "MissingStartedCondition"
+	skipReasonProtected                = "Protected"
+	skipReasonWithinRetainTime         = "WithinRetainTime"
+	skipReasonBelowMinimumStorageUsage = "BelowMinimumStorageUsage"
+	skipReasonGracePeriod              = "GracePeriod"
+	skipReasonDeletionCancelled        = "DeletionCancelled"
+)
+
+// pruningRun collects the outcome of a run of DevWorkspace pruning. All methods can be called on a nil pruningRun, in
+// which case nothing is collected.
+type pruningRun struct {
+	startTime metav1.Time
+	dryRun    bool
+	// selected are the DevWorkspaces selected for deletion
+	selected   []client.Object
+	candidates int
+	scheduled  int
+	// backups is the number of DevWorkspaces for which a backup was requested
+	backups int
+	// skipped maps DevWorkspaces that are not deleted to the reason they are skipped
+	skipped map[client.Object]string
+	errors  []string
+}
+
+func newPruningRun(dryRun bool) *pruningRun {
+	return &pruningRun{
+		startTime: metav1.Now(),
+		dryRun:    dryRun,
+		skipped:   map[client.Object]string{},
+	}
+}
+
+// skip records that a DevWorkspace is not deleted for reason, replacing any reason recorded previously.
+func (run *pruningRun) skip(obj client.Object, reason string) {
+	if run == nil {
+		return
+	}
+	run.skipped[obj] = reason
+}
+
+// setCandidates records the DevWorkspaces eligible for pruning. Reasons recorded previously for skipping them are
+// discarded.
+func (run *pruningRun) setCandidates(objs []client.Object) {
+	if run == nil {
+		return
+	}
+	run.candidates = len(objs)
+	for _, obj := range objs {
+		delete(run.skipped, obj)
+	}
+}
+
+// setSelected records the DevWorkspaces selected for deletion.
+func (run *pruningRun) setSelected(objs []client.Object) {
+	if run == nil {
+		return
+	}
+	run.selected = objs
+}
+
+// scheduledForDeletion records that a DevWorkspace was scheduled for deletion after a grace period.
+func (run *pruningRun) scheduledForDeletion(obj client.Object) {
+	if run == nil {
+		return
+	}
+	run.scheduled++
+	run.skipped[obj] = skipReasonGracePeriod
+}
+
+// backupRequested records that a backup was requested for a DevWorkspace that was not backed up since it was last
+// stopped.
+func (run *pruningRun) backupRequested() {
+	if run == nil {
+		return
+	}
+	run.backups++
+}
+
+func (run *pruningRun) addError(err error) {
+	if run == nil {
+		return
+	}
+	run.errors = append(run.errors, err.Error())
+}
+
+// report returns the report of the pruning run, given the DevWorkspaces that were deleted.
+func (run *pruningRun) report(deleted []client.Object) *controllerv1alpha1.PruningRunReport {
+	completionTime := metav1.Now()
+	report := &controllerv1alpha1.PruningRunReport{
+		StartTime:            run.startTime,
+		CompletionTime:       &completionTime,
+		DryRun:               run.dryRun,
+		Candidates:           int32(run.candidates),
+		ScheduledForDeletion: int32(run.scheduled),
+		BackupsRequested:     int32(run.backups),
+		DeletedCount:         int32(len(deleted)),
+		Errors:               run.errors,
+	}
+	for idx, obj := range deleted {
+		if idx >= maxReportedDeletedDevWorkspaces {
+			break
+		}
+		report.Deleted = append(report.Deleted, controllerv1alpha1.PrunedDevWorkspace{
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+		})
+	}
+	for _, reason := range run.skipped {
+		if report.Skipped == nil {
+			report.Skipped = map[string]int32{}
+		}
+		report.Skipped[reason]++
+	}
+	return report
+}
+
+// recordPruningRun adds the report of a pruning run to the status of the DevWorkspaceOperatorConfig, keeping the
+// maxPruningRunReports most recent reports.
+func (r *CleanupCronJobReconciler) recordPruningRun(ctx context.Context, report *controllerv1alpha1.PruningRunReport) error {
+	operatorNamespace, err := infrastructure.GetNamespace()
+	if err != nil {
+		return err
+	}
+	dwOperatorConfig := &controllerv1alpha1.DevWorkspaceOperatorConfig{}
+	if err := r.Get(ctx, client.ObjectKey{Name: config.OperatorConfigName, Namespace: operatorNamespace}, dwOperatorConfig); err != nil {
+		return fmt.Errorf("failed to get DevWorkspaceOperatorConfig: %w", err)
+	}
+
+	origConfig := client.MergeFrom(dwOperatorConfig.DeepCopy())
+	if dwOperatorConfig.Status == nil {
+		dwOperatorConfig.Status = &controllerv1alpha1.OperatorConfigurationStatus{}
+	}
+	runs := append([]controllerv1alpha1.PruningRunReport{*report}, dwOperatorConfig.Status.PruningRuns...)
+	if len(runs) > maxPruningRunReports {
+		runs = runs[:maxPruningRunReports]
+	}
+	dwOperatorConfig.Status.PruningRuns = runs
+	return r.Status().Patch(ctx, dwOperatorConfig, origConfig)
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/config"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/infrastructure"
+)
+
+var _ = Describe("Pruning run reports", func() {
+	const operatorNamespace = "devworkspace-controller"
+
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		reconciler CleanupCronJobReconciler
+		log        logr.Logger
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(controllerv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(dwv2.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&controllerv1alpha1.DevWorkspaceOperatorConfig{}).Build()
+		log = zap.New(zap.UseDevMode(true)).WithName("pruningReport")
+		reconciler = CleanupCronJobReconciler{
+			Client: fakeClient,
+			Log:    log,
+			Scheme: scheme,
+		}
+
+		origWatchNamespace := os.Getenv(infrastructure.WatchNamespaceEnvVar)
+		Expect(os.Setenv(infrastructure.WatchNamespaceEnvVar, operatorNamespace)).To(Succeed())
+		DeferCleanup(os.Setenv, infrastructure.WatchNamespaceEnvVar, origWatchNamespace)
+
+		Expect(fakeClient.Create(ctx, &controllerv1alpha1.DevWorkspaceOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: config.OperatorConfigName, Namespace: operatorNamespace},
+		})).To(Succeed())
+	})
+
+	createWorkspace := func(name, namespace string, started bool, inactivity time.Duration) *dwv2.DevWorkspace {
+		workspace := createDevWorkspace(name, namespace, started, metav1.NewTime(time.Now().Add(-inactivity)))
+		Expect(fakeClient.Create(ctx, workspace)).To(Succeed())
+		return workspace
+	}
+
+	getPruningRuns := func() []controllerv1alpha1.PruningRunReport {
+		dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: config.OperatorConfigName, Namespace: operatorNamespace}, dwoc)).To(Succeed())
+		Expect(dwoc.Status).ToNot(BeNil())
+		return dwoc.Status.PruningRuns
+	}
+
+	It("Records pruned and skipped DevWorkspaces in the DevWorkspaceOperatorConfig status", func() {
+		createWorkspace("stale", "report-ns", false, 2*time.Hour)
+		createWorkspace("recent", "report-ns", false, time.Minute)
+		createWorkspace("running", "report-ns", true, 2*time.Hour)
+		protected := createDevWorkspace("protected", "report-ns", false, metav1.NewTime(time.Now().Add(-2*time.Hour)))
+		protected.Annotations = map[string]string{constants.DevWorkspacePruneProtectedAnnotation: "true"}
+		Expect(fakeClient.Create(ctx, protected)).To(Succeed())
+		prunedBefore := testutil.ToFloat64(prunedDevWorkspaces.WithLabelValues("report-ns", "false"))
+
+		Expect(reconciler.pruneDevWorkspaces(ctx, &pruneOptions{retainTime: time.Hour}, log)).To(Succeed())
+
+		runs := getPruningRuns()
+		Expect(runs).To(HaveLen(1))
+		Expect(runs[0].DryRun).To(BeFalse())
+		Expect(runs[0].CompletionTime).ToNot(BeNil())
+		Expect(runs[0].Candidates).To(Equal(int32(1)))
+		Expect(runs[0].DeletedCount).To(Equal(int32(1)))
+		Expect(runs[0].Deleted).To(Equal([]controllerv1alpha1.PrunedDevWorkspace{{Name: "stale", Namespace: "report-ns"}}))
+		Expect(runs[0].Skipped).To(Equal(map[string]int32{
+			skipReasonWithinRetainTime: 1,
+			skipReasonStarted:          1,
+			skipReasonProtected:        1,
+		}))
+		Expect(runs[0].Errors).To(BeEmpty())
+		Expect(testutil.ToFloat64(prunedDevWorkspaces.WithLabelValues("report-ns", "false")) - prunedBefore).To(Equal(float64(1)))
+	})
+
+	It("Reports DevWorkspaces that would have been deleted in dry-run mode", func() {
+		createWorkspace("stale", "dry-run-ns", false, 2*time.Hour)
+
+		Expect(reconciler.pruneDevWorkspaces(ctx, &pruneOptions{retainTime: time.Hour, dryRun: true}, log)).To(Succeed())
+
+		runs := getPruningRuns()
+		Expect(runs).To(HaveLen(1))
+		Expect(runs[0].DryRun).To(BeTrue())
+		Expect(runs[0].DeletedCount).To(Equal(int32(1)))
+		Expect(runs[0].Deleted).To(Equal([]controllerv1alpha1.PrunedDevWorkspace{{Name: "stale", Namespace: "dry-run-ns"}}))
+		workspaces := &dwv2.DevWorkspaceList{}
+		Expect(fakeClient.List(ctx, workspaces)).To(Succeed())
+		Expect(workspaces.Items).To(HaveLen(1))
+	})
+
+	It("Does not count DevWorkspaces selected by the per-user limit as skipped", func() {
+		for idx := 0; idx < 3; idx++ {
+			workspace := createDevWorkspace(fmt.Sprintf("dw%d", idx), "limit-ns", false, metav1.NewTime(time.Now().Add(-time.Duration(idx+1)*time.Minute)))
+			workspace.Labels = map[string]string{constants.DevWorkspaceCreatorLabel: "user1"}
+			Expect(fakeClient.Create(ctx, workspace)).To(Succeed())
+		}
+
+		Expect(reconciler.pruneDevWorkspaces(ctx, &pruneOptions{retainTime: time.Hour, maxStoppedPerUser: 1}, log)).To(Succeed())
+
+		runs := getPruningRuns()
+		Expect(runs).To(HaveLen(1))
+		Expect(runs[0].Candidates).To(Equal(int32(2)))
+		Expect(runs[0].DeletedCount).To(Equal(int32(2)))
+		Expect(runs[0].Skipped).To(Equal(map[string]int32{skipReasonWithinRetainTime: 1}))
+	})
+
+	It("Keeps only the most recent pruning runs", func() {
+		for idx := 0; idx < maxPruningRunReports+2; idx++ {
+			Expect(reconciler.pruneDevWorkspaces(ctx, &pruneOptions{retainTime: time.Hour}, log)).To(Succeed())
+		}
+		Expect(getPruningRuns()).To(HaveLen(maxPruningRunReports))
+	})
+})
@@ -0,0 +1,386 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/conditions"
+	"github.com/devfile/devworkspace-operator/pkg/config"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/provision/storage"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+const (
+	// storageUsageSourceKubelet is the reason set on the StorageUsage condition when usage was read from
+	// the kubelet stats summary of the node the workspace's PVC is mounted on.
+	storageUsageSourceKubelet = "KubeletStats"
+	// storageUsageSourceJob is the reason set on the StorageUsage condition when usage was computed by a
+	// storage usage job for the workspace's directory in a common PVC.
+	storageUsageSourceJob = "StorageUsageJob"
+)
+
+// NodeStatsClient reads the kubelet stats summary (/stats/summary) of cluster nodes.
+type NodeStatsClient interface {
+	GetNodeStatsSummary(ctx context.Context, nodeName string) ([]byte, error)
+}
+
+type kubeletNodeStatsClient struct {
+	restClient rest.Interface
+}
+
+// NewNodeStatsClient returns a NodeStatsClient that reads the kubelet stats summary through the API server's
+// node proxy.
+func NewNodeStatsClient(cfg *rest.Config) (NodeStatsClient, error) {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &kubeletNodeStatsClient{restClient: clientset.CoreV1().RESTClient()}, nil
+}
+
+func (c *kubeletNodeStatsClient) GetNodeStatsSummary(ctx context.Context, nodeName string) ([]byte, error) {
+	return c.restClient.Get().Resource("nodes").Name(nodeName).SubResource("proxy").Suffix("stats/summary").DoRaw(ctx)
+}
+
+// collectStorageUsage collects the storage usage of all DevWorkspaces on the cluster and records it in the
+// DevWorkspace's StorageUsage condition and storage usage annotation, and in the storage usage metric.
+//
+// The usage of workspace directories in common PVCs is computed by a storage usage job for each common PVC. By default,
+// the usage of per-workspace PVCs is computed by a storage usage job for each PVC as well. If reading kubelet stats is
+// enabled, the usage of per-workspace PVCs is instead read from the kubelet stats summary of the nodes running
+// DevWorkspace pods, and is therefore only updated while a DevWorkspace is running. DevWorkspaces for which no usage
+// could be collected keep their previously recorded usage.
+func (r *CleanupCronJobReconciler) collectStorageUsage(ctx context.Context, logger logr.Logger) error {
+	log := logger.WithName("storageUsage")
+
+	operatorConfig := config.GetGlobalConfig()
+	useKubeletStats := isKubeletStatsEnabled(operatorConfig.Workspace.CleanupCronJob)
+
+	workspaces := &dwv2.DevWorkspaceList{}
+	if err := r.Client.List(ctx, workspaces); err != nil {
+		return fmt.Errorf("failed to list DevWorkspaces: %w", err)
+	}
+
+	var pvcUsage map[types.NamespacedName]int64
+	if useKubeletStats {
+		var err error
+		pvcUsage, err = r.getPVCUsageFromNodeStats(ctx, log)
+		if err != nil {
+			return fmt.Errorf("failed to read storage usage from nodes: %w", err)
+		}
+	}
+
+	commonWorkspaceIds := map[string][]string{}
+	perWorkspaceIds := map[string][]string{}
+	for _, workspace := range workspaces.Items {
+		if workspace.Status.DevWorkspaceId == "" {
+			continue
+		}
+		if usesCommonPVC(&workspace) {
+			commonWorkspaceIds[workspace.Namespace] = append(commonWorkspaceIds[workspace.Namespace], workspace.Status.DevWorkspaceId)
+		} else if !useKubeletStats && getStorageType(&workspace) == constants.PerWorkspaceStorageClassType {
+			perWorkspaceIds[workspace.Namespace] = append(perWorkspaceIds[workspace.Namespace], workspace.Status.DevWorkspaceId)
+		}
+	}
+	jobUsage, err := r.getStorageUsageFromJobs(ctx, commonWorkspaceIds, perWorkspaceIds, operatorConfig, log)
+	if err != nil {
+		return fmt.Errorf("failed to collect storage usage of PVCs: %w", err)
+	}
+
+	storageUsageBytes.Reset()
+	for idx := range workspaces.Items {
+		workspace := &workspaces.Items[idx]
+		workspaceId := workspace.Status.DevWorkspaceId
+		storageType := getStorageType(workspace)
+		if workspaceId == "" || storageType == constants.EphemeralStorageClassType {
+			continue
+		}
+
+		var usage int64
+		var found bool
+		var source string
+		if usesCommonPVC(workspace) || !useKubeletStats {
+			usage, found = jobUsage[workspace.Namespace][workspaceId]
+			source = storageUsageSourceJob
+		} else {
+			usage, found = pvcUsage[types.NamespacedName{Name: common.PerWorkspacePVCName(workspaceId), Namespace: workspace.Namespace}]
+			source = storageUsageSourceKubelet
+		}
+
+		if !found {
+			if lastUsage, ok := getRecordedStorageUsage(workspace); ok {
+				storageUsageBytes.WithLabelValues(workspace.Namespace, storageType).Add(float64(lastUsage))
+			}
+			continue
+		}
+		storageUsageBytes.WithLabelValues(workspace.Namespace, storageType).Add(float64(usage))
+		if err := r.recordStorageUsage(ctx, workspace, usage, source); err != nil {
+			log.Error(err, "Failed to record storage usage", "namespace", workspace.Namespace, "devworkspace", workspace.Name)
+			storageUsageCollectionFailures.Inc()
+		}
+	}
+
+	return nil
+}
+
+// getPVCUsageFromNodeStats returns the usage of PVCs mounted on nodes that run DevWorkspace pods, as reported by the
+// kubelet stats summary of each node. Nodes whose stats cannot be read are skipped.
+func (r *CleanupCronJobReconciler) getPVCUsageFromNodeStats(ctx context.Context, log logr.Logger) (map[types.NamespacedName]int64, error) {
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods, client.HasLabels{constants.DevWorkspaceIDLabel}); err != nil {
+		return nil, err
+	}
+	nodeNames := map[string]bool{}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" && pod.Status.Phase == corev1.PodRunning {
+			nodeNames[pod.Spec.NodeName] = true
+		}
+	}
+
+	pvcUsage := map[types.NamespacedName]int64{}
+	for nodeName := range nodeNames {
+		summary, err := r.NodeStatsClient.GetNodeStatsSummary(ctx, nodeName)
+		if err != nil {
+			log.Error(err, "Failed to read kubelet stats summary", "node", nodeName)
+			storageUsageCollectionFailures.Inc()
+			continue
+		}
+		nodeUsage, err := storage.ParsePVCUsageFromNodeStatsSummary(summary)
+		if err != nil {
+			log.Error(err, "Failed to parse kubelet stats summary", "node", nodeName)
+			storageUsageCollectionFailures.Inc()
+			continue
+		}
+		for pvc, usage := range nodeUsage {
+			pvcUsage[pvc] = usage
+		}
+	}
+	return pvcUsage, nil
+}
+
+// getStorageUsageFromJobs runs storage usage jobs for each common PVC in a namespace that contains DevWorkspaces
+// using common storage, and for the per-workspace PVC of each DevWorkspace in perWorkspaceIds. Returns the collected
+// usage by namespace and DevWorkspace ID. If a DevWorkspace has directories in multiple common PVCs, their usage is
+// summed.
+//
+// Jobs are run in rounds, each of which runs at most one job per PVC. A common PVC needs multiple jobs if it contains
+// more workspace directories than a single job can report; these jobs share a name and are run in consecutive rounds.
+func (r *CleanupCronJobReconciler) getStorageUsageFromJobs(ctx context.Context, commonWorkspaceIds, perWorkspaceIds map[string][]string,
+	operatorConfig *controllerv1alpha1.OperatorConfiguration, log logr.Logger) (map[string]map[string]int64, error) {
+	pvcs, err := r.getCommonPVCs(ctx, operatorConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterAPI := sync.ClusterAPI{
+		Ctx:    ctx,
+		Client: r.Client,
+		Scheme: r.Scheme,
+		Logger: log,
+	}
+	var rounds [][]*batchv1.Job
+	addJob := func(round int, job *batchv1.Job) {
+		for len(rounds) <= round {
+			rounds = append(rounds, nil)
+		}
+		rounds[round] = append(rounds[round], job)
+	}
+
+	for idx := range pvcs {
+		pvc := &pvcs[idx]
+		if len(commonWorkspaceIds[pvc.Namespace]) == 0 {
+			continue
+		}
+		jobs, err := storage.GetStorageUsageJobs(pvc, commonWorkspaceIds[pvc.Namespace], operatorConfig, clusterAPI)
+		if err != nil {
+			log.Error(err, "Failed to create storage usage job", "namespace", pvc.Namespace, "pvc", pvc.Name)
+			storageUsageCollectionFailures.Inc()
+			continue
+		}
+		for round, job := range jobs {
+			addJob(round, job)
+		}
+	}
+
+	for namespace, workspaceIds := range perWorkspaceIds {
+		for _, workspaceId := range workspaceIds {
+			pvc := &corev1.PersistentVolumeClaim{}
+			pvcNamespacedName := types.NamespacedName{Name: common.PerWorkspacePVCName(workspaceId), Namespace: namespace}
+			if err := r.Client.Get(ctx, pvcNamespacedName, pvc); err != nil {
+				if !k8sErrors.IsNotFound(err) {
+					log.Error(err, "Failed to get per-workspace PVC", "namespace", namespace, "pvc", pvcNamespacedName.Name)
+					storageUsageCollectionFailures.Inc()
+				}
+				continue
+			}
+			if pvc.DeletionTimestamp != nil {
+				continue
+			}
+			job, err := storage.GetPerWorkspaceStorageUsageJob(pvc, workspaceId, operatorConfig, clusterAPI)
+			if err != nil {
+				log.Error(err, "Failed to create storage usage job", "namespace", pvc.Namespace, "pvc", pvc.Name)
+				storageUsageCollectionFailures.Inc()
+				continue
+			}
+			addJob(0, job)
+		}
+	}
+
+	usage := map[string]map[string]int64{}
+	for _, jobs := range rounds {
+		r.runStorageUsageJobs(ctx, jobs, usage, log)
+	}
+	return usage, nil
+}
+
+// runStorageUsageJobs starts jobs, waits for each of them to finish and adds the usage they report to usage, by
+// namespace and DevWorkspace ID. Jobs are deleted once they finished.
+func (r *CleanupCronJobReconciler) runStorageUsageJobs(ctx context.Context, jobs []*batchv1.Job, usage map[string]map[string]int64, log logr.Logger) {
+	var startedJobs []*batchv1.Job
+	for _, job := range jobs {
+		if err := r.recreateJob(ctx, job); err != nil {
+			log.Error(err, "Failed to start storage usage job", "namespace", job.Namespace, "job", job.Name)
+			storageUsageCollectionFailures.Inc()
+			continue
+		}
+		startedJobs = append(startedJobs, job)
+	}
+
+	for _, job := range startedJobs {
+		jobLog := log.WithValues("namespace", job.Namespace, "job", job.Name)
+		message, err := r.waitForJobTerminationMessage(ctx, job)
+		var result *storage.StorageUsageResult
+		if err == nil {
+			result, err = storage.ParseStorageUsageResult(message)
+		}
+		if err != nil {
+			jobLog.Error(err, "Storage usage job did not succeed")
+			storageUsageCollectionFailures.Inc()
+		} else {
+			if usage[job.Namespace] == nil {
+				usage[job.Namespace] = map[string]int64{}
+			}
+			for workspaceId, bytes := range result.Usage {
+				usage[job.Namespace][workspaceId] += bytes
+			}
+		}
+		if err := r.deleteJob(ctx, job); err != nil {
+			jobLog.Error(err, "Failed to delete storage usage job")
+		}
+	}
+}
+
+// recordStorageUsage stores the storage usage of a DevWorkspace in its storage usage annotation and StorageUsage
+// condition, if it changed since the last collection.
+func (r *CleanupCronJobReconciler) recordStorageUsage(ctx context.Context, workspace *dwv2.DevWorkspace, usage int64, source string) error {
+	usageValue := strconv.FormatInt(usage, 10)
+	if workspace.Annotations[constants.DevWorkspaceStorageUsageAnnotation] != usageValue {
+		patch := client.MergeFrom(workspace.DeepCopy())
+		if workspace.Annotations == nil {
+			workspace.Annotations = map[string]string{}
+		}
+		workspace.Annotations[constants.DevWorkspaceStorageUsageAnnotation] = usageValue
+		if err := r.Patch(ctx, workspace, patch); err != nil {
+			return err
+		}
+	}
+
+	storageUsageCondition := dwv2.DevWorkspaceCondition{
+		Type:               conditions.StorageUsage,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             source,
+		Message:            fmt.Sprintf("DevWorkspace uses %s of storage", storage.FormatStorageUsage(usage)),
+	}
+	var newConditions []dwv2.DevWorkspaceCondition
+	for _, condition := range workspace.Status.Conditions {
+		if condition.Type != conditions.StorageUsage {
+			newConditions = append(newConditions, condition)
+			continue
+		}
+		if condition.Reason == storageUsageCondition.Reason && condition.Message == storageUsageCondition.Message {
+			return nil
+		}
+	}
+	newConditions = append(newConditions, storageUsageCondition)
+
+	// Use optimistic locking, as conditions are also updated by the DevWorkspace controller
+	statusPatch := client.MergeFromWithOptions(workspace.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	workspace.Status.Conditions = newConditions
+	return r.Status().Patch(ctx, workspace, statusPatch)
+}
+
+// getRecordedStorageUsage returns the storage usage last recorded for a DevWorkspace, if any.
+func getRecordedStorageUsage(workspace *dwv2.DevWorkspace) (int64, bool) {
+	value, ok := workspace.Annotations[constants.DevWorkspaceStorageUsageAnnotation]
+	if !ok {
+		return 0, false
+	}
+	usage, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return usage, true
+}
+
+// getStorageType returns the storage type used by a DevWorkspace, defaulting to common storage if the storage type
+// attribute is not set.
+func getStorageType(workspace *dwv2.DevWorkspace) string {
+	storageType := workspace.Spec.Template.Attributes.GetString(constants.DevWorkspaceStorageTypeAttribute, nil)
+	if storageType == "" {
+		return constants.CommonStorageClassType
+	}
+	return storageType
+}
+
+// usesCommonPVC returns whether a DevWorkspace stores its data in a workspace directory within a common PVC.
+func usesCommonPVC(workspace *dwv2.DevWorkspace) bool {
+	switch getStorageType(workspace) {
+	case constants.CommonStorageClassType, constants.PerUserStorageClassType, constants.AsyncStorageClassType:
+		return true
+	default:
+		return false
+	}
+}
+
+// isKubeletStatsEnabled returns whether the usage of per-workspace PVCs should be read from the kubelet stats summary
+// of nodes instead of being computed by storage usage jobs.
+func isKubeletStatsEnabled(cleanupConfig *controllerv1alpha1.CleanupCronJobConfig) bool {
+	return cleanupConfig.StorageUsage != nil &&
+		cleanupConfig.StorageUsage.KubeletStats != nil &&
+		*cleanupConfig.StorageUsage.KubeletStats
+}
+
+func isStorageUsageCollectionEnabled(cleanupConfig *controllerv1alpha1.CleanupCronJobConfig) bool {
+	return cleanupConfig.StorageUsage != nil &&
+		cleanupConfig.StorageUsage.Enable != nil &&
+		*cleanupConfig.StorageUsage.Enable
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/robfig/cron/v3"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/conditions"
+	"github.com/devfile/devworkspace-operator/pkg/config"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+)
+
+type fakeNodeStatsClient struct {
+	summaries map[string]string
+	requests  int
+}
+
+func (c *fakeNodeStatsClient) GetNodeStatsSummary(_ context.Context, nodeName string) ([]byte, error) {
+	c.requests++
+	summary, ok := c.summaries[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("node %s not found", nodeName)
+	}
+	return []byte(summary), nil
+}
+
+var _ = Describe("Storage usage collection", func() {
+	var (
+		ctx             context.Context
+		fakeClient      client.Client
+		nodeStatsClient *fakeNodeStatsClient
+		reconciler      CleanupCronJobReconciler
+		log             logr.Logger
+	)
+
+	createWorkspace := func(name, workspaceId, storageType string) *dwv2.DevWorkspace {
+		workspace := createDevWorkspace(name, "test-ns", true, metav1.Now())
+		workspace.Status.DevWorkspaceId = workspaceId
+		workspace.Spec.Template.Attributes = attributes.Attributes{}
+		workspace.Spec.Template.Attributes.PutString(constants.DevWorkspaceStorageTypeAttribute, storageType)
+		Expect(fakeClient.Create(ctx, workspace)).To(Succeed())
+		return workspace
+	}
+
+	createWorkspacePod := func(workspaceId, nodeName string) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      workspaceId + "-pod",
+				Namespace: "test-ns",
+				Labels:    map[string]string{constants.DevWorkspaceIDLabel: workspaceId},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+		}
+		Expect(fakeClient.Create(ctx, pod)).To(Succeed())
+		pod.Status.Phase = corev1.PodRunning
+		Expect(fakeClient.Status().Update(ctx, pod)).To(Succeed())
+	}
+
+	getWorkspace := func(name string) *dwv2.DevWorkspace {
+		workspace := &dwv2.DevWorkspace{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "test-ns"}, workspace)).To(Succeed())
+		return workspace
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(controllerv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(dwv2.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(batchv1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&dwv2.DevWorkspace{}, &corev1.Pod{}).Build()
+		log = zap.New(zap.UseDevMode(true)).WithName("cleanupCronJobController")
+		nodeStatsClient = &fakeNodeStatsClient{summaries: map[string]string{}}
+
+		reconciler = CleanupCronJobReconciler{
+			Client:           fakeClient,
+			NonCachingClient: fakeClient,
+			NodeStatsClient:  nodeStatsClient,
+			Log:              log,
+			Scheme:           scheme,
+			cron:             cron.New(),
+		}
+		config.SetGlobalConfigForTesting(&controllerv1alpha1.OperatorConfiguration{
+			Workspace: &controllerv1alpha1.WorkspaceConfig{
+				PVCName: "claim-devworkspace",
+			},
+		})
+	})
+
+	AfterEach(func() {
+		reconciler.stopCron(log)
+	})
+
+	It("Should start cron if only storage usage collection is enabled", func() {
+		cleanupConfig := &controllerv1alpha1.CleanupCronJobConfig{
+			Enable:   pointer.Bool(false),
+			Schedule: "* * * * *",
+			StorageUsage: &controllerv1alpha1.StorageUsageConfig{
+				Enable: pointer.Bool(true),
+			},
+		}
+		reconciler.startCron(ctx, cleanupConfig, log)
+		Expect(reconciler.cron.Entries()).To(HaveLen(1))
+	})
+
+	It("Should not read kubelet stats unless enabled", func() {
+		createWorkspace("per-workspace", "workspace-a", constants.PerWorkspaceStorageClassType)
+		createWorkspacePod("workspace-a", "node-1")
+		nodeStatsClient.summaries["node-1"] = `{"pods":[{"volume":[{"usedBytes":3221225472,"pvcRef":{"name":"storage-workspace-a","namespace":"test-ns"}}]}]}`
+
+		Expect(reconciler.collectStorageUsage(ctx, log)).To(Succeed())
+
+		Expect(nodeStatsClient.requests).To(BeZero())
+		workspace := getWorkspace("per-workspace")
+		Expect(workspace.Annotations).ToNot(HaveKey(constants.DevWorkspaceStorageUsageAnnotation))
+		Expect(conditions.GetConditionByType(workspace.Status.Conditions, conditions.StorageUsage)).To(BeNil())
+	})
+
+	It("Should record usage of per-workspace PVCs from kubelet stats", func() {
+		config.SetGlobalConfigForTesting(&controllerv1alpha1.OperatorConfiguration{
+			Workspace: &controllerv1alpha1.WorkspaceConfig{
+				PVCName: "claim-devworkspace",
+				CleanupCronJob: &controllerv1alpha1.CleanupCronJobConfig{
+					StorageUsage: &controllerv1alpha1.StorageUsageConfig{
+						KubeletStats: pointer.Bool(true),
+					},
+				},
+			},
+		})
+		createWorkspace("per-workspace", "workspace-a", constants.PerWorkspaceStorageClassType)
+		createWorkspacePod("workspace-a", "node-1")
+		nodeStatsClient.summaries["node-1"] = `{"pods":[{"volume":[{"usedBytes":3221225472,"pvcRef":{"name":"storage-workspace-a","namespace":"test-ns"}}]}]}`
+
+		Expect(reconciler.collectStorageUsage(ctx, log)).To(Succeed())
+
+		workspace := getWorkspace("per-workspace")
+		Expect(workspace.Annotations).To(HaveKeyWithValue(constants.DevWorkspaceStorageUsageAnnotation, "3221225472"))
+		condition := conditions.GetConditionByType(workspace.Status.Conditions, conditions.StorageUsage)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(storageUsageSourceKubelet))
+		Expect(condition.Message).To(Equal("DevWorkspace uses 3.0 GiB of storage"))
+		Expect(conditions.GetConditionByType(workspace.Status.Conditions, conditions.Started)).ToNot(BeNil(), "Other conditions should be preserved")
+
+		Expect(testutil.ToFloat64(storageUsageBytes.WithLabelValues("test-ns", constants.PerWorkspaceStorageClassType))).To(Equal(float64(3221225472)))
+	})
+
+	It("Should keep previously recorded usage if usage cannot be collected", func() {
+		workspace := createWorkspace("stopped", "workspace-b", constants.PerWorkspaceStorageClassType)
+		workspace.Annotations = map[string]string{constants.DevWorkspaceStorageUsageAnnotation: "1024"}
+		Expect(fakeClient.Update(ctx, workspace)).To(Succeed())
+		createWorkspacePod("workspace-b", "unreachable-node")
+
+		Expect(reconciler.collectStorageUsage(ctx, log)).To(Succeed())
+
+		workspace = getWorkspace("stopped")
+		Expect(workspace.Annotations).To(HaveKeyWithValue(constants.DevWorkspaceStorageUsageAnnotation, "1024"))
+		Expect(conditions.GetConditionByType(workspace.Status.Conditions, conditions.StorageUsage)).To(BeNil())
+		Expect(testutil.ToFloat64(storageUsageBytes.WithLabelValues("test-ns", constants.PerWorkspaceStorageClassType))).To(Equal(float64(1024)))
+	})
+
+	It("Should not update StorageUsage condition if usage did not change", func() {
+		workspace := createWorkspace("unchanged", "workspace-c", constants.PerWorkspaceStorageClassType)
+		Expect(reconciler.recordStorageUsage(ctx, workspace, 2048, storageUsageSourceKubelet)).To(Succeed())
+		workspace = getWorkspace("unchanged")
+		firstCondition := conditions.GetConditionByType(workspace.Status.Conditions, conditions.StorageUsage)
+		Expect(firstCondition).ToNot(BeNil())
+		resourceVersion := workspace.ResourceVersion
+
+		Expect(reconciler.recordStorageUsage(ctx, workspace, 2048, storageUsageSourceKubelet)).To(Succeed())
+		workspace = getWorkspace("unchanged")
+		Expect(workspace.ResourceVersion).To(Equal(resourceVersion))
+		Expect(workspace.Status.Conditions).To(HaveLen(2))
+	})
+
+	It("Should not prune DevWorkspaces using less than minimum storage usage", func() {
+		small := createDevWorkspace("small", "test-ns", false, metav1.Now())
+		small.Annotations = map[string]string{constants.DevWorkspaceStorageUsageAnnotation: "1024"}
+		large := createDevWorkspace("large", "test-ns", false, metav1.Now())
+		large.Annotations = map[string]string{constants.DevWorkspaceStorageUsageAnnotation: "10737418240"}
+		unknown := createDevWorkspace("unknown", "test-ns", false, metav1.Now())
+
+		minimumUsage := resource.MustParse("1Gi")
+		filtered := filterByStorageUsage([]client.Object{small, large, unknown}, &minimumUsage, nil, log)
+		Expect(filtered).To(ConsistOf(large, unknown))
+
+		Expect(filterByStorageUsage([]client.Object{small, large}, nil, nil, log)).To(HaveLen(2))
+	})
+})
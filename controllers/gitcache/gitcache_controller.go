@@ -0,0 +1,192 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/config"
+	"github.com/devfile/devworkspace-operator/pkg/infrastructure"
+)
+
+// GitCacheReconciler reconciles the git mirror cache configuration and periodically refreshes the git mirrors of
+// repositories that are used by many DevWorkspaces in a namespace.
+type GitCacheReconciler struct {
+	client.Client
+	NonCachingClient client.Client
+	Log              logr.Logger
+	Scheme           *runtime.Scheme
+
+	cron *cron.Cron
+}
+
+// getGitCacheConfig returns the git mirror cache configuration defined in a DevWorkspaceOperatorConfig, if any.
+func getGitCacheConfig(dwoc *controllerv1alpha1.DevWorkspaceOperatorConfig) *controllerv1alpha1.ProjectCloneGitCacheConfig {
+	if dwoc.Config == nil || dwoc.Config.Workspace == nil || dwoc.Config.Workspace.ProjectCloneConfig == nil {
+		return nil
+	}
+	return dwoc.Config.Workspace.ProjectCloneConfig.GitCache
+}
+
+func shouldReconcileOnUpdate(e event.UpdateEvent, log logr.Logger) bool {
+	log.Info("DevWorkspaceOperatorConfig update event received")
+	oldConfig, ok := e.ObjectOld.(*controllerv1alpha1.DevWorkspaceOperatorConfig)
+	if !ok {
+		return false
+	}
+	newConfig, ok := e.ObjectNew.(*controllerv1alpha1.DevWorkspaceOperatorConfig)
+	if !ok {
+		return false
+	}
+	return !equality.Semantic.DeepEqual(getGitCacheConfig(oldConfig), getGitCacheConfig(newConfig))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GitCacheReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	log := r.Log.WithName("setupWithManager")
+	log.Info("Setting up GitCacheReconciler")
+
+	configPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return shouldReconcileOnUpdate(e, log)
+		},
+		CreateFunc:  func(e event.CreateEvent) bool { return true },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return true },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+
+	// Initialize cron scheduler
+	r.cron = cron.New()
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("GitCache").
+		WithOptions(controller.Options{
+			UsePriorityQueue: ptr.To(false),
+		}).
+		Watches(&controllerv1alpha1.DevWorkspaceOperatorConfig{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+				operatorNamespace, err := infrastructure.GetNamespace()
+				// Ignore events from other namespaces
+				if err != nil || object.GetNamespace() != operatorNamespace || object.GetName() != config.OperatorConfigName {
+					log.Info("Received event from different namespace, ignoring", "namespace", object.GetNamespace())
+					return []ctrl.Request{}
+				}
+
+				return []ctrl.Request{
+					{
+						NamespacedName: client.ObjectKey{
+							Name:      object.GetName(),
+							Namespace: object.GetNamespace(),
+						},
+					},
+				}
+			}),
+		).
+		WithEventFilter(configPredicate).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=workspace.devfile.io,resources=devworkspaces,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=controller.devfile.io,resources=devworkspaceoperatorconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;delete
+
+// Reconcile is the main reconciliation loop for the GitCache controller.
+func (r *GitCacheReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log
+	log.Info("Reconciling GitCache", "DWOC", req.NamespacedName)
+
+	dwOperatorConfig := &controllerv1alpha1.DevWorkspaceOperatorConfig{}
+	err := r.Get(ctx, req.NamespacedName, dwOperatorConfig)
+	if err != nil {
+		log.Error(err, "Failed to get DevWorkspaceOperatorConfig")
+		r.stopCron(log)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	gitCacheConfig := getGitCacheConfig(dwOperatorConfig)
+	if gitCacheConfig == nil || gitCacheConfig.Enable == nil || !*gitCacheConfig.Enable {
+		log.Info("Git mirror cache is disabled, stopping cron scheduler and skipping reconciliation")
+		r.stopCron(log)
+		return ctrl.Result{}, nil
+	}
+
+	schedule := gitCacheConfig.Schedule
+	if schedule == "" {
+		schedule = config.GetGlobalConfig().Workspace.ProjectCloneConfig.GitCache.Schedule
+	}
+	r.startCron(ctx, schedule, log)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *GitCacheReconciler) startCron(ctx context.Context, schedule string, logger logr.Logger) {
+	log := logger.WithName("cron")
+	log.Info("Starting cron scheduler", "schedule", schedule)
+
+	// remove existing cronjob tasks
+	// we cannot update the existing tasks, so we need to remove them and add new ones
+	entries := r.cron.Entries()
+	for _, entry := range entries {
+		r.cron.Remove(entry.ID)
+	}
+
+	_, err := r.cron.AddFunc(schedule, func() {
+		taskLog := logger.WithName("cronTask")
+
+		taskLog.Info("Starting git mirror refresh job")
+		if err := r.refreshGitMirrors(ctx, config.GetGlobalConfig(), logger); err != nil {
+			taskLog.Error(err, "Failed to refresh git mirrors")
+		}
+		taskLog.Info("Git mirror refresh job finished")
+	})
+	if err != nil {
+		log.Error(err, "Failed to add cronjob function")
+		return
+	}
+
+	r.cron.Start()
+}
+
+func (r *GitCacheReconciler) stopCron(logger logr.Logger) {
+	log := logger.WithName("cron")
+	log.Info("Stopping cron scheduler")
+
+	// remove existing cronjob tasks
+	entries := r.cron.Entries()
+	for _, entry := range entries {
+		r.cron.Remove(entry.ID)
+	}
+
+	ctx := r.cron.Stop()
+	ctx.Done()
+
+	log.Info("Cron scheduler stopped")
+}
@@ -0,0 +1,278 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/internal/images"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/library/projects"
+	dwResources "github.com/devfile/devworkspace-operator/pkg/library/resources"
+	nsconfig "github.com/devfile/devworkspace-operator/pkg/provision/config"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+// Git mirror refresh jobs report their result through the termination message of their pod. As these jobs are not
+// labelled with a DevWorkspace ID, they are not visible to the cached client and must be managed using the
+// non-caching client.
+
+const (
+	jobPollInterval = 10 * time.Second
+	jobTimeout      = 2 * time.Hour
+
+	gitCacheRefreshContainerName = "git-cache-refresh"
+)
+
+// gitCacheRefreshScript creates or updates a mirror in the git mirror cache for each repository in
+// GIT_CACHE_REPOSITORIES, which contains one '<mirror name> <url>' pair per line. New mirrors are cloned to a temporary
+// directory first, so that project clone containers never use a partially cloned mirror. Mirrors may have been created
+// by a job running as a different user, so ownership checks are disabled when updating them.
+const gitCacheRefreshScript = `
+cd "$GIT_CACHE_PATH" || exit 1
+created=0
+updated=0
+failed=0
+while read -r name url; do
+  [ -n "$name" ] || continue
+  if [ -d "$name" ]; then
+    echo "Updating mirror $name"
+    if git -c safe.directory='*' -C "$name" remote update --prune; then
+      updated=$((updated + 1))
+    else
+      failed=$((failed + 1))
+    fi
+  else
+    echo "Creating mirror $name from $url"
+    mkdir -p "$(dirname "$name")"
+    rm -rf "$name.tmp"
+    if git clone --mirror -- "$url" "$name.tmp" && mv "$name.tmp" "$name"; then
+      created=$((created + 1))
+    else
+      rm -rf "$name.tmp"
+      failed=$((failed + 1))
+    fi
+  fi
+done <<REPOSITORIES
+$GIT_CACHE_REPOSITORIES
+REPOSITORIES
+echo "{\"created\":$created,\"updated\":$updated,\"failed\":$failed}" > /dev/termination-log
+`
+
+// gitCacheRefreshResult is the result reported by a git mirror refresh job through its termination message.
+type gitCacheRefreshResult struct {
+	// Created is the number of mirrors that were created
+	Created int `json:"created"`
+	// Updated is the number of existing mirrors that were updated
+	Updated int `json:"updated"`
+	// Failed is the number of mirrors that could not be created or updated
+	Failed int `json:"failed"`
+}
+
+func parseGitCacheRefreshResult(message string) (*gitCacheRefreshResult, error) {
+	result := &gitCacheRefreshResult{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(message)), result); err != nil {
+		return nil, fmt.Errorf("failed to parse git mirror refresh result: %w", err)
+	}
+	return result, nil
+}
+
+// getGitCacheRefreshJob returns a job that creates or updates the mirrors of repositories in the git mirror cache PVC
+// pvc. The job uses the project clone image, as it provides git and is configured to access the same git servers as
+// the project clone container.
+func getGitCacheRefreshJob(pvc *corev1.PersistentVolumeClaim, repositories []gitRepository,
+	config *controllerv1alpha1.OperatorConfiguration, clusterAPI sync.ClusterAPI) (*batchv1.Job, error) {
+	projectCloneConfig := config.Workspace.ProjectCloneConfig
+
+	image := projectCloneConfig.Image
+	if image == "" {
+		image = images.GetProjectCloneImage()
+	}
+	if image == "" {
+		return nil, fmt.Errorf("project clone image is not defined")
+	}
+	pullPolicy := projectCloneConfig.ImagePullPolicy
+	if pullPolicy == "" {
+		pullPolicy = corev1.PullPolicy(config.Workspace.ImagePullPolicy)
+	}
+	resources := dwResources.FilterResources(projectCloneConfig.Resources)
+
+	var repositoryLines []string
+	for _, repository := range repositories {
+		repositoryLines = append(repositoryLines, fmt.Sprintf("%s %s", repository.MirrorName, repository.URL))
+	}
+
+	jobLabels := map[string]string{
+		constants.DevWorkspaceGitCacheRefreshJobLabel: "true",
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.GitCacheRefreshJobName(pvc.Name),
+			Namespace: pvc.Namespace,
+			Labels:    jobLabels,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:  ptr.To[int32](1),
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: jobLabels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:   corev1.RestartPolicyNever,
+					SecurityContext: config.Workspace.PodSecurityContext,
+					Volumes: []corev1.Volume{
+						{
+							Name: projects.ProjectCloneGitCacheVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: pvc.Name,
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:            gitCacheRefreshContainerName,
+							Image:           image,
+							ImagePullPolicy: pullPolicy,
+							Command:         []string{"/bin/sh"},
+							Args:            []string{"-c", gitCacheRefreshScript},
+							Env: []corev1.EnvVar{
+								{Name: "GIT_CACHE_PATH", Value: projects.ProjectCloneGitCacheMountPath},
+								{Name: "GIT_CACHE_REPOSITORIES", Value: strings.Join(repositoryLines, "\n")},
+								// Fail instead of waiting for credentials if a repository requires authentication
+								{Name: "GIT_TERMINAL_PROMPT", Value: "0"},
+							},
+							Resources:                *resources,
+							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      projects.ProjectCloneGitCacheVolumeName,
+									MountPath: projects.ProjectCloneGitCacheMountPath,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	podTolerations, nodeSelector, err := nsconfig.GetNamespacePodTolerationsAndNodeSelector(pvc.Namespace, clusterAPI)
+	if err != nil {
+		return nil, err
+	}
+	if len(podTolerations) > 0 {
+		job.Spec.Template.Spec.Tolerations = podTolerations
+	}
+	if len(nodeSelector) > 0 {
+		job.Spec.Template.Spec.NodeSelector = nodeSelector
+	}
+
+	return job, nil
+}
+
+// recreateJob creates job on the cluster, removing any leftover job with the same name from a previous run first
+// (e.g. if the controller restarted while the job was running).
+func (r *GitCacheReconciler) recreateJob(ctx context.Context, job *batchv1.Job) error {
+	if err := r.deleteJob(ctx, job); err != nil {
+		return err
+	}
+	if err := wait.PollUntilContextTimeout(ctx, time.Second, time.Minute, true, func(ctx context.Context) (bool, error) {
+		err := r.NonCachingClient.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{})
+		if k8sErrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}); err != nil {
+		return fmt.Errorf("timed out waiting for previous job %s to be deleted: %w", job.Name, err)
+	}
+
+	return r.NonCachingClient.Create(ctx, job)
+}
+
+// waitForJobTerminationMessage waits for a job to finish and returns the termination message of its pod.
+func (r *GitCacheReconciler) waitForJobTerminationMessage(ctx context.Context, job *batchv1.Job) (string, error) {
+	var message string
+	err := wait.PollUntilContextTimeout(ctx, jobPollInterval, jobTimeout, false, func(ctx context.Context) (bool, error) {
+		var err error
+		var done bool
+		done, message, err = r.getJobTerminationMessage(ctx, job)
+		return done, err
+	})
+	return message, err
+}
+
+// getJobTerminationMessage checks whether a job has finished. If the job completed successfully, the termination
+// message of the job's pod is returned. If the job failed, an error is returned.
+func (r *GitCacheReconciler) getJobTerminationMessage(ctx context.Context, job *batchv1.Job) (done bool, message string, err error) {
+	clusterJob := &batchv1.Job{}
+	if err := r.NonCachingClient.Get(ctx, client.ObjectKeyFromObject(job), clusterJob); err != nil {
+		return false, "", err
+	}
+
+	for _, condition := range clusterJob.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobFailed:
+			return true, "", fmt.Errorf("job failed: %s", condition.Message)
+		case batchv1.JobComplete:
+			message, err := r.readJobTerminationMessage(ctx, clusterJob)
+			return true, message, err
+		}
+	}
+	return false, "", nil
+}
+
+func (r *GitCacheReconciler) readJobTerminationMessage(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.NonCachingClient.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.State.Terminated != nil && containerStatus.State.Terminated.Message != "" {
+				return containerStatus.State.Terminated.Message, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("could not find result of job %s", job.Name)
+}
+
+func (r *GitCacheReconciler) deleteJob(ctx context.Context, job *batchv1.Job) error {
+	err := r.NonCachingClient.Delete(ctx, job.DeepCopy(), client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return client.IgnoreNotFound(err)
+}
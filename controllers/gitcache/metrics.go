@@ -0,0 +1,66 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespaceLabel = "namespace"
+	metricsResultLabel    = "result"
+)
+
+var (
+	mirroredRepositories = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "devworkspace",
+			Name:      "git_cache_mirrored_repositories",
+			Help:      "Number of git repositories selected for mirroring in the git mirror cache, as of the last refresh",
+		},
+		[]string{metricsNamespaceLabel},
+	)
+	mirrorRefreshes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "git_cache_mirror_refreshes_total",
+			Help:      "Number of git mirrors created, updated or that failed to refresh in the git mirror cache",
+		},
+		[]string{metricsResultLabel},
+	)
+	refreshJobFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "git_cache_refresh_job_failures_total",
+			Help:      "Number of git mirror refresh jobs that could not be started, failed or did not complete in time",
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		mirroredRepositories,
+		mirrorRefreshes,
+		refreshJobFailures,
+	)
+}
+
+func recordGitCacheRefresh(result *gitCacheRefreshResult) {
+	mirrorRefreshes.WithLabelValues("created").Add(float64(result.Created))
+	mirrorRefreshes.WithLabelValues("updated").Add(float64(result.Updated))
+	mirrorRefreshes.WithLabelValues("failed").Add(float64(result.Failed))
+}
@@ -0,0 +1,189 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/library/projects"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+// gitRepository is a git repository that is mirrored in the git mirror cache
+type gitRepository struct {
+	// MirrorName is the path of the repository's mirror within the git mirror cache
+	MirrorName string
+	// URL is the URL the mirror is fetched from
+	URL string
+	// Workspaces is the number of DevWorkspaces that use the repository
+	Workspaces int
+}
+
+// refreshGitMirrors starts a job for each namespace that contains the git mirror cache PVC, which creates or updates
+// the mirrors of git repositories that are used by at least the configured minimum number of DevWorkspaces in that
+// namespace. The results of all jobs are collected once they finish.
+func (r *GitCacheReconciler) refreshGitMirrors(ctx context.Context, operatorConfig *controllerv1alpha1.OperatorConfiguration, logger logr.Logger) error {
+	log := logger.WithName("gitMirrors")
+
+	gitCacheConfig := operatorConfig.Workspace.ProjectCloneConfig.GitCache
+	workspaces := &dwv2.DevWorkspaceList{}
+	if err := r.Client.List(ctx, workspaces); err != nil {
+		return fmt.Errorf("failed to list DevWorkspaces: %w", err)
+	}
+	repositories := getRepositoriesToMirror(workspaces.Items, int(*gitCacheConfig.MinWorkspaces), int(*gitCacheConfig.MaxRepositories))
+
+	clusterAPI := sync.ClusterAPI{
+		Ctx:    ctx,
+		Client: r.Client,
+		Scheme: r.Scheme,
+		Logger: log,
+	}
+	mirroredRepositories.Reset()
+	var jobs []*batchv1.Job
+	for namespace, namespaceRepositories := range repositories {
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: gitCacheConfig.ClaimName, Namespace: namespace}, pvc); err != nil {
+			if !k8sErrors.IsNotFound(err) {
+				log.Error(err, "Failed to get git mirror cache PVC", "namespace", namespace)
+				refreshJobFailures.Inc()
+			}
+			continue
+		}
+		job, err := getGitCacheRefreshJob(pvc, namespaceRepositories, operatorConfig, clusterAPI)
+		if err == nil {
+			err = r.recreateJob(ctx, job)
+		}
+		if err != nil {
+			log.Error(err, "Failed to start git mirror refresh job", "namespace", namespace, "pvc", pvc.Name)
+			refreshJobFailures.Inc()
+			continue
+		}
+		log.Info(fmt.Sprintf("Refreshing %d git mirrors", len(namespaceRepositories)), "namespace", namespace, "pvc", pvc.Name)
+		mirroredRepositories.WithLabelValues(namespace).Set(float64(len(namespaceRepositories)))
+		jobs = append(jobs, job)
+	}
+
+	for _, job := range jobs {
+		jobLog := log.WithValues("namespace", job.Namespace, "job", job.Name)
+		message, err := r.waitForJobTerminationMessage(ctx, job)
+		var result *gitCacheRefreshResult
+		if err == nil {
+			result, err = parseGitCacheRefreshResult(message)
+		}
+		if err != nil {
+			jobLog.Error(err, "Git mirror refresh job did not succeed")
+			refreshJobFailures.Inc()
+		} else {
+			jobLog.Info("Refreshed git mirrors", "created", result.Created, "updated", result.Updated, "failed", result.Failed)
+			recordGitCacheRefresh(result)
+		}
+		if err := r.deleteJob(ctx, job); err != nil {
+			jobLog.Error(err, "Failed to delete git mirror refresh job")
+		}
+	}
+	return nil
+}
+
+// getRepositoriesToMirror returns the git repositories to mirror in each namespace. A repository is mirrored if it is
+// used by at least minWorkspaces DevWorkspaces in the namespace. If more than maxRepositories repositories are eligible,
+// the repositories used by the most DevWorkspaces are selected.
+//
+// Remotes of a repository are counted together regardless of the protocol used, but mirrors are only fetched from
+// HTTP(S) remotes that do not contain credentials, as the refresh job does not have access to users' credentials.
+func getRepositoriesToMirror(workspaces []dwv2.DevWorkspace, minWorkspaces, maxRepositories int) map[string][]gitRepository {
+	repositoriesByNamespace := map[string]map[string]*gitRepository{}
+	for _, workspace := range workspaces {
+		if workspace.DeletionTimestamp != nil {
+			continue
+		}
+		if repositoriesByNamespace[workspace.Namespace] == nil {
+			repositoriesByNamespace[workspace.Namespace] = map[string]*gitRepository{}
+		}
+		repositories := repositoriesByNamespace[workspace.Namespace]
+
+		workspaceRemotes := map[string]bool{}
+		var allProjects []dwv2.Project
+		allProjects = append(allProjects, workspace.Spec.Template.Projects...)
+		allProjects = append(allProjects, workspace.Spec.Template.DependentProjects...)
+		for _, project := range allProjects {
+			if project.Git == nil {
+				continue
+			}
+			for _, remoteURL := range project.Git.Remotes {
+				mirrorName, err := projects.GetGitMirrorName(remoteURL)
+				if err != nil {
+					continue
+				}
+				repository, ok := repositories[mirrorName]
+				if !ok {
+					repository = &gitRepository{MirrorName: mirrorName}
+					repositories[mirrorName] = repository
+				}
+				if isAnonymousHTTPRemote(remoteURL) && (repository.URL == "" || remoteURL < repository.URL) {
+					repository.URL = remoteURL
+				}
+				if !workspaceRemotes[mirrorName] {
+					workspaceRemotes[mirrorName] = true
+					repository.Workspaces++
+				}
+			}
+		}
+	}
+
+	result := map[string][]gitRepository{}
+	for namespace, repositories := range repositoriesByNamespace {
+		var eligible []gitRepository
+		for _, repository := range repositories {
+			if repository.URL != "" && repository.Workspaces >= minWorkspaces {
+				eligible = append(eligible, *repository)
+			}
+		}
+		if len(eligible) == 0 {
+			continue
+		}
+		sort.Slice(eligible, func(i, j int) bool {
+			if eligible[i].Workspaces != eligible[j].Workspaces {
+				return eligible[i].Workspaces > eligible[j].Workspaces
+			}
+			return eligible[i].MirrorName < eligible[j].MirrorName
+		})
+		if len(eligible) > maxRepositories {
+			eligible = eligible[:maxRepositories]
+		}
+		result[namespace] = eligible
+	}
+	return result
+}
+
+// isAnonymousHTTPRemote returns whether a git remote uses HTTP(S) and does not contain credentials
+func isAnonymousHTTPRemote(remoteURL string) bool {
+	parsed, err := url.Parse(remoteURL)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "https" || parsed.Scheme == "http") && parsed.User == nil
+}
@@ -91,6 +91,7 @@ type DevWorkspaceReconciler struct {
 // +kubebuilder:rbac:groups="",resources=pods;serviceaccounts;secrets;configmaps;persistentvolumeclaims,verbs=*
 // +kubebuilder:rbac:groups="",resources=namespaces;events,verbs=get;list;watch
 // +kubebuilder:rbac:groups="batch",resources=jobs,verbs=get;create;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create;delete
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews;localsubjectaccessreviews,verbs=create
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;list;watch;create;update
@@ -199,6 +200,12 @@ func (r *DevWorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		// Set 'Started' condition as early as possible to get accurate timing metrics
 		workspace.Status.Phase = dw.DevWorkspaceStatusStarting
 		workspace.Status.Message = "Initializing DevWorkspace"
+		var externalConditions []dw.DevWorkspaceCondition
+		for _, condition := range workspace.Status.Conditions {
+			if conditions.IsExternalCondition(condition.Type) {
+				externalConditions = append(externalConditions, condition)
+			}
+		}
 		workspace.Status.Conditions = []dw.DevWorkspaceCondition{
 			{
 				Type:               conditions.Started,
@@ -207,6 +214,7 @@ func (r *DevWorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 				Message:            "DevWorkspace is starting",
 			},
 		}
+		workspace.Status.Conditions = append(workspace.Status.Conditions, externalConditions...)
 		err = r.Status().Update(ctx, workspace.DevWorkspace)
 		if err == nil {
 			metrics.WorkspaceStarted(workspace, reqLogger)
@@ -220,6 +228,7 @@ func (r *DevWorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	clusterWorkspace := &common.DevWorkspaceWithConfig{}
 	clusterWorkspace.DevWorkspace = workspace.DevWorkspace.DeepCopy()
 	clusterWorkspace.Config = workspace.Config
+	var projectCloneResults string
 
 	defer func() (reconcile.Result, error) {
 		// Don't accidentally suppress errors by overwriting here; only check for timeout when no error
@@ -241,6 +250,9 @@ func (r *DevWorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			// since WorkspaceStarted and WorkspaceRunning metrics are not updated if this annotation exists
 			defer r.syncStartedAtToCluster(ctx, clusterWorkspace, reqLogger)
 		}
+		if projectCloneResults != "" {
+			defer r.syncProjectCloneResultsToCluster(ctx, clusterWorkspace, projectCloneResults, reqLogger)
+		}
 
 		return r.updateWorkspaceStatus(clusterWorkspace, reqLogger, &reconcileStatus, reconcileResult, err)
 	}()
@@ -391,6 +403,16 @@ func (r *DevWorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			Env:       env.GetEnvironmentVariablesForProjectClone(workspace),
 			Resources: workspace.Config.Workspace.ProjectCloneConfig.Resources,
 		}
+		if hasSSHKey, err := ssh.HasSSHKey(clusterAPI, workspace.Namespace); err != nil {
+			reqLogger.Error(err, "Error retrieving SSH secret")
+		} else {
+			projectCloneOptions.MountSSHKey = hasSSHKey
+		}
+		if gitCacheClaimName, err := getGitCacheClaimName(workspace, clusterAPI); err != nil {
+			reqLogger.Error(err, "Error retrieving git mirror cache PVC")
+		} else {
+			projectCloneOptions.GitCacheClaimName = gitCacheClaimName
+		}
 		if workspace.Config.Workspace.ProjectCloneConfig.ImagePullPolicy != "" {
 			projectCloneOptions.PullPolicy = config.Workspace.ProjectCloneConfig.ImagePullPolicy
 		} else {
@@ -400,6 +422,12 @@ func (r *DevWorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			return r.failWorkspace(workspace, fmt.Sprintf("Failed to set up project-clone init container: %s", err), metrics.ReasonInfrastructureFailure, reqLogger, &reconcileStatus), nil
 		} else if projectClone != nil {
 			devfilePodAdditions.InitContainers = append([]corev1.Container{*projectClone}, devfilePodAdditions.InitContainers...)
+			if projectCloneOptions.MountSSHKey {
+				devfilePodAdditions.Volumes = append(devfilePodAdditions.Volumes, projects.GetProjectCloneSSHKeyVolume())
+			}
+			if projectCloneOptions.GitCacheClaimName != "" {
+				devfilePodAdditions.Volumes = append(devfilePodAdditions.Volumes, projects.GetProjectCloneGitCacheVolume(projectCloneOptions.GitCacheClaimName))
+			}
 		}
 	}
 
@@ -471,6 +499,11 @@ func (r *DevWorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return reconcileResult, reconcileErr
 	}
 
+	err = automount.ProvisionSharedCacheVolumesInto(devfilePodAdditions, workspace, clusterAPI)
+	if shouldReturn, reconcileResult, reconcileErr := r.checkDWError(workspace, err, "Failed to mount shared cache volumes", metrics.ReasonBadRequest, reqLogger, &reconcileStatus); shouldReturn {
+		return reconcileResult, reconcileErr
+	}
+
 	err = storageProvisioner.ProvisionStorage(devfilePodAdditions, workspace, clusterAPI)
 	if shouldReturn, reconcileResult, reconcileErr := r.checkDWError(workspace, err, "Error provisioning storage", metrics.ReasonInfrastructureFailure, reqLogger, &reconcileStatus); shouldReturn {
 		reconcileStatus.setConditionFalse(conditions.StorageReady, fmt.Sprintf("Provisioning storage: %s", err.Error()))
@@ -589,6 +622,7 @@ func (r *DevWorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 	reconcileStatus.setConditionTrue(conditions.DeploymentReady, "DevWorkspace deployment ready")
+	projectCloneResults = checkProjectCloneResults(workspace, clusterAPI, &reconcileStatus, reqLogger)
 
 	serverReady, serverStatusCode, err := checkServerStatus(clusterWorkspace)
 	if shouldReturn, reconcileResult, reconcileErr := r.checkDWError(workspace, err, "Error checking server status", metrics.ReasonInfrastructureFailure, reqLogger, &reconcileStatus); shouldReturn {
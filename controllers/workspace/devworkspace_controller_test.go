@@ -28,6 +28,7 @@ import (
 	"github.com/devfile/devworkspace-operator/pkg/conditions"
 	"github.com/devfile/devworkspace-operator/pkg/config"
 	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
 	"github.com/devfile/devworkspace-operator/pkg/library/projects"
 	"github.com/devfile/devworkspace-operator/pkg/library/restore"
 	. "github.com/onsi/ginkgo/v2"
@@ -1428,7 +1429,7 @@ var _ = Describe("DevWorkspace Controller", func() {
 			Expect(restoreInitContainer).ToNot(BeNil(), "Workspace restore init container should not be nil")
 			Expect(restoreInitContainer.Name).To(Equal(restore.WorkspaceRestoreContainerName), "Workspace restore init container should be present in deployment")
 
-			Expect(restoreInitContainer.Command).To(Equal([]string{"/workspace-recovery.sh"}), "Restore init container should have correct command")
+			Expect(restoreInitContainer.Command).To(Equal([]string{backup.WorkspaceRecoveryCommand}), "Restore init container should have correct command")
 			Expect(restoreInitContainer.Args).To(Equal([]string{"--restore"}), "Restore init container should have correct args")
 			Expect(restoreInitContainer.VolumeMounts).To(ContainElement(corev1.VolumeMount{
 				Name:        "claim-devworkspace", // PVC name for common storage
@@ -1490,7 +1491,7 @@ var _ = Describe("DevWorkspace Controller", func() {
 			Expect(restoreInitContainer).ToNot(BeNil(), "Workspace restore init container should not be nil")
 			Expect(restoreInitContainer.Name).To(Equal(restore.WorkspaceRestoreContainerName), "Workspace restore init container should be present in deployment")
 
-			Expect(restoreInitContainer.Command).To(Equal([]string{"/workspace-recovery.sh"}), "Restore init container should have correct command")
+			Expect(restoreInitContainer.Command).To(Equal([]string{backup.WorkspaceRecoveryCommand}), "Restore init container should have correct command")
 			Expect(restoreInitContainer.Args).To(Equal([]string{"--restore"}), "Restore init container should have correct args")
 			Expect(restoreInitContainer.VolumeMounts).To(ContainElement(corev1.VolumeMount{
 				Name:        common.PerWorkspacePVCName(workspaceID),
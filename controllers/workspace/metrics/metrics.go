@@ -25,6 +25,7 @@ const (
 	metricSourceLabel        = "source"
 	metricsRoutingClassLabel = "routingclass"
 	metricsReasonLabel       = "reason"
+	metricsResultLabel       = "result"
 )
 
 var (
@@ -73,9 +74,19 @@ var (
 			metricsRoutingClassLabel,
 		},
 	)
+	projectCloneGitCacheTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "devworkspace",
+			Name:      "project_clone_git_cache_total",
+			Help:      "Number of projects cloned while the git mirror cache was available, by whether a mirror was found for the project",
+		},
+		[]string{
+			metricsResultLabel,
+		},
+	)
 )
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(workspaceTotal, workspaceStarts, workspaceFailures, workspaceStartupTimesHist)
+	metrics.Registry.MustRegister(workspaceTotal, workspaceStarts, workspaceFailures, workspaceStartupTimesHist, projectCloneGitCacheTotal)
 }
@@ -16,6 +16,8 @@
 package metrics
 
 import (
+	"strings"
+
 	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
@@ -23,6 +25,7 @@ import (
 	"github.com/devfile/devworkspace-operator/pkg/common"
 	"github.com/devfile/devworkspace-operator/pkg/conditions"
 	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/library/projects"
 )
 
 // WorkspaceStarted updates metrics for workspaces entering the 'Starting' phase, given a workspace. If an error is
@@ -51,6 +54,16 @@ func WorkspaceFailed(wksp *common.DevWorkspaceWithConfig, log logr.Logger) {
 	incrementMetricForWorkspaceFailure(workspaceFailures, wksp, log)
 }
 
+// ProjectsCloned updates metrics for the results reported by a workspace's project clone container. Should be called
+// once for each set of results.
+func ProjectsCloned(results *projects.ProjectCloneResults) {
+	for _, result := range results.Projects {
+		if result.GitCache != "" {
+			projectCloneGitCacheTotal.WithLabelValues(strings.ToLower(string(result.GitCache))).Inc()
+		}
+	}
+}
+
 func incrementMetricForWorkspace(metric *prometheus.CounterVec, workspace *common.DevWorkspaceWithConfig, log logr.Logger) {
 	sourceLabel := workspace.Labels[workspaceSourceLabel]
 	if sourceLabel == "" {
@@ -0,0 +1,96 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/devfile/devworkspace-operator/controllers/workspace/metrics"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/library/projects"
+	"github.com/devfile/devworkspace-operator/pkg/library/status"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+const projectCloneFailedReason = "ProjectCloneFailed"
+
+// checkProjectCloneResults reads the results reported by the project clone container and adds a warning to the
+// workspace status for each project that was not set up successfully and for each warning reported for a project,
+// e.g. failing to fetch Git LFS objects. Returns the raw results, to be stored on
+// the DevWorkspace via syncProjectCloneResultsToCluster, or an empty string if no results are available.
+func checkProjectCloneResults(workspace *common.DevWorkspaceWithConfig, clusterAPI sync.ClusterAPI, reconcileStatus *currentStatus, logger logr.Logger) string {
+	message, results, err := status.GetProjectCloneResults(workspace, clusterAPI)
+	if err != nil {
+		logger.Info("Failed to read project clone results", "error", err.Error())
+		return ""
+	}
+	if results == nil {
+		return ""
+	}
+	for _, warning := range status.GetProjectCloneWarnings(results) {
+		reconcileStatus.addWarningWithReason(warning, projectCloneFailedReason)
+	}
+	return message
+}
+
+func (r *DevWorkspaceReconciler) syncProjectCloneResultsToCluster(
+	ctx context.Context, workspace *common.DevWorkspaceWithConfig, results string, reqLogger logr.Logger) {
+
+	if workspace.Annotations[constants.DevWorkspaceProjectCloneResultsAnnotation] == results {
+		return
+	}
+	if workspace.Annotations == nil {
+		workspace.Annotations = map[string]string{}
+	}
+
+	workspace.Annotations[constants.DevWorkspaceProjectCloneResultsAnnotation] = results
+	if err := r.Update(ctx, workspace.DevWorkspace); err != nil {
+		if k8sErrors.IsConflict(err) {
+			reqLogger.Info("Got conflict when trying to apply project clone results annotation to workspace")
+		} else {
+			reqLogger.Error(err, "Error trying to apply project clone results annotation to devworkspace")
+		}
+		return
+	}
+	// Results are only recorded once they are stored, to avoid counting the same results multiple times
+	if parsed, err := projects.ParseProjectCloneResults(results); err == nil {
+		metrics.ProjectsCloned(parsed)
+	}
+}
+
+// getGitCacheClaimName returns the name of the git mirror cache PVC to mount to the project clone container, or an
+// empty string if the git mirror cache is disabled or the PVC does not exist in the workspace's namespace.
+func getGitCacheClaimName(workspace *common.DevWorkspaceWithConfig, clusterAPI sync.ClusterAPI) (string, error) {
+	gitCache := workspace.Config.Workspace.ProjectCloneConfig.GitCache
+	if gitCache == nil || gitCache.Enable == nil || !*gitCache.Enable || gitCache.ClaimName == "" {
+		return "", nil
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := clusterAPI.Client.Get(clusterAPI.Ctx, types.NamespacedName{Name: gitCache.ClaimName, Namespace: workspace.Namespace}, pvc)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return pvc.Name, nil
+}
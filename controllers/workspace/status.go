@@ -111,6 +111,11 @@ func syncConditions(workspaceStatus *dw.DevWorkspaceStatus, currentStatus *curre
 			existingWarnings[workspaceCondition.Message] = workspaceCondition
 			continue
 		}
+		if conditions.IsExternalCondition(workspaceCondition.Type) {
+			// Conditions such as storage usage are reported by other controllers and are not managed here
+			newConditions = append(newConditions, workspaceCondition)
+			continue
+		}
 		existingConditions[workspaceCondition.Type] = true
 
 		currCondition, ok := currentStatus.conditions[workspaceCondition.Type]
@@ -39,6 +39,7 @@ import (
 	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
 	backupCronJobController "github.com/devfile/devworkspace-operator/controllers/backupcronjob"
 	cleanupCronJobController "github.com/devfile/devworkspace-operator/controllers/cleanupcronjob"
+	gitCacheController "github.com/devfile/devworkspace-operator/controllers/gitcache"
 	workspacecontroller "github.com/devfile/devworkspace-operator/controllers/workspace"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -152,6 +153,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	nodeStatsClient, err := cleanupCronJobController.NewNodeStatsClient(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to initialize node stats client")
+		os.Exit(1)
+	}
+
 	// Index Events on involvedObject.name to allow us to get events involving a DevWorkspace's pod(s). This is used to
 	// check for issues that prevent the pod from starting, so that DevWorkspaces aren't just hanging indefinitely.
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Event{}, "involvedObject.name", func(obj client.Object) []string {
@@ -182,9 +189,12 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&cleanupCronJobController.CleanupCronJobReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("CleanupCronJob"),
-		Scheme: mgr.GetScheme(),
+		Client:           mgr.GetClient(),
+		NonCachingClient: nonCachingClient,
+		NodeStatsClient:  nodeStatsClient,
+		Log:              ctrl.Log.WithName("controllers").WithName("CleanupCronJob"),
+		Scheme:           mgr.GetScheme(),
+		Recorder:         mgr.GetEventRecorder("cleanup-cronjob"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CleanupCronJob")
 		os.Exit(1)
@@ -198,6 +208,15 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "BackupCronJob")
 		os.Exit(1)
 	}
+	if err = (&gitCacheController.GitCacheReconciler{
+		Client:           mgr.GetClient(),
+		NonCachingClient: nonCachingClient,
+		Log:              ctrl.Log.WithName("controllers").WithName("GitCache"),
+		Scheme:           mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GitCache")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	// Get a config to talk to the apiserver
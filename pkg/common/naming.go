@@ -108,6 +108,26 @@ func PVCCleanupJobName(workspaceId string) string {
 	return fmt.Sprintf("cleanup-%s", workspaceId)
 }
 
+func OrphanedStorageCleanupJobName(pvcName string) string {
+	return fmt.Sprintf("cleanup-orphaned-%s", pvcName)
+}
+
+func StorageUsageJobName(pvcName string) string {
+	return fmt.Sprintf("storage-usage-%s", pvcName)
+}
+
+func GitCacheRefreshJobName(pvcName string) string {
+	return fmt.Sprintf("git-cache-refresh-%s", pvcName)
+}
+
+func CloneSourceVolumeSnapshotName(workspaceId string) string {
+	return fmt.Sprintf("%s-clone-source", workspaceId)
+}
+
+func BackupSourceVolumeSnapshotName(workspaceId, suffix string) string {
+	return fmt.Sprintf("%s-backup-source-%s", workspaceId, suffix)
+}
+
 func PerWorkspacePVCName(workspaceId string) string {
 	return fmt.Sprintf("storage-%s", workspaceId)
 }
@@ -116,6 +136,10 @@ func MetadataConfigMapName(workspaceId string) string {
 	return fmt.Sprintf("%s-metadata", workspaceId)
 }
 
+func BackupHistoryConfigMapName(workspaceId string) string {
+	return fmt.Sprintf("%s-backup-history", workspaceId)
+}
+
 // We can't add prefixes to automount volume names, as adding any characters
 // can potentially push the name over the 63 character limit (if the original
 // object has a long name)
@@ -131,6 +155,10 @@ func AutoMountPVCVolumeName(pvcName string) string {
 	return pvcName
 }
 
+func SharedCacheVolumeName(cacheName string) string {
+	return fmt.Sprintf("shared-cache-%s", cacheName)
+}
+
 func AutoMountProjectedVolumeName(mountPath string) string {
 	// To avoid issues around sanitizing mountPath to generate a unique name (length, allowed chars)
 	// just use the sha256 hash of mountPath
@@ -28,8 +28,20 @@ const (
 	KubeComponentsReady  dw.DevWorkspaceConditionType = "KubernetesComponentsProvisioned"
 	DeploymentReady      dw.DevWorkspaceConditionType = "DeploymentReady"
 	DevWorkspaceWarning  dw.DevWorkspaceConditionType = "DevWorkspaceWarning"
+	// StorageUsage reports the storage used by a DevWorkspace. Unlike other conditions, it is set by the
+	// cleanup cron job rather than by the DevWorkspace controller.
+	StorageUsage dw.DevWorkspaceConditionType = "StorageUsage"
+	// BackupSucceeded reports whether the most recent backup of a DevWorkspace succeeded. Unlike other conditions,
+	// it is set by the backup cron job rather than by the DevWorkspace controller.
+	BackupSucceeded dw.DevWorkspaceConditionType = "BackupSucceeded"
 )
 
+// IsExternalCondition returns whether a condition type is set by a controller other than the DevWorkspace
+// controller. These conditions must be preserved when the DevWorkspace controller updates the DevWorkspace's conditions.
+func IsExternalCondition(t dw.DevWorkspaceConditionType) bool {
+	return t == StorageUsage || t == BackupSucceeded
+}
+
 func GetConditionByType(conditions []dw.DevWorkspaceCondition, t dw.DevWorkspaceConditionType) *dw.DevWorkspaceCondition {
 	for _, condition := range conditions {
 		if condition.Type == t {
@@ -67,6 +67,13 @@ var defaultConfig = &v1alpha1.OperatorConfiguration{
 					corev1.ResourceCPU:    resource.MustParse("100m"),
 				},
 			},
+			GitCache: &v1alpha1.ProjectCloneGitCacheConfig{
+				Enable:          pointer.Bool(false),
+				ClaimName:       "git-mirror-cache",
+				Schedule:        "0 */4 * * *",
+				MinWorkspaces:   pointer.Int32(2),
+				MaxRepositories: pointer.Int32(20),
+			},
 		},
 		RestoreConfig: &v1alpha1.RestoreConfig{
 			Resources: &corev1.ResourceRequirements{
@@ -93,6 +100,14 @@ var defaultConfig = &v1alpha1.OperatorConfiguration{
 			DryRun:     pointer.Bool(false),
 			RetainTime: pointer.Int32(2592000),
 			Schedule:   "0 0 1 * *",
+			OrphanedStorageCleanup: &v1alpha1.OrphanedStorageCleanupConfig{
+				Enable: pointer.Bool(false),
+				DryRun: pointer.Bool(false),
+			},
+			StorageUsage: &v1alpha1.StorageUsageConfig{
+				Enable:       pointer.Bool(false),
+				KubeletStats: pointer.Bool(false),
+			},
 		},
 		BackupCronJob: &v1alpha1.BackupCronJobConfig{
 			Enable:       pointer.Bool(false),
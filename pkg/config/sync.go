@@ -301,6 +301,9 @@ func mergeConfig(from, to *controller.OperatorConfiguration) {
 		if from.Workspace.StorageClassName != nil {
 			to.Workspace.StorageClassName = from.Workspace.StorageClassName
 		}
+		if from.Workspace.VolumeSnapshotClassName != nil {
+			to.Workspace.VolumeSnapshotClassName = from.Workspace.VolumeSnapshotClassName
+		}
 		if from.Workspace.RuntimeClassName != nil {
 			to.Workspace.RuntimeClassName = from.Workspace.RuntimeClassName
 		}
@@ -401,6 +404,41 @@ func mergeConfig(from, to *controller.OperatorConfiguration) {
 			if from.Workspace.ProjectCloneConfig.Env != nil {
 				to.Workspace.ProjectCloneConfig.Env = from.Workspace.ProjectCloneConfig.Env
 			}
+			if from.Workspace.ProjectCloneConfig.Parallelism != nil {
+				to.Workspace.ProjectCloneConfig.Parallelism = from.Workspace.ProjectCloneConfig.Parallelism
+			}
+			if from.Workspace.ProjectCloneConfig.SSH != nil {
+				to.Workspace.ProjectCloneConfig.SSH = from.Workspace.ProjectCloneConfig.SSH.DeepCopy()
+			}
+			if from.Workspace.ProjectCloneConfig.Depth != nil {
+				to.Workspace.ProjectCloneConfig.Depth = from.Workspace.ProjectCloneConfig.Depth
+			}
+			if from.Workspace.ProjectCloneConfig.Filter != "" {
+				to.Workspace.ProjectCloneConfig.Filter = from.Workspace.ProjectCloneConfig.Filter
+			}
+			if from.Workspace.ProjectCloneConfig.SingleBranch != nil {
+				to.Workspace.ProjectCloneConfig.SingleBranch = from.Workspace.ProjectCloneConfig.SingleBranch
+			}
+			if from.Workspace.ProjectCloneConfig.GitCache != nil {
+				if to.Workspace.ProjectCloneConfig.GitCache == nil {
+					to.Workspace.ProjectCloneConfig.GitCache = &controller.ProjectCloneGitCacheConfig{}
+				}
+				if from.Workspace.ProjectCloneConfig.GitCache.Enable != nil {
+					to.Workspace.ProjectCloneConfig.GitCache.Enable = from.Workspace.ProjectCloneConfig.GitCache.Enable
+				}
+				if from.Workspace.ProjectCloneConfig.GitCache.ClaimName != "" {
+					to.Workspace.ProjectCloneConfig.GitCache.ClaimName = from.Workspace.ProjectCloneConfig.GitCache.ClaimName
+				}
+				if from.Workspace.ProjectCloneConfig.GitCache.Schedule != "" {
+					to.Workspace.ProjectCloneConfig.GitCache.Schedule = from.Workspace.ProjectCloneConfig.GitCache.Schedule
+				}
+				if from.Workspace.ProjectCloneConfig.GitCache.MinWorkspaces != nil {
+					to.Workspace.ProjectCloneConfig.GitCache.MinWorkspaces = from.Workspace.ProjectCloneConfig.GitCache.MinWorkspaces
+				}
+				if from.Workspace.ProjectCloneConfig.GitCache.MaxRepositories != nil {
+					to.Workspace.ProjectCloneConfig.GitCache.MaxRepositories = from.Workspace.ProjectCloneConfig.GitCache.MaxRepositories
+				}
+			}
 		}
 		if from.Workspace.RestoreConfig != nil {
 			if to.Workspace.RestoreConfig == nil {
@@ -460,6 +498,51 @@ func mergeConfig(from, to *controller.OperatorConfiguration) {
 			if from.Workspace.CleanupCronJob.Schedule != "" {
 				to.Workspace.CleanupCronJob.Schedule = from.Workspace.CleanupCronJob.Schedule
 			}
+			if from.Workspace.CleanupCronJob.OrphanedStorageCleanup != nil {
+				if to.Workspace.CleanupCronJob.OrphanedStorageCleanup == nil {
+					to.Workspace.CleanupCronJob.OrphanedStorageCleanup = &controller.OrphanedStorageCleanupConfig{}
+				}
+				if from.Workspace.CleanupCronJob.OrphanedStorageCleanup.Enable != nil {
+					to.Workspace.CleanupCronJob.OrphanedStorageCleanup.Enable = from.Workspace.CleanupCronJob.OrphanedStorageCleanup.Enable
+				}
+				if from.Workspace.CleanupCronJob.OrphanedStorageCleanup.DryRun != nil {
+					to.Workspace.CleanupCronJob.OrphanedStorageCleanup.DryRun = from.Workspace.CleanupCronJob.OrphanedStorageCleanup.DryRun
+				}
+			}
+			if from.Workspace.CleanupCronJob.StorageUsage != nil {
+				if to.Workspace.CleanupCronJob.StorageUsage == nil {
+					to.Workspace.CleanupCronJob.StorageUsage = &controller.StorageUsageConfig{}
+				}
+				if from.Workspace.CleanupCronJob.StorageUsage.Enable != nil {
+					to.Workspace.CleanupCronJob.StorageUsage.Enable = from.Workspace.CleanupCronJob.StorageUsage.Enable
+				}
+				if from.Workspace.CleanupCronJob.StorageUsage.MinimumUsageForPruning != nil {
+					minimumUsage := from.Workspace.CleanupCronJob.StorageUsage.MinimumUsageForPruning.DeepCopy()
+					to.Workspace.CleanupCronJob.StorageUsage.MinimumUsageForPruning = &minimumUsage
+				}
+				if from.Workspace.CleanupCronJob.StorageUsage.KubeletStats != nil {
+					to.Workspace.CleanupCronJob.StorageUsage.KubeletStats = from.Workspace.CleanupCronJob.StorageUsage.KubeletStats
+				}
+			}
+			if from.Workspace.CleanupCronJob.Policies != nil {
+				policies := make([]controller.PruningPolicy, 0, len(from.Workspace.CleanupCronJob.Policies))
+				for _, policy := range from.Workspace.CleanupCronJob.Policies {
+					policies = append(policies, *policy.DeepCopy())
+				}
+				to.Workspace.CleanupCronJob.Policies = policies
+			}
+			if from.Workspace.CleanupCronJob.MaxStoppedWorkspacesPerUser != nil {
+				to.Workspace.CleanupCronJob.MaxStoppedWorkspacesPerUser = from.Workspace.CleanupCronJob.MaxStoppedWorkspacesPerUser
+			}
+			if from.Workspace.CleanupCronJob.GracePeriod != nil {
+				to.Workspace.CleanupCronJob.GracePeriod = from.Workspace.CleanupCronJob.GracePeriod
+			}
+			if from.Workspace.CleanupCronJob.Notification != nil {
+				to.Workspace.CleanupCronJob.Notification = from.Workspace.CleanupCronJob.Notification.DeepCopy()
+			}
+			if from.Workspace.CleanupCronJob.RequireBackup != nil {
+				to.Workspace.CleanupCronJob.RequireBackup = from.Workspace.CleanupCronJob.RequireBackup
+			}
 		}
 		if from.Workspace.BackupCronJob != nil {
 			if to.Workspace.BackupCronJob == nil {
@@ -482,6 +565,59 @@ func mergeConfig(from, to *controller.OperatorConfiguration) {
 					to.Workspace.BackupCronJob.Registry.AuthSecret = from.Workspace.BackupCronJob.Registry.AuthSecret
 				}
 			}
+			if from.Workspace.BackupCronJob.Encryption != nil {
+				if to.Workspace.BackupCronJob.Encryption == nil {
+					to.Workspace.BackupCronJob.Encryption = &controller.BackupEncryptionConfig{}
+				}
+				if from.Workspace.BackupCronJob.Encryption.Required != nil {
+					to.Workspace.BackupCronJob.Encryption.Required = from.Workspace.BackupCronJob.Encryption.Required
+				}
+				if from.Workspace.BackupCronJob.Encryption.KeySecret != "" {
+					to.Workspace.BackupCronJob.Encryption.KeySecret = from.Workspace.BackupCronJob.Encryption.KeySecret
+				}
+			}
+			if from.Workspace.BackupCronJob.RunningWorkspaces != nil {
+				if to.Workspace.BackupCronJob.RunningWorkspaces == nil {
+					to.Workspace.BackupCronJob.RunningWorkspaces = &controller.RunningWorkspacesBackupConfig{}
+				}
+				if from.Workspace.BackupCronJob.RunningWorkspaces.Enable != nil {
+					to.Workspace.BackupCronJob.RunningWorkspaces.Enable = from.Workspace.BackupCronJob.RunningWorkspaces.Enable
+				}
+				if from.Workspace.BackupCronJob.RunningWorkspaces.VolumeSnapshotClassName != nil {
+					to.Workspace.BackupCronJob.RunningWorkspaces.VolumeSnapshotClassName = from.Workspace.BackupCronJob.RunningWorkspaces.VolumeSnapshotClassName
+				}
+			}
+			if from.Workspace.BackupCronJob.Include != nil {
+				if to.Workspace.BackupCronJob.Include == nil {
+					to.Workspace.BackupCronJob.Include = &controller.BackupIncludeConfig{}
+				}
+				if from.Workspace.BackupCronJob.Include.PersistentHome != nil {
+					to.Workspace.BackupCronJob.Include.PersistentHome = from.Workspace.BackupCronJob.Include.PersistentHome
+				}
+				if from.Workspace.BackupCronJob.Include.Volumes != nil {
+					to.Workspace.BackupCronJob.Include.Volumes = from.Workspace.BackupCronJob.Include.Volumes
+				}
+			}
+			if from.Workspace.BackupCronJob.S3 != nil {
+				if to.Workspace.BackupCronJob.S3 == nil {
+					to.Workspace.BackupCronJob.S3 = &controller.S3Config{}
+				}
+				if from.Workspace.BackupCronJob.S3.Endpoint != "" {
+					to.Workspace.BackupCronJob.S3.Endpoint = from.Workspace.BackupCronJob.S3.Endpoint
+				}
+				if from.Workspace.BackupCronJob.S3.Bucket != "" {
+					to.Workspace.BackupCronJob.S3.Bucket = from.Workspace.BackupCronJob.S3.Bucket
+				}
+				if from.Workspace.BackupCronJob.S3.Prefix != "" {
+					to.Workspace.BackupCronJob.S3.Prefix = from.Workspace.BackupCronJob.S3.Prefix
+				}
+				if from.Workspace.BackupCronJob.S3.Region != "" {
+					to.Workspace.BackupCronJob.S3.Region = from.Workspace.BackupCronJob.S3.Region
+				}
+				if from.Workspace.BackupCronJob.S3.CredentialsSecret != "" {
+					to.Workspace.BackupCronJob.S3.CredentialsSecret = from.Workspace.BackupCronJob.S3.CredentialsSecret
+				}
+			}
 			if from.Workspace.BackupCronJob.OrasConfig != nil {
 				if to.Workspace.BackupCronJob.OrasConfig == nil {
 					to.Workspace.BackupCronJob.OrasConfig = &controller.OrasConfig{}
@@ -493,6 +629,29 @@ func mergeConfig(from, to *controller.OperatorConfiguration) {
 			if from.Workspace.BackupCronJob.BackoffLimit != nil {
 				to.Workspace.BackupCronJob.BackoffLimit = from.Workspace.BackupCronJob.BackoffLimit
 			}
+			if from.Workspace.BackupCronJob.Retention != nil {
+				if to.Workspace.BackupCronJob.Retention == nil {
+					to.Workspace.BackupCronJob.Retention = &controller.BackupRetentionConfig{}
+				}
+				if from.Workspace.BackupCronJob.Retention.KeepLast != nil {
+					to.Workspace.BackupCronJob.Retention.KeepLast = from.Workspace.BackupCronJob.Retention.KeepLast
+				}
+				if from.Workspace.BackupCronJob.Retention.KeepDaily != nil {
+					to.Workspace.BackupCronJob.Retention.KeepDaily = from.Workspace.BackupCronJob.Retention.KeepDaily
+				}
+				if from.Workspace.BackupCronJob.Retention.KeepWeekly != nil {
+					to.Workspace.BackupCronJob.Retention.KeepWeekly = from.Workspace.BackupCronJob.Retention.KeepWeekly
+				}
+				if from.Workspace.BackupCronJob.Retention.MaxAge != nil {
+					to.Workspace.BackupCronJob.Retention.MaxAge = from.Workspace.BackupCronJob.Retention.MaxAge
+				}
+				if from.Workspace.BackupCronJob.Retention.DeleteOnWorkspaceDeletion != nil {
+					to.Workspace.BackupCronJob.Retention.DeleteOnWorkspaceDeletion = from.Workspace.BackupCronJob.Retention.DeleteOnWorkspaceDeletion
+				}
+				if from.Workspace.BackupCronJob.Retention.DryRun != nil {
+					to.Workspace.BackupCronJob.Retention.DryRun = from.Workspace.BackupCronJob.Retention.DryRun
+				}
+			}
 		}
 
 		if from.Workspace.PostStartTimeout != "" {
@@ -522,6 +681,22 @@ func mergeConfig(from, to *controller.OperatorConfiguration) {
 				to.Workspace.Overrides.RestrictedPodOverrideFields = from.Workspace.Overrides.RestrictedPodOverrideFields
 			}
 		}
+
+		if from.Workspace.SharedCacheVolumes != nil {
+			sharedCacheVolumesCopy := make([]controller.SharedCacheVolume, len(from.Workspace.SharedCacheVolumes))
+			for i, volume := range from.Workspace.SharedCacheVolumes {
+				sharedCacheVolumesCopy[i] = *volume.DeepCopy()
+			}
+			to.Workspace.SharedCacheVolumes = sharedCacheVolumesCopy
+		}
+
+		if from.Workspace.StorageProfiles != nil {
+			storageProfilesCopy := make([]controller.StorageProfile, len(from.Workspace.StorageProfiles))
+			for i, profile := range from.Workspace.StorageProfiles {
+				storageProfilesCopy[i] = *profile.DeepCopy()
+			}
+			to.Workspace.StorageProfiles = storageProfilesCopy
+		}
 	}
 }
 
@@ -686,6 +861,9 @@ func GetCurrentConfigString(currConfig *controller.OperatorConfiguration) string
 		if workspace.StorageClassName != nil && workspace.StorageClassName != defaultConfig.Workspace.StorageClassName {
 			config = append(config, fmt.Sprintf("workspace.storageClassName=%s", *workspace.StorageClassName))
 		}
+		if workspace.VolumeSnapshotClassName != nil && workspace.VolumeSnapshotClassName != defaultConfig.Workspace.VolumeSnapshotClassName {
+			config = append(config, fmt.Sprintf("workspace.volumeSnapshotClassName=%s", *workspace.VolumeSnapshotClassName))
+		}
 		if workspace.RuntimeClassName != nil && workspace.RuntimeClassName != defaultConfig.Workspace.RuntimeClassName {
 			config = append(config, fmt.Sprintf("workspace.runtimeClassName=%s", *workspace.RuntimeClassName))
 		}
@@ -743,6 +921,44 @@ func GetCurrentConfigString(currConfig *controller.OperatorConfiguration) string
 			if workspace.ProjectCloneConfig.Env != nil {
 				config = append(config, "workspace.projectClone.env is set")
 			}
+			if workspace.ProjectCloneConfig.Parallelism != nil {
+				config = append(config, fmt.Sprintf("workspace.projectClone.parallelism=%d", *workspace.ProjectCloneConfig.Parallelism))
+			}
+			if workspace.ProjectCloneConfig.SSH != nil {
+				if workspace.ProjectCloneConfig.SSH.HostKeyPolicy != "" {
+					config = append(config, fmt.Sprintf("workspace.projectClone.ssh.hostKeyPolicy=%s", workspace.ProjectCloneConfig.SSH.HostKeyPolicy))
+				}
+				if len(workspace.ProjectCloneConfig.SSH.KnownHosts) > 0 {
+					config = append(config, "workspace.projectClone.ssh.knownHosts is set")
+				}
+			}
+			if workspace.ProjectCloneConfig.Depth != nil {
+				config = append(config, fmt.Sprintf("workspace.projectClone.depth=%d", *workspace.ProjectCloneConfig.Depth))
+			}
+			if workspace.ProjectCloneConfig.Filter != "" {
+				config = append(config, fmt.Sprintf("workspace.projectClone.filter=%s", workspace.ProjectCloneConfig.Filter))
+			}
+			if workspace.ProjectCloneConfig.SingleBranch != nil {
+				config = append(config, fmt.Sprintf("workspace.projectClone.singleBranch=%t", *workspace.ProjectCloneConfig.SingleBranch))
+			}
+			if gitCache := workspace.ProjectCloneConfig.GitCache; gitCache != nil {
+				defaultGitCache := defaultConfig.Workspace.ProjectCloneConfig.GitCache
+				if gitCache.Enable != nil && *gitCache.Enable != *defaultGitCache.Enable {
+					config = append(config, fmt.Sprintf("workspace.projectClone.gitCache.enable=%t", *gitCache.Enable))
+				}
+				if gitCache.ClaimName != defaultGitCache.ClaimName {
+					config = append(config, fmt.Sprintf("workspace.projectClone.gitCache.claimName=%s", gitCache.ClaimName))
+				}
+				if gitCache.Schedule != defaultGitCache.Schedule {
+					config = append(config, fmt.Sprintf("workspace.projectClone.gitCache.schedule=%s", gitCache.Schedule))
+				}
+				if gitCache.MinWorkspaces != nil && *gitCache.MinWorkspaces != *defaultGitCache.MinWorkspaces {
+					config = append(config, fmt.Sprintf("workspace.projectClone.gitCache.minWorkspaces=%d", *gitCache.MinWorkspaces))
+				}
+				if gitCache.MaxRepositories != nil && *gitCache.MaxRepositories != *defaultGitCache.MaxRepositories {
+					config = append(config, fmt.Sprintf("workspace.projectClone.gitCache.maxRepositories=%d", *gitCache.MaxRepositories))
+				}
+			}
 			if !reflect.DeepEqual(workspace.ProjectCloneConfig.Resources, defaultConfig.Workspace.ProjectCloneConfig.Resources) {
 				config = append(config, "workspace.projectClone.resources is set")
 			}
@@ -769,6 +985,43 @@ func GetCurrentConfigString(currConfig *controller.OperatorConfiguration) string
 			if workspace.CleanupCronJob.Schedule != defaultConfig.Workspace.CleanupCronJob.Schedule {
 				config = append(config, fmt.Sprintf("workspace.cleanupCronJob.cronJobScript=%s", workspace.CleanupCronJob.Schedule))
 			}
+			if workspace.CleanupCronJob.OrphanedStorageCleanup != nil {
+				orphanedStorageCleanup := workspace.CleanupCronJob.OrphanedStorageCleanup
+				defaultOrphanedStorageCleanup := defaultConfig.Workspace.CleanupCronJob.OrphanedStorageCleanup
+				if orphanedStorageCleanup.Enable != nil && *orphanedStorageCleanup.Enable != *defaultOrphanedStorageCleanup.Enable {
+					config = append(config, fmt.Sprintf("workspace.cleanupCronJob.orphanedStorageCleanup.enable=%t", *orphanedStorageCleanup.Enable))
+				}
+				if orphanedStorageCleanup.DryRun != nil && *orphanedStorageCleanup.DryRun != *defaultOrphanedStorageCleanup.DryRun {
+					config = append(config, fmt.Sprintf("workspace.cleanupCronJob.orphanedStorageCleanup.dryRun=%t", *orphanedStorageCleanup.DryRun))
+				}
+			}
+			if workspace.CleanupCronJob.StorageUsage != nil {
+				storageUsage := workspace.CleanupCronJob.StorageUsage
+				if storageUsage.Enable != nil && *storageUsage.Enable != *defaultConfig.Workspace.CleanupCronJob.StorageUsage.Enable {
+					config = append(config, fmt.Sprintf("workspace.cleanupCronJob.storageUsage.enable=%t", *storageUsage.Enable))
+				}
+				if storageUsage.MinimumUsageForPruning != nil {
+					config = append(config, fmt.Sprintf("workspace.cleanupCronJob.storageUsage.minimumUsageForPruning=%s", storageUsage.MinimumUsageForPruning.String()))
+				}
+				if storageUsage.KubeletStats != nil && *storageUsage.KubeletStats != *defaultConfig.Workspace.CleanupCronJob.StorageUsage.KubeletStats {
+					config = append(config, fmt.Sprintf("workspace.cleanupCronJob.storageUsage.kubeletStats=%t", *storageUsage.KubeletStats))
+				}
+			}
+			if len(workspace.CleanupCronJob.Policies) > 0 {
+				config = append(config, fmt.Sprintf("workspace.cleanupCronJob.policies=%d", len(workspace.CleanupCronJob.Policies)))
+			}
+			if workspace.CleanupCronJob.MaxStoppedWorkspacesPerUser != nil {
+				config = append(config, fmt.Sprintf("workspace.cleanupCronJob.maxStoppedWorkspacesPerUser=%d", *workspace.CleanupCronJob.MaxStoppedWorkspacesPerUser))
+			}
+			if workspace.CleanupCronJob.GracePeriod != nil {
+				config = append(config, fmt.Sprintf("workspace.cleanupCronJob.gracePeriod=%d", *workspace.CleanupCronJob.GracePeriod))
+			}
+			if workspace.CleanupCronJob.Notification != nil && workspace.CleanupCronJob.Notification.WebhookURL != "" {
+				config = append(config, "workspace.cleanupCronJob.notification.webhookURL is set")
+			}
+			if workspace.CleanupCronJob.RequireBackup != nil {
+				config = append(config, fmt.Sprintf("workspace.cleanupCronJob.requireBackup=%t", *workspace.CleanupCronJob.RequireBackup))
+			}
 		}
 		if workspace.BackupCronJob != nil {
 			if workspace.BackupCronJob.Enable != nil && *workspace.BackupCronJob.Enable != *defaultConfig.Workspace.BackupCronJob.Enable {
@@ -781,6 +1034,43 @@ func GetCurrentConfigString(currConfig *controller.OperatorConfiguration) string
 				config = append(config, fmt.Sprintf("workspace.backupCronJob.registry.path=%s", workspace.BackupCronJob.Registry.Path))
 				config = append(config, fmt.Sprintf("workspace.backupCronJob.registry.authSecret=%s", workspace.BackupCronJob.Registry.AuthSecret))
 			}
+			if workspace.BackupCronJob.Encryption != nil {
+				if workspace.BackupCronJob.Encryption.Required != nil {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.encryption.required=%t", *workspace.BackupCronJob.Encryption.Required))
+				}
+				if workspace.BackupCronJob.Encryption.KeySecret != "" {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.encryption.keySecret=%s", workspace.BackupCronJob.Encryption.KeySecret))
+				}
+			}
+			if workspace.BackupCronJob.RunningWorkspaces != nil {
+				if workspace.BackupCronJob.RunningWorkspaces.Enable != nil {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.runningWorkspaces.enable=%t", *workspace.BackupCronJob.RunningWorkspaces.Enable))
+				}
+				if workspace.BackupCronJob.RunningWorkspaces.VolumeSnapshotClassName != nil {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.runningWorkspaces.volumeSnapshotClassName=%s", *workspace.BackupCronJob.RunningWorkspaces.VolumeSnapshotClassName))
+				}
+			}
+			if workspace.BackupCronJob.Include != nil {
+				if workspace.BackupCronJob.Include.PersistentHome != nil {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.include.persistentHome=%t", *workspace.BackupCronJob.Include.PersistentHome))
+				}
+				if len(workspace.BackupCronJob.Include.Volumes) > 0 {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.include.volumes=%s", strings.Join(workspace.BackupCronJob.Include.Volumes, ",")))
+				}
+			}
+			if workspace.BackupCronJob.S3 != nil {
+				config = append(config, fmt.Sprintf("workspace.backupCronJob.s3.endpoint=%s", workspace.BackupCronJob.S3.Endpoint))
+				config = append(config, fmt.Sprintf("workspace.backupCronJob.s3.bucket=%s", workspace.BackupCronJob.S3.Bucket))
+				if workspace.BackupCronJob.S3.Prefix != "" {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.s3.prefix=%s", workspace.BackupCronJob.S3.Prefix))
+				}
+				if workspace.BackupCronJob.S3.Region != "" {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.s3.region=%s", workspace.BackupCronJob.S3.Region))
+				}
+				if workspace.BackupCronJob.S3.CredentialsSecret != "" {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.s3.credentialsSecret=%s", workspace.BackupCronJob.S3.CredentialsSecret))
+				}
+			}
 			if workspace.BackupCronJob.OrasConfig != nil {
 				if workspace.BackupCronJob.OrasConfig.ExtraArgs != "" {
 					config = append(config, fmt.Sprintf("workspace.backupCronJob.orasConfig.extraArgs=%s", workspace.BackupCronJob.OrasConfig.ExtraArgs))
@@ -789,6 +1079,27 @@ func GetCurrentConfigString(currConfig *controller.OperatorConfiguration) string
 			if workspace.BackupCronJob.BackoffLimit != nil && *workspace.BackupCronJob.BackoffLimit != *defaultConfig.Workspace.BackupCronJob.BackoffLimit {
 				config = append(config, fmt.Sprintf("workspace.backupCronJob.backoffLimit=%d", *workspace.BackupCronJob.BackoffLimit))
 			}
+			if workspace.BackupCronJob.Retention != nil {
+				retention := workspace.BackupCronJob.Retention
+				if retention.KeepLast != nil {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.retention.keepLast=%d", *retention.KeepLast))
+				}
+				if retention.KeepDaily != nil {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.retention.keepDaily=%d", *retention.KeepDaily))
+				}
+				if retention.KeepWeekly != nil {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.retention.keepWeekly=%d", *retention.KeepWeekly))
+				}
+				if retention.MaxAge != nil {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.retention.maxAge=%d", *retention.MaxAge))
+				}
+				if retention.DeleteOnWorkspaceDeletion != nil {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.retention.deleteOnWorkspaceDeletion=%t", *retention.DeleteOnWorkspaceDeletion))
+				}
+				if retention.DryRun != nil {
+					config = append(config, fmt.Sprintf("workspace.backupCronJob.retention.dryRun=%t", *retention.DryRun))
+				}
+			}
 		}
 		if workspace.HostUsers != nil {
 			config = append(config, fmt.Sprintf("workspace.hostUsers=%t", *workspace.HostUsers))
@@ -808,6 +1119,20 @@ func GetCurrentConfigString(currConfig *controller.OperatorConfiguration) string
 			}
 			config = append(config, fmt.Sprintf("workspace.initContainers=[%s]", strings.Join(initContainerNames, ", ")))
 		}
+		if len(workspace.SharedCacheVolumes) > 0 {
+			sharedCacheVolumeNames := make([]string, len(workspace.SharedCacheVolumes))
+			for i, volume := range workspace.SharedCacheVolumes {
+				sharedCacheVolumeNames[i] = volume.Name
+			}
+			config = append(config, fmt.Sprintf("workspace.sharedCacheVolumes=[%s]", strings.Join(sharedCacheVolumeNames, ", ")))
+		}
+		if len(workspace.StorageProfiles) > 0 {
+			storageProfileNames := make([]string, len(workspace.StorageProfiles))
+			for i, profile := range workspace.StorageProfiles {
+				storageProfileNames[i] = profile.Name
+			}
+			config = append(config, fmt.Sprintf("workspace.storageProfiles=[%s]", strings.Join(storageProfileNames, ", ")))
+		}
 	}
 	if currConfig.EnableExperimentalFeatures != nil && *currConfig.EnableExperimentalFeatures {
 		config = append(config, "enableExperimentalFeatures=true")
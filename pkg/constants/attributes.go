@@ -172,6 +172,65 @@ const (
 	//
 	WorkspaceRestoreSourceImageAttribute = "controller.devfile.io/restore-source-image"
 
+	// WorkspaceRestoreBackupAttribute selects which backup to restore when the backup source is determined from the
+	// cluster configuration. The value can be the tag of a backup (e.g. "backup-20261018T010000Z"), the time the
+	// backup was created in RFC3339 format (e.g. "2026-10-18T01:00:00Z") or the digest of the backup artifact (e.g.
+	// "sha256:..."). The backups available for a DevWorkspace are listed in its backup history ConfigMap. If this
+	// attribute is not set, the most recent backup is restored. Ignored if WorkspaceRestoreSourceImageAttribute is set.
+	WorkspaceRestoreBackupAttribute = "controller.devfile.io/restore-backup"
+
+	// WorkspaceRestoreSourceWorkspaceAttribute defines the DevWorkspace whose backups should be restored, which allows
+	// restoring the backup of a DevWorkspace into a new DevWorkspace. The value is the name of a DevWorkspace in the
+	// same namespace; backups of DevWorkspaces in other namespaces cannot be restored. The source DevWorkspace does not
+	// need to exist anymore, as long as its backups are still available. Ignored if WorkspaceRestoreSourceImageAttribute
+	// is set.
+	// For example:
+	//
+	//     spec:
+	//       template:
+	//         attributes:
+	//           controller.devfile.io/restore-workspace: true
+	//           controller.devfile.io/restore-source-workspace: my-workspace
+	//           controller.devfile.io/restore-backup: "2026-10-18T01:00:00Z"
+	//
+	WorkspaceRestoreSourceWorkspaceAttribute = "controller.devfile.io/restore-source-workspace"
+
+	// CloneFromAttribute defines the name of an existing DevWorkspace in the same namespace that a new DevWorkspace
+	// should be cloned from. When this attribute is set, the new DevWorkspace's PVC is pre-populated with the contents
+	// of the source DevWorkspace's PVC when it is first created, instead of starting from empty storage. Projects that
+	// already exist in the cloned storage are not cloned again from git.
+	// Both the source and the new DevWorkspace must use the 'per-workspace' storage type. If the
+	// workspace.volumeSnapshotClassName field is set in the DevWorkspaceOperatorConfig, the source PVC is cloned via a
+	// VolumeSnapshot; otherwise, the source PVC is used directly as the data source of the new PVC.
+	// For example:
+	//
+	//     spec:
+	//       template:
+	//         attributes:
+	//           controller.devfile.io/storage-type: per-workspace
+	//           controller.devfile.io/clone-from: my-workspace
+	//
+	CloneFromAttribute = "controller.devfile.io/clone-from"
+
+	// StorageProfileAttribute defines the name of a storage profile (defined in the DevWorkspaceOperatorConfig's
+	// workspace.storageProfiles field) that should be used for the PVCs backing a DevWorkspace. The storage profile
+	// determines the storage class, access modes, default size and volume mode of the PVC. If the DevWorkspace uses
+	// the common or async storage strategy, the profile is only applied when the shared PVC is first created.
+	//
+	// Example DevWorkspace:
+	//
+	//       kind: DevWorkspace
+	//       apiVersion: workspace.devfile.io/v1alpha2
+	//       metadata:
+	//         name: my-workspace
+	//       spec:
+	//         template:
+	//           attributes:
+	//             controller.devfile.io/storage-type: per-workspace
+	//             controller.devfile.io/storage-profile: fast-ssd
+	//
+	StorageProfileAttribute = "controller.devfile.io/storage-profile"
+
 	// MountOnStartAttribute is an attribute applied to Kubernetes resources to indicate that they should only
 	// be mounted to a workspace when it starts. When this attribute is set to "true", newly created
 	// resources will not be automatically mounted to running workspaces, preventing unwanted workspace
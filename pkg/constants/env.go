@@ -45,4 +45,32 @@ const (
 	DevWorkspaceComponentName = "DEVWORKSPACE_COMPONENT_NAME"
 	DISPLAY                   = "DISPLAY"
 	SSHAskPass                = "SSH_ASKPASS"
+
+	// ProjectCloneParallelism contains env var name which value is the maximum number of projects that the
+	// project-clone container sets up concurrently
+	ProjectCloneParallelism = "PROJECT_CLONE_PARALLELISM"
+
+	// ProjectCloneSSHHostKeyPolicy contains env var name which value is the policy used by the project-clone
+	// container to verify host keys of SSH remotes
+	ProjectCloneSSHHostKeyPolicy = "PROJECT_CLONE_SSH_HOST_KEY_POLICY"
+
+	// ProjectCloneSSHKnownHosts contains env var name which value is a newline-separated list of known_hosts
+	// entries trusted by the project-clone container
+	ProjectCloneSSHKnownHosts = "PROJECT_CLONE_SSH_KNOWN_HOSTS"
+
+	// ProjectCloneDepth contains env var name which value is the default depth used by the project-clone
+	// container when cloning git projects
+	ProjectCloneDepth = "PROJECT_CLONE_DEPTH"
+
+	// ProjectCloneFilter contains env var name which value is the default partial clone filter used by the
+	// project-clone container when cloning git projects
+	ProjectCloneFilter = "PROJECT_CLONE_FILTER"
+
+	// ProjectCloneSingleBranch contains env var name which value indicates whether the project-clone container
+	// clones only a single branch of git projects by default
+	ProjectCloneSingleBranch = "PROJECT_CLONE_SINGLE_BRANCH"
+
+	// ProjectCloneGitCachePath contains env var name which value is the path where the git mirror cache is
+	// mounted in the project-clone container
+	ProjectCloneGitCachePath = "PROJECT_CLONE_GIT_CACHE_PATH"
 )
@@ -29,4 +29,8 @@ const (
 	// serviceaccount is added to the workspace rolebinding, it is necessary to remove it
 	// when a workspace is deleted
 	RBACCleanupFinalizer = "rbac.controller.devfile.io"
+	// BackupCleanupFinalizer is used to block DevWorkspace deletion until the DevWorkspace's backups are
+	// removed from the backup registry. It is only added when backups should be removed on DevWorkspace
+	// deletion and is managed by the backup controller.
+	BackupCleanupFinalizer = "backup.controller.devfile.io"
 )
@@ -122,6 +122,12 @@ const (
 	// SSHSecretPassphraseKey is the key used to retrieve the optional passphrase stored inside the SSH secret.
 	SSHSecretPassphraseKey = "passphrase"
 
+	// SSHSecretPrivateKeyKey is the key used to retrieve the private SSH key stored inside the SSH secret.
+	SSHSecretPrivateKeyKey = "dwo_ssh_key"
+
+	// SSHSecretKnownHostsKey is the key used to retrieve the optional known_hosts entries stored inside the SSH secret.
+	SSHSecretKnownHostsKey = "known_hosts"
+
 	SshAskPassConfigMapName = "devworkspace-ssh-askpass"
 
 	// GitCredentialsMergedSecretName is the name for the merged Git credentials secret that is mounted to workspaces
@@ -195,14 +201,75 @@ const (
 	// in that namespace. Value should be json-encoded map[string]string
 	NamespaceNodeSelectorAnnotation = "controller.devfile.io/node-selector"
 
+	// NamespaceAllowedStorageProfilesAnnotation is an annotation applied to a namespace to restrict the storage profiles
+	// that DevWorkspaces in that namespace may select. Value should be a comma-separated list of storage profile names.
+	// If the annotation is not set, all storage profiles defined in the DevWorkspaceOperatorConfig are allowed.
+	NamespaceAllowedStorageProfilesAnnotation = "controller.devfile.io/allowed-storage-profiles"
+
 	// DevWorkspaceBackupJobNamePrefix is the prefix used for backup jobs created for DevWorkspaces
 	DevWorkspaceBackupJobNamePrefix = "devworkspace-backup-"
 
 	// DevWorkspaceBackupJobLabel is the label key to identify backup jobs created for DevWorkspaces
 	DevWorkspaceBackupJobLabel = "controller.devfile.io/backup-job"
 
+	// DevWorkspaceBackupHistoryLabel is the label key to identify ConfigMaps that store the backup history of a
+	// DevWorkspace
+	DevWorkspaceBackupHistoryLabel = "controller.devfile.io/backup-history"
+
+	// DevWorkspaceBackupDeletionJobNamePrefix is the prefix used for jobs that remove the backups of deleted DevWorkspaces
+	DevWorkspaceBackupDeletionJobNamePrefix = "devworkspace-backup-deletion-"
+
+	// DevWorkspaceBackupDeletionJobLabel is the label key to identify jobs that remove the backups of deleted DevWorkspaces
+	DevWorkspaceBackupDeletionJobLabel = "controller.devfile.io/backup-deletion-job"
+
+	// DevWorkspaceOrphanedStorageCleanupJobLabel is the label key to identify jobs that remove orphaned workspace
+	// directories from common PVCs
+	DevWorkspaceOrphanedStorageCleanupJobLabel = "controller.devfile.io/orphaned-storage-cleanup-job"
+
+	// DevWorkspaceStorageUsageJobLabel is the label key to identify jobs that compute the storage usage of
+	// workspace directories in common PVCs
+	DevWorkspaceStorageUsageJobLabel = "controller.devfile.io/storage-usage-job"
+
+	// DevWorkspaceGitCacheRefreshJobLabel is the label key to identify jobs that refresh the git mirrors in a
+	// git mirror cache PVC
+	DevWorkspaceGitCacheRefreshJobLabel = "controller.devfile.io/git-cache-refresh-job"
+
+	// DevWorkspaceStorageUsageAnnotation is an annotation that stores the storage usage (in bytes) of a DevWorkspace,
+	// as last collected by the cleanup cron job. A human-readable version of this value is reported in the
+	// 'StorageUsage' condition of the DevWorkspace.
+	DevWorkspaceStorageUsageAnnotation = "controller.devfile.io/storage-usage-bytes"
+
+	// DevWorkspacePruneProtectedAnnotation can be set to "true" on a DevWorkspace to exempt it from pruning by the
+	// cleanup cron job, regardless of the configured retain times and pruning policies.
+	DevWorkspacePruneProtectedAnnotation = "controller.devfile.io/prune-protected"
+
+	// DevWorkspaceScheduledForDeletionAtAnnotation is set by the cleanup cron job on DevWorkspaces that are eligible for
+	// pruning when a grace period is configured. Its value is the time (RFC 3339) after which the DevWorkspace is
+	// deleted if it is still eligible for pruning.
+	DevWorkspaceScheduledForDeletionAtAnnotation = "controller.devfile.io/scheduled-for-deletion-at"
+
+	// DevWorkspaceDeletionNotifiedAtAnnotation is set by the cleanup cron job together with the
+	// scheduled-for-deletion-at annotation, to the time (RFC 3339) the user was notified that the DevWorkspace is
+	// scheduled for deletion. If the user removes the scheduled-for-deletion-at annotation but this annotation is
+	// kept, the scheduled deletion is cancelled and the DevWorkspace is not scheduled for deletion again until it is
+	// started or stopped.
+	DevWorkspaceDeletionNotifiedAtAnnotation = "controller.devfile.io/deletion-notified-at"
+
+	// DevWorkspaceProjectCloneResultsAnnotation is set by the DevWorkspace controller to the results reported by
+	// the project clone container the last time the DevWorkspace was started. Its value is a JSON object with the
+	// name, state, error, checked out commit, and setup duration of each project.
+	DevWorkspaceProjectCloneResultsAnnotation = "controller.devfile.io/project-clone-results"
+
 	DevWorkspaceBackupAuthSecretName = "devworkspace-backup-registry-auth"
 
+	// DevWorkspaceBackupS3CredentialsSecretName is the name of the secret in workspace namespaces that contains the
+	// credentials used to store backups in S3-compatible object storage
+	DevWorkspaceBackupS3CredentialsSecretName = "devworkspace-backup-s3-credentials"
+
+	// DevWorkspaceBackupEncryptionKeySecretName is the name of the secret in workspace namespaces that contains the
+	// key used to encrypt and decrypt backups
+	DevWorkspaceBackupEncryptionKeySecretName = "devworkspace-backup-encryption-key"
+
 	// DevWorkspaceLastBackupSuccessfulAnnotation is an annotation that indicates whether the last backup
 	// attempt for this DevWorkspace was successful. Value is either "true" or "false".
 	DevWorkspaceLastBackupSuccessfulAnnotation = "controller.devfile.io/last-backup-successful"
@@ -215,4 +282,9 @@ const (
 	// failed backup attempt. This annotation is only present when the last backup failed, and is cleared
 	// when a backup succeeds.
 	DevWorkspaceLastBackupErrorAnnotation = "controller.devfile.io/last-backup-error"
+
+	// DevWorkspaceBackupNowAnnotation can be set to "true" on a DevWorkspace to request a backup of the DevWorkspace
+	// outside the configured backup schedule. The annotation is removed once the backup job is created, and the
+	// result of the backup is recorded in the last-backup-* annotations.
+	DevWorkspaceBackupNowAnnotation = "controller.devfile.io/backup-now"
 )
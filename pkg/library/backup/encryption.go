@@ -0,0 +1,99 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"fmt"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// Environment variables used to pass the encryption configuration to the workspace-recovery binary
+	EncryptionRequiredEnvVar   = "BACKUP_ENCRYPTION_REQUIRED"
+	EncryptionKeyEnvVar        = "BACKUP_ENCRYPTION_KEY"
+	EncryptionPublicKeyEnvVar  = "BACKUP_ENCRYPTION_PUBLIC_KEY"
+	EncryptionPrivateKeyEnvVar = "BACKUP_ENCRYPTION_PRIVATE_KEY"
+
+	// Keys of the encryption key secret
+	EncryptionKeySecretKey        = "key"
+	EncryptionPublicKeySecretKey  = "publicKey"
+	EncryptionPrivateKeySecretKey = "privateKey"
+)
+
+// IsEncryptionRequired returns whether backups must be encrypted according to the backup configuration.
+func IsEncryptionRequired(config *controllerv1alpha1.BackupCronJobConfig) bool {
+	return config != nil && config.Encryption != nil && config.Encryption.Required != nil && *config.Encryption.Required
+}
+
+// ValidateEncryptionConfig returns an error if encryption of backups is required but no encryption key is configured.
+func ValidateEncryptionConfig(config *controllerv1alpha1.BackupCronJobConfig) error {
+	if IsEncryptionRequired(config) && config.Encryption.KeySecret == "" {
+		return fmt.Errorf("backup encryption is required, but no encryption key secret is configured")
+	}
+	return nil
+}
+
+// ValidateEncryptionKeySecret returns an error if the given encryption key secret does not contain a key that can be
+// used to encrypt backups. Backups are never encrypted with a private key, as backup jobs only have access to the
+// public key.
+func ValidateEncryptionKeySecret(secret *corev1.Secret) error {
+	for _, key := range []string{EncryptionKeySecretKey, EncryptionPublicKeySecretKey} {
+		if len(secret.Data[key]) > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("encryption key secret %s does not contain any of the keys %q or %q", secret.Name,
+		EncryptionKeySecretKey, EncryptionPublicKeySecretKey)
+}
+
+// GetBackupEncryptionEnv returns the environment variables used to pass the encryption configuration to the
+// workspace-recovery binary when creating backups. The symmetric key or, if not present, the public key is read from
+// the given secret in the workspace namespace. The private key is never passed to backup jobs.
+func GetBackupEncryptionEnv(config *controllerv1alpha1.BackupCronJobConfig, keySecret *corev1.Secret) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if IsEncryptionRequired(config) {
+		env = append(env, corev1.EnvVar{Name: EncryptionRequiredEnvVar, Value: "true"})
+	}
+	if keySecret == nil {
+		return env
+	}
+	switch {
+	case len(keySecret.Data[EncryptionKeySecretKey]) > 0:
+		env = append(env, getSecretKeyEnv(EncryptionKeyEnvVar, keySecret.Name, EncryptionKeySecretKey))
+	case len(keySecret.Data[EncryptionPublicKeySecretKey]) > 0:
+		env = append(env, getSecretKeyEnv(EncryptionPublicKeyEnvVar, keySecret.Name, EncryptionPublicKeySecretKey))
+	}
+	return env
+}
+
+// GetRestoreEncryptionEnv returns the environment variables used to pass the keys required to decrypt backups to the
+// workspace-recovery binary when restoring backups. Keys are read from the given secret in the workspace namespace,
+// if any.
+func GetRestoreEncryptionEnv(keySecret *corev1.Secret) []corev1.EnvVar {
+	if keySecret == nil {
+		return nil
+	}
+	var env []corev1.EnvVar
+	if len(keySecret.Data[EncryptionKeySecretKey]) > 0 {
+		env = append(env, getSecretKeyEnv(EncryptionKeyEnvVar, keySecret.Name, EncryptionKeySecretKey))
+	}
+	if len(keySecret.Data[EncryptionPrivateKeySecretKey]) > 0 {
+		env = append(env, getSecretKeyEnv(EncryptionPrivateKeyEnvVar, keySecret.Name, EncryptionPrivateKeySecretKey))
+	}
+	return env
+}
@@ -0,0 +1,174 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package encryption implements client-side encryption of workspace backup layers.
+//
+// Layers are encrypted in the age format (https://age-encryption.org/v1), either for an X25519 recipient or with a
+// symmetric key that is used as an scrypt passphrase. Backups can be created with only the X25519 public key (the age
+// recipient); the private key (the age identity) is required to restore them.
+package encryption
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+)
+
+const (
+	// SymmetricKeySize is the size of symmetric encryption keys, in bytes
+	SymmetricKeySize = 32
+
+	// scryptWorkFactor is the scrypt work factor used to derive file keys from symmetric keys. Symmetric keys are
+	// random rather than passwords, so a low work factor keeps the time and memory used for each layer small (32 MiB)
+	// without making brute-forcing the key feasible.
+	scryptWorkFactor = 15
+
+	infoFingerprint = "devworkspace-backup-v1 fingerprint"
+)
+
+// ErrDecryptionFailed is returned if an encrypted layer cannot be decrypted, either because it was encrypted with a
+// different key or because it is corrupted.
+var ErrDecryptionFailed = errors.New("failed to decrypt backup layer: wrong key or corrupted data")
+
+// Key is a key used to encrypt or decrypt backup layers: a symmetric key, an X25519 public key (which can only be used
+// to encrypt) or an X25519 private key.
+type Key struct {
+	recipient      age.Recipient
+	identity       age.Identity
+	fingerprintKey []byte
+}
+
+// ParseSymmetricKey parses a base64-encoded 32-byte symmetric key, e.g. generated with "openssl rand -base64 32".
+func ParseSymmetricKey(encoded string) (*Key, error) {
+	encoded = strings.TrimSpace(encoded)
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid symmetric encryption key: %w", err)
+	}
+	if len(key) != SymmetricKeySize {
+		return nil, fmt.Errorf("invalid symmetric encryption key: expected %d bytes, got %d", SymmetricKeySize, len(key))
+	}
+	recipient, err := age.NewScryptRecipient(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid symmetric encryption key: %w", err)
+	}
+	recipient.SetWorkFactor(scryptWorkFactor)
+	identity, err := age.NewScryptIdentity(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid symmetric encryption key: %w", err)
+	}
+	fingerprintKey, err := hkdf.Key(sha256.New, key, nil, infoFingerprint, sha256.Size)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{recipient: recipient, identity: identity, fingerprintKey: fingerprintKey}, nil
+}
+
+// ParsePublicKey parses an age X25519 recipient, e.g. "age1..." as printed by "age-keygen".
+func ParsePublicKey(encoded string) (*Key, error) {
+	recipient, err := age.ParseX25519Recipient(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public encryption key: %w", err)
+	}
+	return &Key{recipient: recipient, fingerprintKey: []byte(recipient.String())}, nil
+}
+
+// ParsePrivateKey parses an age X25519 identity, e.g. "AGE-SECRET-KEY-1..." or a key file generated with "age-keygen".
+func ParsePrivateKey(encoded string) (*Key, error) {
+	identities, err := age.ParseIdentities(strings.NewReader(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private encryption key: %w", err)
+	}
+	if len(identities) != 1 {
+		return nil, fmt.Errorf("invalid private encryption key: expected a single key, got %d", len(identities))
+	}
+	identity, ok := identities[0].(*age.X25519Identity)
+	if !ok {
+		return nil, fmt.Errorf("invalid private encryption key: only X25519 keys are supported")
+	}
+	recipient := identity.Recipient()
+	return &Key{recipient: recipient, identity: identity, fingerprintKey: []byte(recipient.String())}, nil
+}
+
+// PublicKeyFromPrivateKey returns the age X25519 recipient of a private key parsed by ParsePrivateKey.
+func PublicKeyFromPrivateKey(encoded string) (string, error) {
+	key, err := ParsePrivateKey(encoded)
+	if err != nil {
+		return "", err
+	}
+	return key.recipient.(*age.X25519Recipient).String(), nil
+}
+
+// CanEncrypt returns whether the key can be used to encrypt backup layers.
+func (k *Key) CanEncrypt() bool {
+	return k != nil && k.recipient != nil
+}
+
+// CanDecrypt returns whether the key can be used to decrypt backup layers.
+func (k *Key) CanDecrypt() bool {
+	return k != nil && k.identity != nil
+}
+
+// Fingerprint returns a keyed hash of data, e.g. the digest of an unencrypted layer. As each layer is encrypted with a
+// random file key, fingerprints are used to recognize layers that are unchanged since a previous backup without
+// revealing the digest of their contents. Fingerprints change when the key changes.
+func (k *Key) Fingerprint(data string) string {
+	mac := hmac.New(sha256.New, k.fingerprintKey)
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewWriter returns a writer that encrypts data written to it and writes it to dst. The writer must be closed to write
+// the final chunk of encrypted data; closing it does not close dst.
+func NewWriter(dst io.Writer, key *Key) (io.WriteCloser, error) {
+	if !key.CanEncrypt() {
+		return nil, fmt.Errorf("key cannot be used for encryption")
+	}
+	return age.Encrypt(dst, key.recipient)
+}
+
+// NewReader returns a reader that decrypts the data read from src, which must have been encrypted with NewWriter.
+// Reading returns ErrDecryptionFailed if the data was encrypted with a different key or was modified.
+func NewReader(src io.Reader, key *Key) (io.Reader, error) {
+	if !key.CanDecrypt() {
+		return nil, fmt.Errorf("key cannot be used for decryption")
+	}
+	decrypted, err := age.Decrypt(src, key.identity)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+	}
+	return &reader{src: decrypted}, nil
+}
+
+// reader wraps errors returned while decrypting the payload of a layer in ErrDecryptionFailed.
+type reader struct {
+	src io.Reader
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.src.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+	}
+	return n, err
+}
@@ -0,0 +1,216 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package encryption_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/encryption"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/encryption/encryptiontest"
+)
+
+// payloadChunkSize is the size of the chunks that age encrypts the payload in
+const payloadChunkSize = 64 * 1024
+
+func encrypt(t *testing.T, key *encryption.Key, plain []byte) []byte {
+	var encrypted bytes.Buffer
+	writer, err := encryption.NewWriter(&encrypted, key)
+	require.NoError(t, err)
+	// Write in uneven pieces to exercise chunking
+	for len(plain) > 0 {
+		n := min(len(plain), 10000)
+		_, err := writer.Write(plain[:n])
+		require.NoError(t, err)
+		plain = plain[n:]
+	}
+	require.NoError(t, writer.Close())
+	return encrypted.Bytes()
+}
+
+func decrypt(key *encryption.Key, encrypted []byte) ([]byte, error) {
+	reader, err := encryption.NewReader(bytes.NewReader(encrypted), key)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+func TestRoundTrip(t *testing.T) {
+	symmetricKey := encryptiontest.NewSymmetricKey(t)
+	publicKey, privateKey := encryptiontest.NewX25519Keys(t)
+
+	for _, size := range []int{0, 1, payloadChunkSize - 1, payloadChunkSize, payloadChunkSize + 1, 3*payloadChunkSize + 123} {
+		plain := make([]byte, size)
+		_, err := rand.Read(plain)
+		require.NoError(t, err)
+
+		decrypted, err := decrypt(symmetricKey, encrypt(t, symmetricKey, plain))
+		assert.NoError(t, err, "symmetric key, size %d", size)
+		assert.Equal(t, plain, append([]byte{}, decrypted...), "symmetric key, size %d", size)
+
+		decrypted, err = decrypt(privateKey, encrypt(t, publicKey, plain))
+		assert.NoError(t, err, "X25519 key, size %d", size)
+		assert.Equal(t, plain, append([]byte{}, decrypted...), "X25519 key, size %d", size)
+	}
+}
+
+func TestEncryptedLayersUseAgeFormat(t *testing.T) {
+	publicKey, _ := encryptiontest.NewX25519Keys(t)
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	privateKey, err := encryption.ParsePrivateKey(identity.String())
+	require.NoError(t, err)
+
+	// Layers can be decrypted with the age CLI or library, without the workspace-recovery binary
+	encrypted := encrypt(t, privateKey, []byte("workspace data"))
+	assert.True(t, bytes.HasPrefix(encrypted, []byte("age-encryption.org/v1\n")))
+	reader, err := age.Decrypt(bytes.NewReader(encrypted), identity)
+	require.NoError(t, err)
+	decrypted, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "workspace data", string(decrypted))
+
+	_, err = age.Decrypt(bytes.NewReader(encrypt(t, publicKey, []byte("workspace data"))), identity)
+	assert.Error(t, err)
+}
+
+func TestEncryptionIsRandomized(t *testing.T) {
+	key := encryptiontest.NewSymmetricKey(t)
+	plain := []byte("workspace data")
+	assert.NotEqual(t, encrypt(t, key, plain), encrypt(t, key, plain))
+}
+
+func TestDecryptWithWrongKey(t *testing.T) {
+	encrypted := encrypt(t, encryptiontest.NewSymmetricKey(t), []byte("workspace data"))
+	_, err := decrypt(encryptiontest.NewSymmetricKey(t), encrypted)
+	assert.ErrorIs(t, err, encryption.ErrDecryptionFailed)
+
+	publicKey, _ := encryptiontest.NewX25519Keys(t)
+	_, otherPrivateKey := encryptiontest.NewX25519Keys(t)
+	_, err = decrypt(otherPrivateKey, encrypt(t, publicKey, []byte("workspace data")))
+	assert.ErrorIs(t, err, encryption.ErrDecryptionFailed)
+
+	_, err = decrypt(otherPrivateKey, encrypted)
+	assert.ErrorIs(t, err, encryption.ErrDecryptionFailed, "symmetric key encrypted layer with X25519 key")
+}
+
+func TestDecryptDetectsTampering(t *testing.T) {
+	key := encryptiontest.NewSymmetricKey(t)
+	plain := make([]byte, 2*payloadChunkSize+10)
+	encrypted := encrypt(t, key, plain)
+	overhead := 16
+	headerSize := bytes.Index(encrypted, []byte("\n---"))
+	headerSize += bytes.IndexByte(encrypted[headerSize+1:], '\n') + 2
+	// The payload starts with a 16 byte nonce
+	payloadStart := headerSize + 16
+
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)-1] ^= 1
+	_, err := decrypt(key, tampered)
+	assert.ErrorIs(t, err, encryption.ErrDecryptionFailed, "modified data")
+
+	tampered = append([]byte{}, encrypted...)
+	tampered[headerSize-2] ^= 1
+	_, err = decrypt(key, tampered)
+	assert.ErrorIs(t, err, encryption.ErrDecryptionFailed, "modified header")
+
+	truncated := encrypted[:payloadStart+2*(payloadChunkSize+overhead)]
+	_, err = decrypt(key, truncated)
+	assert.ErrorIs(t, err, encryption.ErrDecryptionFailed, "truncated at chunk boundary")
+}
+
+func TestKeyCapabilities(t *testing.T) {
+	symmetricKey := encryptiontest.NewSymmetricKey(t)
+	publicKey, privateKey := encryptiontest.NewX25519Keys(t)
+
+	assert.True(t, symmetricKey.CanEncrypt())
+	assert.True(t, symmetricKey.CanDecrypt())
+	assert.True(t, publicKey.CanEncrypt())
+	assert.False(t, publicKey.CanDecrypt())
+	assert.True(t, privateKey.CanEncrypt())
+	assert.True(t, privateKey.CanDecrypt())
+
+	_, err := encryption.NewReader(bytes.NewReader(nil), publicKey)
+	assert.Error(t, err)
+}
+
+func TestFingerprint(t *testing.T) {
+	symmetricKey := encryptiontest.NewSymmetricKey(t)
+	publicKey, privateKey := encryptiontest.NewX25519Keys(t)
+
+	assert.Equal(t, symmetricKey.Fingerprint("sha256:abc"), symmetricKey.Fingerprint("sha256:abc"))
+	assert.NotEqual(t, symmetricKey.Fingerprint("sha256:abc"), symmetricKey.Fingerprint("sha256:def"))
+	assert.NotEqual(t, symmetricKey.Fingerprint("sha256:abc"), encryptiontest.NewSymmetricKey(t).Fingerprint("sha256:abc"))
+	assert.Equal(t, publicKey.Fingerprint("sha256:abc"), privateKey.Fingerprint("sha256:abc"))
+}
+
+func TestPublicKeyFromPrivateKey(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	publicKey, err := encryption.PublicKeyFromPrivateKey(identity.String())
+	require.NoError(t, err)
+	assert.Equal(t, identity.Recipient().String(), publicKey)
+
+	_, err = encryption.PublicKeyFromPrivateKey(identity.Recipient().String())
+	assert.ErrorContains(t, err, "invalid private encryption key")
+}
+
+func TestParseKeyFiles(t *testing.T) {
+	raw := make([]byte, encryption.SymmetricKeySize)
+	_, err := rand.Read(raw)
+	require.NoError(t, err)
+	_, err = encryption.ParseSymmetricKey(base64.StdEncoding.EncodeToString(raw) + "\n")
+	assert.NoError(t, err)
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	publicKey, err := encryption.ParsePublicKey(identity.Recipient().String() + "\n")
+	require.NoError(t, err)
+	// Key files generated with age-keygen contain comments
+	privateKey, err := encryption.ParsePrivateKey("# created: 2026-10-19T00:00:00Z\n# public key: " + identity.Recipient().String() + "\n" + identity.String() + "\n")
+	require.NoError(t, err)
+	decrypted, err := decrypt(privateKey, encrypt(t, publicKey, []byte("workspace data")))
+	require.NoError(t, err)
+	assert.Equal(t, "workspace data", string(decrypted))
+}
+
+func TestParseInvalidKeys(t *testing.T) {
+	_, err := encryption.ParseSymmetricKey(base64.StdEncoding.EncodeToString([]byte("too short")))
+	assert.ErrorContains(t, err, "expected 32 bytes")
+	_, err = encryption.ParseSymmetricKey("not base64!")
+	assert.Error(t, err)
+	_, err = encryption.ParsePublicKey("not an age recipient")
+	assert.ErrorContains(t, err, "invalid public encryption key")
+	_, err = encryption.ParsePrivateKey("not an age identity")
+	assert.ErrorContains(t, err, "invalid private encryption key")
+
+	first, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	second, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	_, err = encryption.ParsePrivateKey(first.String() + "\n" + second.String())
+	assert.ErrorContains(t, err, "expected a single key")
+	_, err = encryption.ParsePublicKey(first.String())
+	assert.ErrorContains(t, err, "invalid public encryption key")
+}
@@ -0,0 +1,49 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package encryptiontest provides keys for testing code that uses the encryption package.
+package encryptiontest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/encryption"
+)
+
+// NewSymmetricKey returns a random symmetric key.
+func NewSymmetricKey(t *testing.T) *encryption.Key {
+	raw := make([]byte, encryption.SymmetricKeySize)
+	_, err := rand.Read(raw)
+	require.NoError(t, err)
+	key, err := encryption.ParseSymmetricKey(base64.StdEncoding.EncodeToString(raw))
+	require.NoError(t, err)
+	return key
+}
+
+// NewX25519Keys returns the public and private key of a random X25519 key pair.
+func NewX25519Keys(t *testing.T) (publicKey, privateKey *encryption.Key) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	publicKey, err = encryption.ParsePublicKey(identity.Recipient().String())
+	require.NoError(t, err)
+	privateKey, err = encryption.ParsePrivateKey(identity.String())
+	require.NoError(t, err)
+	return publicKey, privateKey
+}
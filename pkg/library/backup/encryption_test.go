@@ -0,0 +1,97 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"testing"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateEncryptionConfig(t *testing.T) {
+	assert.NoError(t, ValidateEncryptionConfig(nil))
+	assert.NoError(t, ValidateEncryptionConfig(&controllerv1alpha1.BackupCronJobConfig{}))
+	assert.NoError(t, ValidateEncryptionConfig(&controllerv1alpha1.BackupCronJobConfig{
+		Encryption: &controllerv1alpha1.BackupEncryptionConfig{Required: ptr.To(true), KeySecret: "backup-key"},
+	}))
+	assert.NoError(t, ValidateEncryptionConfig(&controllerv1alpha1.BackupCronJobConfig{
+		Encryption: &controllerv1alpha1.BackupEncryptionConfig{Required: ptr.To(false)},
+	}))
+	assert.ErrorContains(t, ValidateEncryptionConfig(&controllerv1alpha1.BackupCronJobConfig{
+		Encryption: &controllerv1alpha1.BackupEncryptionConfig{Required: ptr.To(true)},
+	}), "no encryption key secret is configured")
+}
+
+func TestGetEncryptionEnv(t *testing.T) {
+	config := &controllerv1alpha1.BackupCronJobConfig{
+		Encryption: &controllerv1alpha1.BackupEncryptionConfig{Required: ptr.To(true), KeySecret: "backup-key"},
+	}
+	keyPairSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "devworkspace-backup-encryption-key"},
+		Data: map[string][]byte{
+			EncryptionPublicKeySecretKey:  []byte("public"),
+			EncryptionPrivateKeySecretKey: []byte("private"),
+		},
+	}
+
+	backupEnv := GetBackupEncryptionEnv(config, keyPairSecret)
+	assert.Len(t, backupEnv, 2)
+	assert.Equal(t, corev1.EnvVar{Name: EncryptionRequiredEnvVar, Value: "true"}, backupEnv[0])
+	assert.Equal(t, EncryptionPublicKeyEnvVar, backupEnv[1].Name, "backups should only have access to the public key")
+	assert.Equal(t, EncryptionPublicKeySecretKey, backupEnv[1].ValueFrom.SecretKeyRef.Key)
+
+	restoreEnv := GetRestoreEncryptionEnv(keyPairSecret)
+	assert.Len(t, restoreEnv, 1)
+	assert.Equal(t, EncryptionPrivateKeyEnvVar, restoreEnv[0].Name)
+	assert.Equal(t, "devworkspace-backup-encryption-key", restoreEnv[0].ValueFrom.SecretKeyRef.Name)
+
+	symmetricKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "devworkspace-backup-encryption-key"},
+		Data:       map[string][]byte{EncryptionKeySecretKey: []byte("key")},
+	}
+	backupEnv = GetBackupEncryptionEnv(&controllerv1alpha1.BackupCronJobConfig{}, symmetricKeySecret)
+	assert.Len(t, backupEnv, 1)
+	assert.Equal(t, EncryptionKeyEnvVar, backupEnv[0].Name)
+	assert.Len(t, GetRestoreEncryptionEnv(symmetricKeySecret), 1)
+
+	privateKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "devworkspace-backup-encryption-key"},
+		Data:       map[string][]byte{EncryptionPrivateKeySecretKey: []byte("private")},
+	}
+	assert.Empty(t, GetBackupEncryptionEnv(&controllerv1alpha1.BackupCronJobConfig{}, privateKeySecret),
+		"backups should never have access to the private key")
+	assert.Len(t, GetRestoreEncryptionEnv(privateKeySecret), 1)
+
+	assert.Empty(t, GetBackupEncryptionEnv(&controllerv1alpha1.BackupCronJobConfig{}, nil))
+	assert.Empty(t, GetRestoreEncryptionEnv(nil))
+}
+
+func TestValidateEncryptionKeySecret(t *testing.T) {
+	assert.NoError(t, ValidateEncryptionKeySecret(&corev1.Secret{Data: map[string][]byte{EncryptionKeySecretKey: []byte("key")}}))
+	assert.NoError(t, ValidateEncryptionKeySecret(&corev1.Secret{Data: map[string][]byte{EncryptionPublicKeySecretKey: []byte("public")}}))
+	assert.ErrorContains(t, ValidateEncryptionKeySecret(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup-key"},
+		Data:       map[string][]byte{EncryptionPrivateKeySecretKey: []byte("private")},
+	}), "encryption key secret backup-key does not contain")
+	assert.ErrorContains(t, ValidateEncryptionKeySecret(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup-key"},
+		Data:       map[string][]byte{"other": []byte("key")},
+	}), "encryption key secret backup-key does not contain")
+}
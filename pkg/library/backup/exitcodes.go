@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package backup defines values shared between the workspace-recovery binary (used to back up and restore
+// workspace data) and the controllers that run it.
+package backup
+
+import "fmt"
+
+// WorkspaceRecoveryCommand is the path of the workspace-recovery binary in the project backup image. It backs up
+// workspace data when run with the --backup flag and restores it when run with the --restore flag.
+const WorkspaceRecoveryCommand = "/usr/local/bin/workspace-recovery"
+
+// Exit codes used by the workspace-recovery binary. The binary additionally writes a description of the error to
+// its termination message, so that the controller can report both the class of the failure and its details.
+const (
+	ExitCodeSuccess = 0
+	// ExitCodeUnknownError is used for errors that do not fit any other category.
+	ExitCodeUnknownError = 1
+	// ExitCodeInvalidConfiguration is used when required environment variables are missing or invalid.
+	ExitCodeInvalidConfiguration = 2
+	// ExitCodeSourceNotFound is used when the directory to back up does not exist.
+	ExitCodeSourceNotFound = 3
+	// ExitCodeArchiveFailed is used when creating or extracting the backup archive fails.
+	ExitCodeArchiveFailed = 4
+	// ExitCodeAuthenticationFailed is used when the registry rejects the provided credentials.
+	ExitCodeAuthenticationFailed = 5
+	// ExitCodeRegistryUnreachable is used when the registry cannot be reached, e.g. due to DNS or TLS errors.
+	ExitCodeRegistryUnreachable = 6
+	// ExitCodeBackupNotFound is used when the backup image to restore does not exist in the registry.
+	ExitCodeBackupNotFound = 7
+	// ExitCodeTransferFailed is used when pushing or pulling the backup fails after all retries.
+	ExitCodeTransferFailed = 8
+	// ExitCodeEncryptionFailed is used when the backup cannot be encrypted or decrypted, e.g. because no key or the
+	// wrong key is configured.
+	ExitCodeEncryptionFailed = 9
+)
+
+// DescribeExitCode returns a human-readable description of an exit code of the workspace-recovery binary.
+func DescribeExitCode(exitCode int32) string {
+	switch exitCode {
+	case ExitCodeSuccess:
+		return "backup completed successfully"
+	case ExitCodeInvalidConfiguration:
+		return "invalid backup configuration"
+	case ExitCodeSourceNotFound:
+		return "workspace data to back up was not found"
+	case ExitCodeArchiveFailed:
+		return "failed to archive workspace data"
+	case ExitCodeAuthenticationFailed:
+		return "authentication to the backup registry failed"
+	case ExitCodeRegistryUnreachable:
+		return "backup registry is unreachable"
+	case ExitCodeBackupNotFound:
+		return "backup image was not found in the registry"
+	case ExitCodeTransferFailed:
+		return "failed to transfer backup to or from the registry"
+	case ExitCodeEncryptionFailed:
+		return "failed to encrypt or decrypt backup"
+	default:
+		return fmt.Sprintf("backup failed with exit code %d", exitCode)
+	}
+}
@@ -0,0 +1,100 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// HistoryConfigMapKey is the key of the backup history ConfigMap that contains the history as a JSON list
+	HistoryConfigMapKey = "history.json"
+	// AvailableBackupsConfigMapKey is the key of the backup history ConfigMap that contains the backups available in
+	// the registry as a JSON list
+	AvailableBackupsConfigMapKey = "backups.json"
+	// MaxHistoryEntries is the maximum number of entries kept in the backup history of a workspace
+	MaxHistoryEntries = 10
+	// MaxAvailableBackups is the maximum number of available backups reported by a backup job. The list of backups is
+	// passed through the termination message of the backup container, which is limited to 4096 bytes.
+	MaxAvailableBackups = 20
+)
+
+// AvailableBackup describes a backup of a workspace that is available in the registry and can be restored.
+type AvailableBackup struct {
+	// Tag is the tag identifying the backup, which contains the time the backup was created (see BackupTag)
+	Tag string `json:"tag"`
+	// Digest is the digest of the backup artifact's manifest
+	Digest string `json:"digest"`
+}
+
+// HistoryEntry describes the outcome of a single backup of a workspace.
+type HistoryEntry struct {
+	// FinishedAt is the time the backup finished
+	FinishedAt time.Time `json:"finishedAt"`
+	// Successful is true if the backup succeeded
+	Successful bool `json:"successful"`
+	// Image is the reference of the backup artifact, tagged with the time the backup was created
+	Image string `json:"image,omitempty"`
+	// Digest is the digest of the backup artifact's manifest
+	Digest string `json:"digest,omitempty"`
+	// Size is the total size of the backup in bytes
+	Size int64 `json:"size,omitempty"`
+	// Error describes why the backup failed
+	Error string `json:"error,omitempty"`
+}
+
+// ParseHistory parses the backup history stored in a backup history ConfigMap. An empty string is parsed as an
+// empty history.
+func ParseHistory(data string) ([]HistoryEntry, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var history []HistoryEntry
+	if err := json.Unmarshal([]byte(data), &history); err != nil {
+		return nil, fmt.Errorf("failed to parse backup history: %w", err)
+	}
+	return history, nil
+}
+
+// AddHistoryEntry adds an entry to the front of a backup history, ordered from newest to oldest, and drops the oldest
+// entries if the history contains more than MaxHistoryEntries entries.
+func AddHistoryEntry(history []HistoryEntry, entry HistoryEntry) []HistoryEntry {
+	newHistory := append([]HistoryEntry{entry}, history...)
+	if len(newHistory) > MaxHistoryEntries {
+		newHistory = newHistory[:MaxHistoryEntries]
+	}
+	return newHistory
+}
+
+// SelectAvailableBackups returns the tags that identify individual backups, from newest to oldest, limited to the
+// MaxAvailableBackups most recent backups. Tags that do not identify individual backups (e.g. "latest") are ignored.
+func SelectAvailableBackups(tags []string) []string {
+	var backupTags []string
+	for _, tag := range tags {
+		if _, ok := ParseBackupTag(tag); ok {
+			backupTags = append(backupTags, tag)
+		}
+	}
+	// Backup tags contain the creation time in a format that sorts chronologically
+	sort.Sort(sort.Reverse(sort.StringSlice(backupTags)))
+	if len(backupTags) > MaxAvailableBackups {
+		backupTags = backupTags[:MaxAvailableBackups]
+	}
+	return backupTags
+}
@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddHistoryEntryIsBounded(t *testing.T) {
+	var history []HistoryEntry
+	for i := 0; i < MaxHistoryEntries+3; i++ {
+		history = AddHistoryEntry(history, HistoryEntry{FinishedAt: now.Add(time.Duration(i) * time.Hour), Successful: true})
+	}
+	assert.Len(t, history, MaxHistoryEntries)
+	assert.Equal(t, now.Add(time.Duration(MaxHistoryEntries+2)*time.Hour), history[0].FinishedAt, "newest entry should be first")
+	assert.Equal(t, now.Add(3*time.Hour), history[MaxHistoryEntries-1].FinishedAt, "oldest entries should be dropped")
+}
+
+func TestParseHistory(t *testing.T) {
+	history, err := ParseHistory("")
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+
+	expected := []HistoryEntry{
+		{FinishedAt: now, Successful: true, Image: "registry/ns/dw:" + BackupTag(now), Digest: "sha256:abc", Size: 1024},
+		{FinishedAt: now.Add(-time.Hour), Successful: false, Error: "backup failed"},
+	}
+	data, err := json.Marshal(expected)
+	assert.NoError(t, err)
+	history, err = ParseHistory(string(data))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, history)
+
+	_, err = ParseHistory("not-json")
+	assert.Error(t, err)
+}
+
+func TestSelectAvailableBackups(t *testing.T) {
+	tags := tagsAt(now.Add(-2*time.Hour), now, now.Add(-1*time.Hour))
+	tags = append(tags, "latest")
+	assert.Equal(t, tagsAt(now, now.Add(-1*time.Hour), now.Add(-2*time.Hour)), SelectAvailableBackups(tags))
+
+	tags = nil
+	for i := 0; i < MaxAvailableBackups+5; i++ {
+		tags = append(tags, BackupTag(now.Add(time.Duration(-i)*time.Hour)))
+	}
+	selected := SelectAvailableBackups(tags)
+	assert.Len(t, selected, MaxAvailableBackups)
+	assert.Equal(t, BackupTag(now), selected[0])
+}
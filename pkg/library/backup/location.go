@@ -0,0 +1,100 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"strings"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// S3LocationPrefix is the prefix of backup locations in S3-compatible object storage, which have the format
+	// s3://<bucket>/<path>
+	S3LocationPrefix = "s3://"
+
+	// Environment variables used to pass the S3 configuration to the workspace-recovery binary
+	S3EndpointEnvVar        = "BACKUP_S3_ENDPOINT"
+	S3RegionEnvVar          = "BACKUP_S3_REGION"
+	S3AccessKeyIDEnvVar     = "AWS_ACCESS_KEY_ID"
+	S3SecretAccessKeyEnvVar = "AWS_SECRET_ACCESS_KEY"
+
+	// Keys of the S3 credentials secret
+	S3AccessKeyIDSecretKey     = "accessKeyId"
+	S3SecretAccessKeySecretKey = "secretAccessKey"
+)
+
+// GetBackupLocation returns the location backups are stored in according to the backup configuration: either the
+// configured registry path, or "s3://<bucket>/<prefix>" if S3-compatible object storage is configured. Returns an
+// empty string if no backup location is configured.
+func GetBackupLocation(config *controllerv1alpha1.BackupCronJobConfig) string {
+	if config == nil {
+		return ""
+	}
+	if config.S3 != nil && config.S3.Bucket != "" {
+		location := S3LocationPrefix + config.S3.Bucket
+		if prefix := strings.Trim(config.S3.Prefix, "/"); prefix != "" {
+			location += "/" + prefix
+		}
+		return location
+	}
+	if config.Registry != nil {
+		// Remove trailing slash from registry path to avoid double slashes in image reference
+		return strings.TrimRight(config.Registry.Path, "/")
+	}
+	return ""
+}
+
+// GetWorkspaceBackupLocation returns the location of the backups of a workspace, without tag, in the given backup
+// location.
+func GetWorkspaceBackupLocation(location, namespace, name string) string {
+	return strings.TrimRight(location, "/") + "/" + namespace + "/" + name
+}
+
+// GetS3Env returns the environment variables used to pass the configuration of S3-compatible object storage to the
+// workspace-recovery binary. Credentials are read from the given secret in the workspace namespace, if any. Returns
+// nil if S3-compatible object storage is not configured.
+func GetS3Env(config *controllerv1alpha1.BackupCronJobConfig, credentialsSecret *corev1.Secret) []corev1.EnvVar {
+	if config == nil || config.S3 == nil {
+		return nil
+	}
+	env := []corev1.EnvVar{
+		{Name: S3EndpointEnvVar, Value: config.S3.Endpoint},
+	}
+	if config.S3.Region != "" {
+		env = append(env, corev1.EnvVar{Name: S3RegionEnvVar, Value: config.S3.Region})
+	}
+	if credentialsSecret != nil {
+		env = append(env,
+			getSecretKeyEnv(S3AccessKeyIDEnvVar, credentialsSecret.Name, S3AccessKeyIDSecretKey),
+			getSecretKeyEnv(S3SecretAccessKeyEnvVar, credentialsSecret.Name, S3SecretAccessKeySecretKey),
+		)
+	}
+	return env
+}
+
+func getSecretKeyEnv(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
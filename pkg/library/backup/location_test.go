@@ -0,0 +1,88 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"testing"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetBackupLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *controllerv1alpha1.BackupCronJobConfig
+		expected string
+	}{
+		{
+			name:     "No configuration",
+			config:   nil,
+			expected: "",
+		},
+		{
+			name: "Registry",
+			config: &controllerv1alpha1.BackupCronJobConfig{
+				Registry: &controllerv1alpha1.RegistryConfig{Path: "registry.example.com/backups/"},
+			},
+			expected: "registry.example.com/backups",
+		},
+		{
+			name: "S3 bucket",
+			config: &controllerv1alpha1.BackupCronJobConfig{
+				S3: &controllerv1alpha1.S3Config{Endpoint: "https://s3.example.com", Bucket: "backups"},
+			},
+			expected: "s3://backups",
+		},
+		{
+			name: "S3 bucket with prefix takes precedence over registry",
+			config: &controllerv1alpha1.BackupCronJobConfig{
+				Registry: &controllerv1alpha1.RegistryConfig{Path: "registry.example.com/backups"},
+				S3:       &controllerv1alpha1.S3Config{Endpoint: "https://s3.example.com", Bucket: "backups", Prefix: "/cluster-a/"},
+			},
+			expected: "s3://backups/cluster-a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetBackupLocation(tt.config))
+		})
+	}
+}
+
+func TestGetS3Env(t *testing.T) {
+	assert.Nil(t, GetS3Env(&controllerv1alpha1.BackupCronJobConfig{
+		Registry: &controllerv1alpha1.RegistryConfig{Path: "registry.example.com/backups"},
+	}, nil))
+
+	config := &controllerv1alpha1.BackupCronJobConfig{
+		S3: &controllerv1alpha1.S3Config{Endpoint: "https://s3.example.com", Bucket: "backups", Region: "eu-west-1"},
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials"}}
+	env := GetS3Env(config, secret)
+
+	assert.Len(t, env, 4)
+	assert.Equal(t, corev1.EnvVar{Name: S3EndpointEnvVar, Value: "https://s3.example.com"}, env[0])
+	assert.Equal(t, corev1.EnvVar{Name: S3RegionEnvVar, Value: "eu-west-1"}, env[1])
+	assert.Equal(t, S3AccessKeyIDEnvVar, env[2].Name)
+	assert.Equal(t, "s3-credentials", env[2].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, S3AccessKeyIDSecretKey, env[2].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, S3SecretAccessKeyEnvVar, env[3].Name)
+	assert.Equal(t, S3SecretAccessKeySecretKey, env[3].ValueFrom.SecretKeyRef.Key)
+}
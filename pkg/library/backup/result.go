@@ -0,0 +1,55 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Result describes a successful backup. It is written as JSON to the termination message of the backup container.
+type Result struct {
+	// Image is the reference of the backup artifact, tagged with the time the backup was created
+	Image string `json:"image,omitempty"`
+	// Digest is the digest of the backup artifact's manifest
+	Digest string `json:"digest,omitempty"`
+	// Size is the total size of the backup's layers in bytes
+	Size int64 `json:"size"`
+	// UploadedSize is the size of the layers that had to be uploaded in bytes. Layers that are unchanged since a
+	// previous backup are already present in the registry and are not uploaded again.
+	UploadedSize int64 `json:"uploadedSize"`
+	// Layers is the number of layers in the backup
+	Layers int `json:"layers"`
+	// ReusedLayers is the number of layers that were already present in the registry
+	ReusedLayers int `json:"reusedLayers"`
+	// DurationSeconds is the duration of the backup in seconds
+	DurationSeconds float64 `json:"durationSeconds"`
+	// PrunedBackups is the number of previous backups removed according to the retention policy
+	PrunedBackups int `json:"prunedBackups,omitempty"`
+	// DryRun is true if PrunedBackups is the number of backups that would have been removed
+	DryRun bool `json:"dryRun,omitempty"`
+	// Backups lists the most recent backups available in the registry after the backup and pruning completed
+	Backups []AvailableBackup `json:"backups,omitempty"`
+}
+
+// ParseResult parses the termination message of a successful backup container.
+func ParseResult(message string) (*Result, error) {
+	result := &Result{}
+	if err := json.Unmarshal([]byte(message), result); err != nil {
+		return nil, fmt.Errorf("failed to parse backup result: %w", err)
+	}
+	return result, nil
+}
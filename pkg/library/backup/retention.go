@@ -0,0 +1,138 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// backupTagPrefix is the prefix of tags that identify individual backups of a workspace. Each backup is
+	// tagged with the time it was created in addition to the "latest" tag.
+	backupTagPrefix     = "backup-"
+	backupTagTimeFormat = "20060102T150405Z"
+)
+
+// Environment variables used to pass the retention policy to the workspace-recovery binary.
+const (
+	RetentionKeepLastEnvVar   = "BACKUP_RETENTION_KEEP_LAST"
+	RetentionKeepDailyEnvVar  = "BACKUP_RETENTION_KEEP_DAILY"
+	RetentionKeepWeeklyEnvVar = "BACKUP_RETENTION_KEEP_WEEKLY"
+	RetentionMaxAgeEnvVar     = "BACKUP_RETENTION_MAX_AGE"
+	RetentionDryRunEnvVar     = "BACKUP_RETENTION_DRY_RUN"
+)
+
+// RetentionPolicy defines which backups of a workspace are kept in the registry.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent backups to keep
+	KeepLast int
+	// KeepDaily is the number of days for which the most recent backup of the day is kept
+	KeepDaily int
+	// KeepWeekly is the number of weeks for which the most recent backup of the week is kept
+	KeepWeekly int
+	// MaxAge is the maximum age of a backup. Zero means backups do not expire.
+	MaxAge time.Duration
+}
+
+// IsEnabled returns whether the policy removes any backups.
+func (p RetentionPolicy) IsEnabled() bool {
+	return p.KeepLast > 0 || p.KeepDaily > 0 || p.KeepWeekly > 0 || p.MaxAge > 0
+}
+
+func (p RetentionPolicy) String() string {
+	return fmt.Sprintf("keepLast=%d, keepDaily=%d, keepWeekly=%d, maxAge=%s", p.KeepLast, p.KeepDaily, p.KeepWeekly, p.MaxAge)
+}
+
+// BackupTag returns the tag identifying a backup created at the given time.
+func BackupTag(created time.Time) string {
+	return backupTagPrefix + created.UTC().Format(backupTagTimeFormat)
+}
+
+// ParseBackupTag returns the time a backup was created from its tag. Returns false if the tag does not
+// identify an individual backup (e.g. the "latest" tag).
+func ParseBackupTag(tag string) (time.Time, bool) {
+	if !strings.HasPrefix(tag, backupTagPrefix) {
+		return time.Time{}, false
+	}
+	created, err := time.Parse(backupTagTimeFormat, strings.TrimPrefix(tag, backupTagPrefix))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return created, true
+}
+
+// SelectBackupsToPrune returns the tags of backups that are not kept by the retention policy, from oldest to newest.
+// Tags that do not identify individual backups are ignored. A backup is kept if it is selected by any of KeepLast,
+// KeepDaily and KeepWeekly (or if none of them are set) and if it is not older than MaxAge. The most recent backup
+// is always kept.
+func SelectBackupsToPrune(tags []string, policy RetentionPolicy, now time.Time) []string {
+	type backupTag struct {
+		tag     string
+		created time.Time
+	}
+	var backups []backupTag
+	for _, tag := range tags {
+		if created, ok := ParseBackupTag(tag); ok {
+			backups = append(backups, backupTag{tag, created})
+		}
+	}
+	if !policy.IsEnabled() || len(backups) == 0 {
+		return nil
+	}
+	// Newest backups first
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].created.After(backups[j].created)
+	})
+
+	hasKeepRules := policy.KeepLast > 0 || policy.KeepDaily > 0 || policy.KeepWeekly > 0
+	keptDays := map[string]bool{}
+	keptWeeks := map[string]bool{}
+	var toPrune []string
+	for idx, backup := range backups {
+		keep := !hasKeepRules
+		if idx < policy.KeepLast {
+			keep = true
+		}
+		day := backup.created.UTC().Format("2006-01-02")
+		if !keptDays[day] && len(keptDays) < policy.KeepDaily {
+			keptDays[day] = true
+			keep = true
+		}
+		year, week := backup.created.UTC().ISOWeek()
+		weekKey := fmt.Sprintf("%d-%d", year, week)
+		if !keptWeeks[weekKey] && len(keptWeeks) < policy.KeepWeekly {
+			keptWeeks[weekKey] = true
+			keep = true
+		}
+		if policy.MaxAge > 0 && now.Sub(backup.created) > policy.MaxAge {
+			keep = false
+		}
+		if idx == 0 {
+			keep = true
+		}
+		if !keep {
+			toPrune = append(toPrune, backup.tag)
+		}
+	}
+	// Oldest backups first
+	for i, j := 0, len(toPrune)-1; i < j; i, j = i+1, j-1 {
+		toPrune[i], toPrune[j] = toPrune[j], toPrune[i]
+	}
+	return toPrune
+}
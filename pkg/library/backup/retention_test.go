@@ -0,0 +1,97 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func tagsAt(times ...time.Time) []string {
+	var tags []string
+	for _, t := range times {
+		tags = append(tags, BackupTag(t))
+	}
+	return tags
+}
+
+func TestParseBackupTag(t *testing.T) {
+	created, ok := ParseBackupTag(BackupTag(now))
+	assert.True(t, ok)
+	assert.Equal(t, now, created)
+
+	_, ok = ParseBackupTag("latest")
+	assert.False(t, ok, "latest tag should not be parsed as a backup tag")
+	_, ok = ParseBackupTag("backup-invalid")
+	assert.False(t, ok, "tags with invalid timestamps should not be parsed as backup tags")
+}
+
+func TestSelectBackupsToPruneDisabledPolicy(t *testing.T) {
+	tags := tagsAt(now.Add(-72*time.Hour), now.Add(-48*time.Hour), now)
+	assert.Empty(t, SelectBackupsToPrune(tags, RetentionPolicy{}, now))
+}
+
+func TestSelectBackupsToPruneKeepLast(t *testing.T) {
+	tags := tagsAt(now.Add(-3*time.Hour), now, now.Add(-1*time.Hour), now.Add(-2*time.Hour))
+	tags = append(tags, "latest")
+	pruned := SelectBackupsToPrune(tags, RetentionPolicy{KeepLast: 2}, now)
+	assert.Equal(t, tagsAt(now.Add(-3*time.Hour), now.Add(-2*time.Hour)), pruned)
+}
+
+func TestSelectBackupsToPruneKeepDaily(t *testing.T) {
+	tags := tagsAt(
+		now.Add(-49*time.Hour),
+		now.Add(-48*time.Hour),
+		now.Add(-25*time.Hour),
+		now.Add(-24*time.Hour),
+		now.Add(-1*time.Hour),
+		now,
+	)
+	pruned := SelectBackupsToPrune(tags, RetentionPolicy{KeepDaily: 2}, now)
+	assert.Equal(t, tagsAt(now.Add(-49*time.Hour), now.Add(-48*time.Hour), now.Add(-25*time.Hour), now.Add(-1*time.Hour)), pruned)
+}
+
+func TestSelectBackupsToPruneKeepWeekly(t *testing.T) {
+	week := 7 * 24 * time.Hour
+	tags := tagsAt(now.Add(-2*week), now.Add(-week-time.Hour), now.Add(-week), now)
+	pruned := SelectBackupsToPrune(tags, RetentionPolicy{KeepWeekly: 2}, now)
+	assert.Equal(t, tagsAt(now.Add(-2*week), now.Add(-week-time.Hour)), pruned)
+}
+
+func TestSelectBackupsToPruneCombinesKeepRules(t *testing.T) {
+	tags := tagsAt(now.Add(-48*time.Hour), now.Add(-24*time.Hour), now.Add(-1*time.Hour), now)
+	pruned := SelectBackupsToPrune(tags, RetentionPolicy{KeepLast: 1, KeepDaily: 2}, now)
+	assert.Equal(t, tagsAt(now.Add(-48*time.Hour), now.Add(-1*time.Hour)), pruned)
+}
+
+func TestSelectBackupsToPruneMaxAge(t *testing.T) {
+	tags := tagsAt(now.Add(-72*time.Hour), now.Add(-48*time.Hour), now.Add(-1*time.Hour))
+	pruned := SelectBackupsToPrune(tags, RetentionPolicy{MaxAge: 24 * time.Hour}, now)
+	assert.Equal(t, tagsAt(now.Add(-72*time.Hour), now.Add(-48*time.Hour)), pruned)
+
+	pruned = SelectBackupsToPrune(tags, RetentionPolicy{KeepLast: 3, MaxAge: 24 * time.Hour}, now)
+	assert.Equal(t, tagsAt(now.Add(-72*time.Hour), now.Add(-48*time.Hour)), pruned, "MaxAge should take precedence over keep rules")
+}
+
+func TestSelectBackupsToPruneAlwaysKeepsMostRecentBackup(t *testing.T) {
+	tags := tagsAt(now.Add(-72*time.Hour), now.Add(-48*time.Hour))
+	pruned := SelectBackupsToPrune(tags, RetentionPolicy{MaxAge: 24 * time.Hour}, now)
+	assert.Equal(t, tagsAt(now.Add(-72*time.Hour)), pruned)
+}
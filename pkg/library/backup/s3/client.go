@@ -0,0 +1,345 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package s3 implements a minimal client for S3-compatible object storage, used to store workspace backups.
+package s3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRegion is the region used to sign requests if no region is configured
+	DefaultRegion = "us-east-1"
+
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	amzDateFormat    = "20060102T150405Z"
+)
+
+// Config contains the configuration required to access a bucket in S3-compatible object storage.
+type Config struct {
+	// Endpoint is the URL of the S3-compatible service, e.g. https://s3.us-east-1.amazonaws.com
+	Endpoint string
+	// Region is the region used to sign requests. Defaults to DefaultRegion.
+	Region string
+	// Bucket is the name of the bucket
+	Bucket string
+	// AccessKeyID and SecretAccessKey are the credentials used to sign requests. If they are empty, requests
+	// are sent without authentication.
+	AccessKeyID     string
+	SecretAccessKey string
+	// HTTPClient is the client used to send requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Client accesses objects in a single bucket. Buckets are addressed using path-style URLs
+// (<endpoint>/<bucket>/<key>), which are supported by all S3-compatible services.
+type Client struct {
+	config   Config
+	endpoint *url.URL
+	now      func() time.Time
+}
+
+// ErrorResponse is returned when the S3 service responds to a request with an error.
+type ErrorResponse struct {
+	StatusCode int
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *ErrorResponse) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("S3 request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("S3 request failed with status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// IsNotFound returns whether err indicates that an object or bucket does not exist.
+func IsNotFound(err error) bool {
+	var errResp *ErrorResponse
+	return errors.As(err, &errResp) && errResp.StatusCode == http.StatusNotFound
+}
+
+// NewClient returns a client for the bucket in config.
+func NewClient(config Config) (*Client, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: expected an http or https URL", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is not specified")
+	}
+	if config.Region == "" {
+		config.Region = DefaultRegion
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	return &Client{config: config, endpoint: endpoint, now: time.Now}, nil
+}
+
+// PutObject uploads an object of the given size.
+func (c *Client) PutObject(ctx context.Context, key string, body io.Reader, size int64) error {
+	req, err := c.newRequest(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		// Requests with an unknown length are sent with chunked encoding, which is not supported by S3
+		req.Body = http.NoBody
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// GetObject downloads an object. The caller must close the returned reader.
+func (c *Client) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// HeadObject returns the size of an object.
+func (c *Client) HeadObject(ctx context.Context, key string) (int64, error) {
+	req, err := c.newRequest(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.ContentLength, nil
+}
+
+// DeleteObject removes an object. Removing an object that does not exist is not an error.
+func (c *Client) DeleteObject(ctx context.Context, key string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListObjects calls fn with the keys of objects that start with prefix, one page at a time.
+func (c *Client) ListObjects(ctx context.Context, prefix string, fn func(keys []string) error) error {
+	continuationToken := ""
+	for {
+		query := url.Values{
+			"list-type": []string{"2"},
+			"prefix":    []string{prefix},
+		}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+		req, err := c.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := c.do(req)
+		if err != nil {
+			return err
+		}
+		result := &listBucketResult{}
+		err = xml.NewDecoder(resp.Body).Decode(result)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to parse S3 list response: %w", err)
+		}
+
+		keys := make([]string, 0, len(result.Contents))
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+		if err := fn(keys); err != nil {
+			return err
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// newRequest returns a request for an object in the bucket, or for the bucket itself if key is empty.
+func (c *Client) newRequest(ctx context.Context, method, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	path := strings.TrimRight(c.endpoint.Path, "/") + "/" + c.config.Bucket
+	if key != "" {
+		path += "/" + key
+	}
+	reqURL := *c.endpoint
+	reqURL.Path = path
+	reqURL.RawPath = uriEncode(path, false)
+	reqURL.RawQuery = canonicalQuery(query)
+	return http.NewRequestWithContext(ctx, method, reqURL.String(), body)
+}
+
+// do signs and sends a request. Responses with an error status are returned as an ErrorResponse.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	c.sign(req)
+	resp, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	errResp := &ErrorResponse{}
+	if req.Method != http.MethodHead {
+		// The error details are optional; the status code is sufficient to handle the error
+		_ = xml.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(errResp)
+	}
+	errResp.StatusCode = resp.StatusCode
+	return nil, errResp
+}
+
+// sign adds an AWS Signature Version 4 authorization header to a request. The payload is not included in the
+// signature, so that objects can be uploaded without reading them twice.
+func (c *Client) sign(req *http.Request) {
+	if c.config.AccessKeyID == "" || c.config.SecretAccessKey == "" {
+		return
+	}
+	now := c.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	date := amzDate[:8]
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", unsignedPayload)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": unsignedPayload,
+		"x-amz-date":           amzDate,
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+	scope := strings.Join([]string{date, c.config.Region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, sha256Hex(canonicalRequest)}, "\n")
+
+	signature := hex.EncodeToString(hmacSHA256(deriveSigningKey(c.config.SecretAccessKey, date, c.config.Region, "s3"), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, c.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// deriveSigningKey derives the key used to sign requests to a service on the given date from a secret access key.
+func deriveSigningKey(secretAccessKey, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	for _, part := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	return key
+}
+
+// canonicalQuery encodes query parameters sorted by name, as required for signing requests.
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var params []string
+	for _, name := range names {
+		for _, value := range query[name] {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// uriEncode encodes a string as specified for AWS signatures: all characters except unreserved characters are
+// percent-encoded. Slashes are encoded only if encodeSlash is true.
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func sha256Hex(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"slices"
+	"strings"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	devfileConstants "github.com/devfile/devworkspace-operator/pkg/library/constants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// VolumesEnvVar is the environment variable that contains a comma-separated list of names of devfile volumes that
+	// are backed up or restored in addition to the projects
+	VolumesEnvVar = "BACKUP_VOLUMES"
+	// VolumesPathEnvVar is the environment variable that contains the path of the directory that contains the data of
+	// each volume in VolumesEnvVar in a subdirectory named after the volume
+	VolumesPathEnvVar = "BACKUP_VOLUMES_PATH"
+)
+
+// GetIncludedVolumes returns the names of the devfile volumes whose data is backed up in addition to the projects,
+// according to the backup configuration. The persistent home directory is included as the HomeVolumeName volume.
+func GetIncludedVolumes(config *controllerv1alpha1.BackupCronJobConfig) []string {
+	if config == nil || config.Include == nil {
+		return nil
+	}
+	var volumes []string
+	if config.Include.PersistentHome != nil && *config.Include.PersistentHome {
+		volumes = append(volumes, constants.HomeVolumeName)
+	}
+	for _, volume := range config.Include.Volumes {
+		// The projects volume is always backed up
+		if volume == devfileConstants.ProjectsVolumeName || slices.Contains(volumes, volume) {
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
+
+// GetVolumesEnv returns the environment variables that configure the workspace-recovery binary to back up or restore
+// the data of volumes, located in subdirectories of volumesPath.
+func GetVolumesEnv(volumes []string, volumesPath string) []corev1.EnvVar {
+	if len(volumes) == 0 {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{Name: VolumesEnvVar, Value: strings.Join(volumes, ",")},
+		{Name: VolumesPathEnvVar, Value: volumesPath},
+	}
+}
@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"testing"
+
+	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestGetIncludedVolumes(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *controllerv1alpha1.BackupCronJobConfig
+		expected []string
+	}{
+		{
+			name:     "No configuration",
+			config:   &controllerv1alpha1.BackupCronJobConfig{},
+			expected: nil,
+		},
+		{
+			name: "Persistent home",
+			config: &controllerv1alpha1.BackupCronJobConfig{
+				Include: &controllerv1alpha1.BackupIncludeConfig{PersistentHome: pointer.Bool(true)},
+			},
+			expected: []string{"persistent-home"},
+		},
+		{
+			name: "Persistent home disabled",
+			config: &controllerv1alpha1.BackupCronJobConfig{
+				Include: &controllerv1alpha1.BackupIncludeConfig{PersistentHome: pointer.Bool(false), Volumes: []string{"m2"}},
+			},
+			expected: []string{"m2"},
+		},
+		{
+			name: "Volumes without duplicates and projects volume",
+			config: &controllerv1alpha1.BackupCronJobConfig{
+				Include: &controllerv1alpha1.BackupIncludeConfig{
+					PersistentHome: pointer.Bool(true),
+					Volumes:        []string{"m2", "projects", "persistent-home", "cache", "m2"},
+				},
+			},
+			expected: []string{"persistent-home", "m2", "cache"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetIncludedVolumes(tt.config))
+		})
+	}
+}
+
+func TestGetVolumesEnv(t *testing.T) {
+	assert.Nil(t, GetVolumesEnv(nil, "/workspace"))
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: VolumesEnvVar, Value: "persistent-home,m2"},
+		{Name: VolumesPathEnvVar, Value: "/workspace/id"},
+	}, GetVolumesEnv([]string{"persistent-home", "m2"}, "/workspace/id"))
+}
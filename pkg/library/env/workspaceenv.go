@@ -18,6 +18,8 @@ package env
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
@@ -72,6 +74,44 @@ func GetEnvironmentVariablesForProjectClone(workspace *common.DevWorkspaceWithCo
 	var cloneEnv []corev1.EnvVar
 	cloneEnv = append(cloneEnv, workspace.Config.Workspace.ProjectCloneConfig.Env...)
 	cloneEnv = append(cloneEnv, commonEnvironmentVariables(workspace)...)
+	if parallelism := workspace.Config.Workspace.ProjectCloneConfig.Parallelism; parallelism != nil {
+		cloneEnv = append(cloneEnv, corev1.EnvVar{
+			Name:  constants.ProjectCloneParallelism,
+			Value: strconv.Itoa(int(*parallelism)),
+		})
+	}
+	if sshConfig := workspace.Config.Workspace.ProjectCloneConfig.SSH; sshConfig != nil {
+		if sshConfig.HostKeyPolicy != "" {
+			cloneEnv = append(cloneEnv, corev1.EnvVar{
+				Name:  constants.ProjectCloneSSHHostKeyPolicy,
+				Value: string(sshConfig.HostKeyPolicy),
+			})
+		}
+		if len(sshConfig.KnownHosts) > 0 {
+			cloneEnv = append(cloneEnv, corev1.EnvVar{
+				Name:  constants.ProjectCloneSSHKnownHosts,
+				Value: strings.Join(sshConfig.KnownHosts, "\n"),
+			})
+		}
+	}
+	if depth := workspace.Config.Workspace.ProjectCloneConfig.Depth; depth != nil {
+		cloneEnv = append(cloneEnv, corev1.EnvVar{
+			Name:  constants.ProjectCloneDepth,
+			Value: strconv.Itoa(int(*depth)),
+		})
+	}
+	if filter := workspace.Config.Workspace.ProjectCloneConfig.Filter; filter != "" {
+		cloneEnv = append(cloneEnv, corev1.EnvVar{
+			Name:  constants.ProjectCloneFilter,
+			Value: filter,
+		})
+	}
+	if singleBranch := workspace.Config.Workspace.ProjectCloneConfig.SingleBranch; singleBranch != nil {
+		cloneEnv = append(cloneEnv, corev1.EnvVar{
+			Name:  constants.ProjectCloneSingleBranch,
+			Value: strconv.FormatBool(*singleBranch),
+		})
+	}
 	cloneEnv = append(cloneEnv, corev1.EnvVar{
 		Name:  devfileConstants.ProjectsRootEnvVar,
 		Value: constants.DefaultProjectsSourcesRoot,
@@ -23,6 +23,11 @@ import (
 	"sigs.k8s.io/yaml"
 
 	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"k8s.io/utils/pointer"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
 )
 
 func TestResolveDevWorkspaceWorkspaceEnv(t *testing.T) {
@@ -45,6 +50,72 @@ func TestResolveDevWorkspaceWorkspaceEnv(t *testing.T) {
 	}
 }
 
+func TestProjectCloneParallelismEnv(t *testing.T) {
+	getWorkspace := func(parallelism *int32) *common.DevWorkspaceWithConfig {
+		return &common.DevWorkspaceWithConfig{
+			DevWorkspace: &dw.DevWorkspace{},
+			Config: &v1alpha1.OperatorConfiguration{
+				Routing: &v1alpha1.RoutingConfig{},
+				Workspace: &v1alpha1.WorkspaceConfig{
+					ProjectCloneConfig: &v1alpha1.ProjectCloneConfig{
+						Parallelism: parallelism,
+					},
+				},
+			},
+		}
+	}
+
+	envvars := GetEnvironmentVariablesForProjectClone(getWorkspace(pointer.Int32(4)))
+	assert.Contains(t, envvars, corev1.EnvVar{Name: constants.ProjectCloneParallelism, Value: "4"})
+
+	envvars = GetEnvironmentVariablesForProjectClone(getWorkspace(nil))
+	for _, envvar := range envvars {
+		assert.NotEqual(t, constants.ProjectCloneParallelism, envvar.Name, "Should not set parallelism when undefined")
+	}
+}
+
+func TestProjectCloneSSHEnv(t *testing.T) {
+	workspace := &common.DevWorkspaceWithConfig{
+		DevWorkspace: &dw.DevWorkspace{},
+		Config: &v1alpha1.OperatorConfiguration{
+			Routing: &v1alpha1.RoutingConfig{},
+			Workspace: &v1alpha1.WorkspaceConfig{
+				ProjectCloneConfig: &v1alpha1.ProjectCloneConfig{
+					SSH: &v1alpha1.ProjectCloneSSHConfig{
+						HostKeyPolicy: v1alpha1.ProjectCloneSSHHostKeyStrict,
+						KnownHosts:    []string{"host-a ssh-ed25519 AAAA", "host-b ssh-rsa BBBB"},
+					},
+				},
+			},
+		},
+	}
+
+	envvars := GetEnvironmentVariablesForProjectClone(workspace)
+	assert.Contains(t, envvars, corev1.EnvVar{Name: constants.ProjectCloneSSHHostKeyPolicy, Value: "Strict"})
+	assert.Contains(t, envvars, corev1.EnvVar{Name: constants.ProjectCloneSSHKnownHosts, Value: "host-a ssh-ed25519 AAAA\nhost-b ssh-rsa BBBB"})
+}
+
+func TestProjectCloneDefaultCloneOptionsEnv(t *testing.T) {
+	workspace := &common.DevWorkspaceWithConfig{
+		DevWorkspace: &dw.DevWorkspace{},
+		Config: &v1alpha1.OperatorConfiguration{
+			Routing: &v1alpha1.RoutingConfig{},
+			Workspace: &v1alpha1.WorkspaceConfig{
+				ProjectCloneConfig: &v1alpha1.ProjectCloneConfig{
+					Depth:        pointer.Int32(1),
+					Filter:       "blob:none",
+					SingleBranch: pointer.Bool(true),
+				},
+			},
+		},
+	}
+
+	envvars := GetEnvironmentVariablesForProjectClone(workspace)
+	assert.Contains(t, envvars, corev1.EnvVar{Name: constants.ProjectCloneDepth, Value: "1"})
+	assert.Contains(t, envvars, corev1.EnvVar{Name: constants.ProjectCloneFilter, Value: "blob:none"})
+	assert.Contains(t, envvars, corev1.EnvVar{Name: constants.ProjectCloneSingleBranch, Value: "true"})
+}
+
 type TestCase struct {
 	Name   string     `json:"name"`
 	Input  TestInput  `json:"input"`
@@ -25,6 +25,7 @@ import (
 	devfileConstants "github.com/devfile/devworkspace-operator/pkg/library/constants"
 	dwResources "github.com/devfile/devworkspace-operator/pkg/library/resources"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 
 	"github.com/devfile/devworkspace-operator/internal/images"
 	"github.com/devfile/devworkspace-operator/pkg/constants"
@@ -32,6 +33,14 @@ import (
 
 const (
 	ProjectClonerContainerName = "project-clone"
+	// ProjectCloneSSHKeyVolumeName is the name of the volume used to mount the SSH secret to the project clone container
+	ProjectCloneSSHKeyVolumeName = "project-clone-ssh-key"
+	// ProjectCloneSSHKeyMountPath is the path where the SSH secret is mounted in the project clone container
+	ProjectCloneSSHKeyMountPath = "/etc/project-clone/ssh"
+	// ProjectCloneGitCacheVolumeName is the name of the volume used to mount the git mirror cache to the project clone container
+	ProjectCloneGitCacheVolumeName = "project-clone-git-cache"
+	// ProjectCloneGitCacheMountPath is the path where the git mirror cache is mounted in the project clone container
+	ProjectCloneGitCacheMountPath = "/git-cache"
 )
 
 type Options struct {
@@ -39,6 +48,13 @@ type Options struct {
 	PullPolicy corev1.PullPolicy
 	Resources  *corev1.ResourceRequirements
 	Env        []corev1.EnvVar
+	// MountSSHKey defines whether the SSH secret is mounted to the project clone container. If true, the volume
+	// returned by GetProjectCloneSSHKeyVolume must be added to the workspace pod.
+	MountSSHKey bool
+	// GitCacheClaimName is the name of the PVC containing the git mirror cache. If set, the PVC is mounted read-only
+	// to the project clone container, and the volume returned by GetProjectCloneGitCacheVolume must be added to the
+	// workspace pod.
+	GitCacheClaimName string
 }
 
 // ValidateAllProjectsvalidates that no two projects, dependentProjects or starterProjects (if one is selected) share
@@ -117,21 +133,70 @@ func GetProjectCloneInitContainer(workspace *dw.DevWorkspaceTemplateSpec, option
 		return nil, fmt.Errorf("invalid resources for project clone container: %w", err)
 	}
 
-	return &corev1.Container{
-		Name:      ProjectClonerContainerName,
-		Image:     cloneImage,
-		Env:       options.Env,
-		Resources: *resources,
-		VolumeMounts: []corev1.VolumeMount{
-			{
-				Name:      devfileConstants.ProjectsVolumeName,
-				MountPath: constants.DefaultProjectsSourcesRoot,
-			},
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      devfileConstants.ProjectsVolumeName,
+			MountPath: constants.DefaultProjectsSourcesRoot,
 		},
+	}
+	if options.MountSSHKey {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      ProjectCloneSSHKeyVolumeName,
+			MountPath: ProjectCloneSSHKeyMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	env := options.Env
+	if options.GitCacheClaimName != "" {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      ProjectCloneGitCacheVolumeName,
+			MountPath: ProjectCloneGitCacheMountPath,
+			ReadOnly:  true,
+		})
+		env = append(env, corev1.EnvVar{
+			Name:  constants.ProjectCloneGitCachePath,
+			Value: ProjectCloneGitCacheMountPath,
+		})
+	}
+
+	return &corev1.Container{
+		Name:            ProjectClonerContainerName,
+		Image:           cloneImage,
+		Env:             env,
+		Resources:       *resources,
+		VolumeMounts:    volumeMounts,
 		ImagePullPolicy: options.PullPolicy,
 	}, nil
 }
 
+// GetProjectCloneSSHKeyVolume returns the volume for the SSH secret used by the project clone container
+func GetProjectCloneSSHKeyVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: ProjectCloneSSHKeyVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  constants.SSHSecretName,
+				DefaultMode: pointer.Int32(0640),
+				Optional:    pointer.Bool(true),
+			},
+		},
+	}
+}
+
+// GetProjectCloneGitCacheVolume returns the volume for the git mirror cache PVC used by the project clone container
+func GetProjectCloneGitCacheVolume(claimName string) corev1.Volume {
+	return corev1.Volume{
+		Name: ProjectCloneGitCacheVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
 func GetStarterProject(workspace *dw.DevWorkspaceTemplateSpec) (*dw.StarterProject, error) {
 	if !workspace.Attributes.Exists(constants.StarterProjectAttribute) {
 		return nil, nil
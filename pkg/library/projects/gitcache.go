@@ -0,0 +1,59 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package projects
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// scpLikeURLRegex matches scp-like git remote URLs, e.g. 'git@github.com:devfile/api.git'
+	scpLikeURLRegex = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+	mirrorHostRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9.-]*[a-z0-9])?$`)
+	mirrorPathRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+)
+
+// GetGitMirrorName returns the path, relative to the root of the git mirror cache, of the mirror for a git remote.
+// Remote URLs that refer to the same repository over different protocols (e.g. HTTPS and SSH) share the same mirror,
+// e.g. both 'https://github.com/devfile/api.git' and 'git@github.com:devfile/api' result in 'github.com/devfile/api.git'.
+// An error is returned if the remote URL cannot be mapped to a mirror path.
+func GetGitMirrorName(remoteURL string) (string, error) {
+	var host, repoPath string
+	if parsed, err := url.Parse(remoteURL); err == nil && parsed.Scheme != "" {
+		host, repoPath = parsed.Hostname(), parsed.Path
+	} else if match := scpLikeURLRegex.FindStringSubmatch(remoteURL); match != nil {
+		host, repoPath = match[1], match[2]
+	}
+
+	host = strings.ToLower(host)
+	if !mirrorHostRegex.MatchString(host) {
+		return "", fmt.Errorf("unsupported git remote URL %s", remoteURL)
+	}
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if repoPath == "" {
+		return "", fmt.Errorf("unsupported git remote URL %s", remoteURL)
+	}
+	for _, segment := range strings.Split(repoPath, "/") {
+		if !mirrorPathRegex.MatchString(segment) {
+			return "", fmt.Errorf("unsupported git remote URL %s", remoteURL)
+		}
+	}
+	return path.Join(host, repoPath) + ".git", nil
+}
@@ -0,0 +1,118 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ProjectCloneState is the outcome of setting up a project in the project clone container
+type ProjectCloneState string
+
+const (
+	// ProjectCloneSucceeded means the project was set up successfully or was already present
+	ProjectCloneSucceeded ProjectCloneState = "Succeeded"
+	// ProjectCloneFailed means an error was encountered while setting up the project
+	ProjectCloneFailed ProjectCloneState = "Failed"
+	// ProjectCloneSkipped means the project was not set up because an earlier project could not be processed
+	ProjectCloneSkipped ProjectCloneState = "Skipped"
+)
+
+// GitCacheResult reports whether the git mirror cache was used when cloning a project
+type GitCacheResult string
+
+const (
+	// GitCacheHit means the project was cloned using a mirror from the git mirror cache as a reference
+	GitCacheHit GitCacheResult = "Hit"
+	// GitCacheMiss means the git mirror cache did not contain a mirror for the project's remote
+	GitCacheMiss GitCacheResult = "Miss"
+)
+
+// maxTerminationMessageLength is the maximum length of a container's termination message in Kubernetes
+const maxTerminationMessageLength = 4096
+
+// ProjectCloneResults is written by the project clone container to its termination message to report
+// the outcome of setting up each project in a DevWorkspace.
+type ProjectCloneResults struct {
+	Projects []ProjectCloneResult `json:"projects"`
+}
+
+// ProjectCloneResult is the outcome of setting up a single project
+type ProjectCloneResult struct {
+	// Name is the name of the project
+	Name string `json:"name"`
+	// State is the outcome of setting up the project
+	State ProjectCloneState `json:"state"`
+	// Error is the error encountered while setting up the project, if any
+	Error string `json:"error,omitempty"`
+	// Commit is the commit checked out for git projects
+	Commit string `json:"commit,omitempty"`
+	// Duration is the time spent setting up the project
+	Duration string `json:"duration,omitempty"`
+	// GitCache reports whether a mirror from the git mirror cache was used when cloning the project. Empty if the
+	// git mirror cache is not available or the project was not cloned.
+	GitCache GitCacheResult `json:"gitCache,omitempty"`
+	// Warnings are problems encountered while setting up the project that did not prevent it from being set up,
+	// e.g. failing to fetch Git LFS objects
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// EncodeProjectCloneResults serializes results for use as a container termination message. If the serialized
+// results exceed the maximum length of a termination message, error and warning messages are truncated until they fit.
+func EncodeProjectCloneResults(results *ProjectCloneResults) ([]byte, error) {
+	encoded, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	for maxErrorLength := 512; len(encoded) > maxTerminationMessageLength && maxErrorLength > 0; maxErrorLength /= 2 {
+		truncated := &ProjectCloneResults{}
+		for _, result := range results.Projects {
+			result.Error = truncateMessage(result.Error, maxErrorLength)
+			if len(result.Warnings) > 0 {
+				warnings := make([]string, len(result.Warnings))
+				for idx, warning := range result.Warnings {
+					warnings[idx] = truncateMessage(warning, maxErrorLength)
+				}
+				result.Warnings = warnings
+			}
+			truncated.Projects = append(truncated.Projects, result)
+		}
+		if encoded, err = json.Marshal(truncated); err != nil {
+			return nil, err
+		}
+	}
+	if len(encoded) > maxTerminationMessageLength {
+		return nil, fmt.Errorf("project clone results exceed maximum termination message length (%d bytes)", maxTerminationMessageLength)
+	}
+	return encoded, nil
+}
+
+func truncateMessage(message string, maxLength int) string {
+	if len(message) > maxLength {
+		return message[:maxLength] + "..."
+	}
+	return message
+}
+
+// ParseProjectCloneResults parses results written by the project clone container to its termination message
+func ParseProjectCloneResults(message string) (*ProjectCloneResults, error) {
+	results := &ProjectCloneResults{}
+	if err := json.Unmarshal([]byte(message), results); err != nil {
+		return nil, fmt.Errorf("failed to parse project clone results: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,85 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package projects
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeProjectCloneResults(t *testing.T) {
+	results := &ProjectCloneResults{
+		Projects: []ProjectCloneResult{
+			{Name: "project-a", State: ProjectCloneSucceeded, Commit: "abc123", Duration: "1.5s"},
+			{Name: "project-b", State: ProjectCloneFailed, Error: "authentication required"},
+		},
+	}
+	encoded, err := EncodeProjectCloneResults(results)
+	if !assert.NoError(t, err) {
+		return
+	}
+	parsed, err := ParseProjectCloneResults(string(encoded))
+	if assert.NoError(t, err) {
+		assert.Equal(t, results, parsed, "Results should be unchanged by encoding and parsing")
+	}
+}
+
+func TestEncodeProjectCloneResultsTruncatesErrors(t *testing.T) {
+	results := &ProjectCloneResults{}
+	for i := 0; i < 10; i++ {
+		results.Projects = append(results.Projects, ProjectCloneResult{
+			Name:  fmt.Sprintf("project-%d", i),
+			State: ProjectCloneFailed,
+			Error: strings.Repeat("e", 1000),
+		})
+	}
+	encoded, err := EncodeProjectCloneResults(results)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.LessOrEqual(t, len(encoded), maxTerminationMessageLength)
+	parsed, err := ParseProjectCloneResults(string(encoded))
+	if assert.NoError(t, err) {
+		assert.Len(t, parsed.Projects, 10, "All projects should be reported")
+		assert.True(t, strings.HasSuffix(parsed.Projects[0].Error, "..."), "Error should be truncated")
+		assert.Equal(t, strings.Repeat("e", 1000), results.Projects[0].Error, "Original results should not be modified")
+	}
+}
+
+func TestEncodeProjectCloneResultsTruncatesWarnings(t *testing.T) {
+	results := &ProjectCloneResults{}
+	for i := 0; i < 10; i++ {
+		results.Projects = append(results.Projects, ProjectCloneResult{
+			Name:     fmt.Sprintf("project-%d", i),
+			State:    ProjectCloneSucceeded,
+			Warnings: []string{strings.Repeat("w", 1000)},
+		})
+	}
+	encoded, err := EncodeProjectCloneResults(results)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.LessOrEqual(t, len(encoded), maxTerminationMessageLength)
+	parsed, err := ParseProjectCloneResults(string(encoded))
+	if assert.NoError(t, err) {
+		assert.Len(t, parsed.Projects, 10, "All projects should be reported")
+		assert.True(t, strings.HasSuffix(parsed.Projects[0].Warnings[0], "..."), "Warning should be truncated")
+		assert.Equal(t, strings.Repeat("w", 1000), results.Projects[0].Warnings[0], "Original results should not be modified")
+	}
+}
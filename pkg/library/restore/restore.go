@@ -19,16 +19,20 @@ package restore
 import (
 	"context"
 	"fmt"
+	"path"
 	"strings"
+	"time"
 
 	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
 	devfileConstants "github.com/devfile/devworkspace-operator/pkg/library/constants"
 	dwResources "github.com/devfile/devworkspace-operator/pkg/library/resources"
 	"github.com/devfile/devworkspace-operator/pkg/secrets"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/devfile/devworkspace-operator/internal/images"
@@ -37,6 +41,9 @@ import (
 
 const (
 	WorkspaceRestoreContainerName = "workspace-restore"
+	// restoreVolumesPath is the directory where the devfile volumes that are restored in addition to the projects are
+	// mounted in the restore container
+	restoreVolumesPath = "/workspace-volumes"
 )
 
 type Options struct {
@@ -55,7 +62,8 @@ func IsWorkspaceRestoreRequested(workspace *dw.DevWorkspaceTemplateSpec) bool {
 }
 
 // GetWorkspaceRestoreInitContainer creates an init container that restores workspace data from a backup image.
-// The restore container uses the existing workspace-recovery.sh script to extract backup content.
+// The restore container runs the workspace-recovery binary, which downloads and extracts all layers of the backup
+// to reassemble the workspace data at the time of the backup.
 func GetWorkspaceRestoreInitContainer(
 	ctx context.Context,
 	workspace *common.DevWorkspaceWithConfig,
@@ -79,13 +87,13 @@ func GetWorkspaceRestoreInitContainer(
 		if workspace.Config.Workspace.BackupCronJob == nil {
 			return nil, nil, fmt.Errorf("workspace restore requested but backup cron job configuration is missing")
 		}
-		if workspace.Config.Workspace.BackupCronJob.Registry == nil || workspace.Config.Workspace.BackupCronJob.Registry.Path == "" {
-			return nil, nil, fmt.Errorf("workspace restore requested but backup cron job registry is not configured")
+		if backup.GetBackupLocation(workspace.Config.Workspace.BackupCronJob) == "" {
+			return nil, nil, fmt.Errorf("workspace restore requested but backup cron job registry or S3 storage is not configured")
+		}
+		restoreSourceImage, err = getDefaultRestoreSourceImage(workspace)
+		if err != nil {
+			return nil, nil, err
 		}
-		// Use default backup image location based on workspace info
-		// Remove trailing slash from registry path to avoid double slashes in image reference
-		registryPath := strings.TrimRight(workspace.Config.Workspace.BackupCronJob.Registry.Path, "/")
-		restoreSourceImage = registryPath + "/" + workspace.Namespace + "/" + workspace.Name + ":latest"
 	}
 	if restoreSourceImage == "" {
 		return nil, nil, fmt.Errorf("empty value for attribute %s is invalid", constants.WorkspaceRestoreSourceImageAttribute)
@@ -96,7 +104,7 @@ func GetWorkspaceRestoreInitContainer(
 		return nil, nil, nil
 	}
 
-	// Use the project backup image which contains the workspace-recovery.sh script
+	// Use the project backup image which contains the workspace-recovery binary
 	restoreImage := images.GetProjectBackupImage()
 
 	// Prepare environment variables for the restore script
@@ -114,6 +122,19 @@ func GetWorkspaceRestoreInitContainer(
 			MountPath: constants.DefaultProjectsSourcesRoot,
 		},
 	}
+	// Devfile volumes included in backups are restored into the same volumes of the restored DevWorkspace, if it defines them
+	var restoredVolumes []string
+	for _, volume := range backup.GetIncludedVolumes(workspace.Config.Workspace.BackupCronJob) {
+		if !hasPersistentVolume(workspaceTemplate, volume) {
+			continue
+		}
+		restoredVolumes = append(restoredVolumes, volume)
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volume,
+			MountPath: path.Join(restoreVolumesPath, volume),
+		})
+	}
+	env = append(env, backup.GetVolumesEnv(restoredVolumes, restoreVolumesPath)...)
 	registryAuthSecret, err := secrets.GetNamespaceRegistryAuthSecret(ctx, k8sClient, workspace.DevWorkspace, workspace.Config, scheme, log)
 	if err != nil {
 		return nil, nil, fmt.Errorf("handling registry auth secret for workspace restore: %w", err)
@@ -129,11 +150,21 @@ func GetWorkspaceRestoreInitContainer(
 			Value: "/tmp/.docker/.dockerconfigjson",
 		})
 	}
+	s3CredentialsSecret, err := secrets.GetNamespaceS3CredentialsSecret(ctx, k8sClient, workspace.DevWorkspace, workspace.Config, log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("handling S3 credentials secret for workspace restore: %w", err)
+	}
+	env = append(env, backup.GetS3Env(workspace.Config.Workspace.BackupCronJob, s3CredentialsSecret)...)
+	encryptionKeySecret, err := secrets.GetNamespaceEncryptionKeySecret(ctx, k8sClient, workspace.DevWorkspace, workspace.Config, log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("handling encryption key secret for workspace restore: %w", err)
+	}
+	env = append(env, backup.GetRestoreEncryptionEnv(encryptionKeySecret)...)
 
 	restoreContainer := &corev1.Container{
 		Name:            WorkspaceRestoreContainerName,
 		Image:           restoreImage,
-		Command:         []string{"/workspace-recovery.sh"},
+		Command:         []string{backup.WorkspaceRecoveryCommand},
 		Args:            []string{"--restore"},
 		Env:             env,
 		Resources:       *resources,
@@ -143,6 +174,70 @@ func GetWorkspaceRestoreInitContainer(
 	return restoreContainer, registryAuthSecret, nil
 }
 
+// getDefaultRestoreSourceImage returns the reference of the backup to restore from the backup location configured in the
+// cluster configuration. By default, the most recent backup of the workspace itself is restored; the source workspace and
+// backup can be selected with the WorkspaceRestoreSourceWorkspaceAttribute and WorkspaceRestoreBackupAttribute attributes.
+func getDefaultRestoreSourceImage(workspace *common.DevWorkspaceWithConfig) (string, error) {
+	attributes := workspace.Spec.Template.Attributes
+	var err error
+
+	sourceName := workspace.Name
+	if attributes.Exists(constants.WorkspaceRestoreSourceWorkspaceAttribute) {
+		sourceWorkspace := attributes.GetString(constants.WorkspaceRestoreSourceWorkspaceAttribute, &err)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s attribute on workspace: %w", constants.WorkspaceRestoreSourceWorkspaceAttribute, err)
+		}
+		// Backups of DevWorkspaces in other namespaces are not accessible, as the backup location is derived from the
+		// source namespace and would otherwise allow reading another user's data
+		if errs := validation.IsDNS1123Subdomain(sourceWorkspace); len(errs) > 0 {
+			return "", fmt.Errorf("invalid value %q for attribute %s: must be the name of a DevWorkspace in namespace %s",
+				sourceWorkspace, constants.WorkspaceRestoreSourceWorkspaceAttribute, workspace.Namespace)
+		}
+		sourceName = sourceWorkspace
+	}
+
+	selector := ":latest"
+	if attributes.Exists(constants.WorkspaceRestoreBackupAttribute) {
+		selected := attributes.GetString(constants.WorkspaceRestoreBackupAttribute, &err)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s attribute on workspace: %w", constants.WorkspaceRestoreBackupAttribute, err)
+		}
+		selector, err = getBackupSelector(selected)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	location := backup.GetBackupLocation(workspace.Config.Workspace.BackupCronJob)
+	return backup.GetWorkspaceBackupLocation(location, workspace.Namespace, sourceName) + selector, nil
+}
+
+// getBackupSelector returns the tag or digest that identifies the backup selected through the
+// WorkspaceRestoreBackupAttribute attribute, prefixed with the separator used in image references.
+func getBackupSelector(selected string) (string, error) {
+	if strings.HasPrefix(selected, "sha256:") {
+		return "@" + selected, nil
+	}
+	if _, ok := backup.ParseBackupTag(selected); ok {
+		return ":" + selected, nil
+	}
+	if created, err := time.Parse(time.RFC3339, selected); err == nil {
+		return ":" + backup.BackupTag(created), nil
+	}
+	return "", fmt.Errorf("invalid value %q for attribute %s: expected a backup tag, an RFC3339 timestamp or a digest",
+		selected, constants.WorkspaceRestoreBackupAttribute)
+}
+
+// hasPersistentVolume returns whether the DevWorkspace defines a volume component with the given name that is not ephemeral.
+func hasPersistentVolume(workspace *dw.DevWorkspaceTemplateSpec, name string) bool {
+	for _, component := range workspace.Components {
+		if component.Name == name && component.Volume != nil {
+			return component.Volume.Ephemeral == nil || !*component.Volume.Ephemeral
+		}
+	}
+	return false
+}
+
 func hasContainerComponents(workspace *dw.DevWorkspaceTemplateSpec) bool {
 	for _, component := range workspace.Components {
 		if component.Container != nil {
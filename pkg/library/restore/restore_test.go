@@ -0,0 +1,174 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package restore
+
+import (
+	"context"
+	"testing"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func TestGetDefaultRestoreSourceImage(t *testing.T) {
+	tests := []struct {
+		name          string
+		attributes    attributes.Attributes
+		expectedImage string
+		expectedErr   string
+	}{
+		{
+			name:          "Restores most recent backup of the workspace by default",
+			attributes:    attributes.Attributes{},
+			expectedImage: "registry.example.com/backups/test-ns/test-workspace:latest",
+		},
+		{
+			name:          "Restores backup selected by tag",
+			attributes:    attributes.Attributes{}.PutString(constants.WorkspaceRestoreBackupAttribute, "backup-20261018T010000Z"),
+			expectedImage: "registry.example.com/backups/test-ns/test-workspace:backup-20261018T010000Z",
+		},
+		{
+			name:          "Restores backup selected by timestamp",
+			attributes:    attributes.Attributes{}.PutString(constants.WorkspaceRestoreBackupAttribute, "2026-10-18T03:00:00+02:00"),
+			expectedImage: "registry.example.com/backups/test-ns/test-workspace:backup-20261018T010000Z",
+		},
+		{
+			name:          "Restores backup selected by digest",
+			attributes:    attributes.Attributes{}.PutString(constants.WorkspaceRestoreBackupAttribute, "sha256:abcdef"),
+			expectedImage: "registry.example.com/backups/test-ns/test-workspace@sha256:abcdef",
+		},
+		{
+			name:          "Restores backup of another workspace in the same namespace",
+			attributes:    attributes.Attributes{}.PutString(constants.WorkspaceRestoreSourceWorkspaceAttribute, "other-workspace"),
+			expectedImage: "registry.example.com/backups/test-ns/other-workspace:latest",
+		},
+		{
+			name: "Restores selected backup of another workspace",
+			attributes: attributes.Attributes{}.
+				PutString(constants.WorkspaceRestoreSourceWorkspaceAttribute, "other-workspace").
+				PutString(constants.WorkspaceRestoreBackupAttribute, "backup-20261018T010000Z"),
+			expectedImage: "registry.example.com/backups/test-ns/other-workspace:backup-20261018T010000Z",
+		},
+		{
+			name:        "Rejects workspace in another namespace",
+			attributes:  attributes.Attributes{}.PutString(constants.WorkspaceRestoreSourceWorkspaceAttribute, "other-ns/other-workspace"),
+			expectedErr: "invalid value \"other-ns/other-workspace\" for attribute controller.devfile.io/restore-source-workspace: must be the name of a DevWorkspace in namespace test-ns",
+		},
+		{
+			name:        "Rejects path traversal in source workspace",
+			attributes:  attributes.Attributes{}.PutString(constants.WorkspaceRestoreSourceWorkspaceAttribute, ".."),
+			expectedErr: "invalid value \"..\" for attribute controller.devfile.io/restore-source-workspace",
+		},
+		{
+			name:        "Rejects invalid backup selection",
+			attributes:  attributes.Attributes{}.PutString(constants.WorkspaceRestoreBackupAttribute, "yesterday"),
+			expectedErr: "invalid value \"yesterday\" for attribute controller.devfile.io/restore-backup",
+		},
+		{
+			name:        "Rejects invalid source workspace",
+			attributes:  attributes.Attributes{}.PutString(constants.WorkspaceRestoreSourceWorkspaceAttribute, ""),
+			expectedErr: "invalid value \"\" for attribute controller.devfile.io/restore-source-workspace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspace := &common.DevWorkspaceWithConfig{
+				DevWorkspace: &dw.DevWorkspace{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "test-ns"},
+					Spec: dw.DevWorkspaceSpec{
+						Template: dw.DevWorkspaceTemplateSpec{
+							DevWorkspaceTemplateSpecContent: dw.DevWorkspaceTemplateSpecContent{
+								Attributes: tt.attributes,
+							},
+						},
+					},
+				},
+				Config: &v1alpha1.OperatorConfiguration{
+					Workspace: &v1alpha1.WorkspaceConfig{
+						BackupCronJob: &v1alpha1.BackupCronJobConfig{
+							Registry: &v1alpha1.RegistryConfig{Path: "registry.example.com/backups/"},
+						},
+					},
+				},
+			}
+			image, err := getDefaultRestoreSourceImage(workspace)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedImage, image)
+		})
+	}
+}
+
+func TestGetWorkspaceRestoreInitContainerRestoresIncludedVolumes(t *testing.T) {
+	workspace := &common.DevWorkspaceWithConfig{
+		DevWorkspace: &dw.DevWorkspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "test-ns"},
+			Spec: dw.DevWorkspaceSpec{
+				Template: dw.DevWorkspaceTemplateSpec{
+					DevWorkspaceTemplateSpecContent: dw.DevWorkspaceTemplateSpecContent{
+						Attributes: attributes.Attributes{}.PutBoolean(constants.WorkspaceRestoreAttribute, true),
+						Components: []dw.Component{
+							{Name: "tools", ComponentUnion: dw.ComponentUnion{Container: &dw.ContainerComponent{}}},
+							{Name: constants.HomeVolumeName, ComponentUnion: dw.ComponentUnion{Volume: &dw.VolumeComponent{}}},
+							{Name: "m2", ComponentUnion: dw.ComponentUnion{Volume: &dw.VolumeComponent{}}},
+							{Name: "tmp", ComponentUnion: dw.ComponentUnion{Volume: &dw.VolumeComponent{
+								Volume: dw.Volume{Ephemeral: pointer.Bool(true)},
+							}}},
+						},
+					},
+				},
+			},
+		},
+		Config: &v1alpha1.OperatorConfiguration{
+			Workspace: &v1alpha1.WorkspaceConfig{
+				BackupCronJob: &v1alpha1.BackupCronJobConfig{
+					Registry: &v1alpha1.RegistryConfig{Path: "registry.example.com/backups"},
+					Include: &v1alpha1.BackupIncludeConfig{
+						PersistentHome: pointer.Bool(true),
+						Volumes:        []string{"m2", "tmp", "undefined"},
+					},
+				},
+			},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().Build()
+
+	container, _, err := GetWorkspaceRestoreInitContainer(context.Background(), workspace, k8sClient, Options{Resources: &corev1.ResourceRequirements{}}, k8sClient.Scheme(), zap.New())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "projects", MountPath: "/projects"},
+		{Name: constants.HomeVolumeName, MountPath: "/workspace-volumes/persistent-home"},
+		{Name: "m2", MountPath: "/workspace-volumes/m2"},
+	}, container.VolumeMounts)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: backup.VolumesEnvVar, Value: "persistent-home,m2"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: backup.VolumesPathEnvVar, Value: "/workspace-volumes"})
+}
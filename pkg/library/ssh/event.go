@@ -73,6 +73,33 @@ func AddSshAgentPostStartEvent(spec *v1alpha2.DevWorkspaceTemplateSpec) error {
 // be used by the workspace's SSH agent.
 // If no SSH key is provided, or the SSH key does not provide a passphrase, then the SSH Agent Post Start event is not required.
 func NeedsSSHPostStartEvent(api sync.ClusterAPI, namespace string) (bool, error) {
+	sshSecret, err := getSSHSecret(api, namespace)
+	if err != nil || sshSecret == nil {
+		return false, err
+	}
+
+	if _, ok := sshSecret.Data[constants.SSHSecretPassphraseKey]; ok {
+		// SSH secret exists and has a passphrase set
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// HasSSHKey determines whether an SSH secret containing a private key is provided for the namespace where the
+// workspace exists. If so, the SSH secret should be mounted to the project clone container to allow cloning
+// projects over SSH.
+func HasSSHKey(api sync.ClusterAPI, namespace string) (bool, error) {
+	sshSecret, err := getSSHSecret(api, namespace)
+	if err != nil || sshSecret == nil {
+		return false, err
+	}
+	_, ok := sshSecret.Data[constants.SSHSecretPrivateKeyKey]
+	return ok, nil
+}
+
+// getSSHSecret returns the SSH secret in a namespace, or nil if it does not exist
+func getSSHSecret(api sync.ClusterAPI, namespace string) (*corev1.Secret, error) {
 	secretNN := types.NamespacedName{
 		Name:      constants.SSHSecretName,
 		Namespace: namespace,
@@ -81,15 +108,9 @@ func NeedsSSHPostStartEvent(api sync.ClusterAPI, namespace string) (bool, error)
 	if err := api.Client.Get(api.Ctx, secretNN, sshSecret); err != nil {
 		if k8sErrors.IsNotFound(err) {
 			// No SSH secret found
-			return false, nil
+			return nil, nil
 		}
-		return false, err
+		return nil, err
 	}
-
-	if _, ok := sshSecret.Data[constants.SSHSecretPassphraseKey]; ok {
-		// SSH secret exists and has a passphrase set
-		return true, nil
-	}
-
-	return false, nil
+	return sshSecret, nil
 }
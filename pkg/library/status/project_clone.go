@@ -0,0 +1,91 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package status
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/library/projects"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+// GetProjectCloneResults returns the results written by the project clone init container to its termination message.
+// If there are multiple workspace pods, the most recently created pod that has reported results is used. Returns an
+// empty message and nil results if the project clone container has not reported any results.
+func GetProjectCloneResults(workspace *common.DevWorkspaceWithConfig, clusterAPI sync.ClusterAPI) (message string, results *projects.ProjectCloneResults, err error) {
+	podList := &corev1.PodList{}
+	workspaceIDLabel := k8sclient.MatchingLabels{constants.DevWorkspaceIDLabel: workspace.Status.DevWorkspaceId}
+	if err := clusterAPI.Client.List(context.TODO(), podList, k8sclient.InNamespace(workspace.Namespace), workspaceIDLabel); err != nil {
+		return "", nil, err
+	}
+
+	var newestPod *corev1.Pod
+	for idx, pod := range podList.Items {
+		if getProjectCloneMessage(&pod) == "" {
+			continue
+		}
+		if newestPod == nil || newestPod.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			newestPod = &podList.Items[idx]
+		}
+	}
+	if newestPod == nil {
+		return "", nil, nil
+	}
+
+	message = getProjectCloneMessage(newestPod)
+	results, err = projects.ParseProjectCloneResults(message)
+	if err != nil {
+		return "", nil, err
+	}
+	return message, results, nil
+}
+
+// GetProjectCloneWarnings returns a warning message for each project that was not set up successfully and for each
+// warning reported for a project that was set up
+func GetProjectCloneWarnings(results *projects.ProjectCloneResults) []string {
+	var warnings []string
+	for _, result := range results.Projects {
+		switch result.State {
+		case projects.ProjectCloneFailed:
+			warnings = append(warnings, fmt.Sprintf("Failed to set up project %s: %s", result.Name, result.Error))
+		case projects.ProjectCloneSkipped:
+			warnings = append(warnings, fmt.Sprintf("Project %s was not set up as an earlier project could not be processed", result.Name))
+		case projects.ProjectCloneSucceeded:
+			for _, warning := range result.Warnings {
+				warnings = append(warnings, fmt.Sprintf("Project %s: %s", result.Name, warning))
+			}
+		}
+	}
+	return warnings
+}
+
+func getProjectCloneMessage(pod *corev1.Pod) string {
+	for _, initContainerStatus := range pod.Status.InitContainerStatuses {
+		if initContainerStatus.Name != projects.ProjectClonerContainerName {
+			continue
+		}
+		if initContainerStatus.State.Terminated != nil {
+			return initContainerStatus.State.Terminated.Message
+		}
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package status
+
+import (
+	"testing"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/library/projects"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+func getTestProjectClonePod(name string, created time.Time, message string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "test-namespace",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels: map[string]string{
+				constants.DevWorkspaceIDLabel: "test-id",
+			},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: projects.ProjectClonerContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: message},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetProjectCloneResults(t *testing.T) {
+	workspace := &common.DevWorkspaceWithConfig{
+		DevWorkspace: &dw.DevWorkspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "test-namespace"},
+			Status:     dw.DevWorkspaceStatus{DevWorkspaceId: "test-id"},
+		},
+	}
+	now := time.Now()
+	newResults := `{"projects":[{"name":"project-a","state":"Failed","error":"authentication required"},{"name":"project-b","state":"Succeeded","commit":"abc123","duration":"1.5s"}]}`
+	oldResults := `{"projects":[{"name":"project-a","state":"Succeeded"}]}`
+
+	tests := []struct {
+		name            string
+		pods            []client.Object
+		expectedMessage string
+		expectedErr     bool
+	}{
+		{
+			name:            "No pods",
+			expectedMessage: "",
+		},
+		{
+			name:            "Project clone has not finished",
+			pods:            []client.Object{getTestProjectClonePod("pod", now, "")},
+			expectedMessage: "",
+		},
+		{
+			name: "Uses results from newest pod",
+			pods: []client.Object{
+				getTestProjectClonePod("old-pod", now.Add(-time.Hour), oldResults),
+				getTestProjectClonePod("new-pod", now, newResults),
+			},
+			expectedMessage: newResults,
+		},
+		{
+			name:        "Invalid results",
+			pods:        []client.Object{getTestProjectClonePod("pod", now, "not json")},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterAPI := sync.ClusterAPI{
+				Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tt.pods...).Build(),
+			}
+			message, results, err := GetProjectCloneResults(workspace, clusterAPI)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.expectedMessage, message)
+			if tt.expectedMessage == "" {
+				assert.Nil(t, results)
+			} else {
+				assert.NotNil(t, results)
+			}
+		})
+	}
+}
+
+func TestGetProjectCloneWarnings(t *testing.T) {
+	results := &projects.ProjectCloneResults{
+		Projects: []projects.ProjectCloneResult{
+			{Name: "project-a", State: projects.ProjectCloneSucceeded, Commit: "abc123"},
+			{Name: "project-b", State: projects.ProjectCloneFailed, Error: "authentication required"},
+			{Name: "project-c", State: projects.ProjectCloneSkipped},
+			{Name: "project-d", State: projects.ProjectCloneSucceeded, Warnings: []string{"failed to fetch Git LFS objects: exit status 2"}},
+		},
+	}
+	assert.Equal(t, []string{
+		"Failed to set up project project-b: authentication required",
+		"Project project-c was not set up as an earlier project could not be processed",
+		"Project project-d: failed to fetch Git LFS objects: exit status 2",
+	}, GetProjectCloneWarnings(results))
+}
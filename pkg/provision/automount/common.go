@@ -189,6 +189,8 @@ func formatVolumeDescription(vol corev1.Volume) string {
 		return fmt.Sprintf("configmap '%s'", vol.ConfigMap.Name)
 	} else if vol.PersistentVolumeClaim != nil {
 		return fmt.Sprintf("pvc '%s'", vol.PersistentVolumeClaim.ClaimName)
+	} else if vol.Image != nil {
+		return fmt.Sprintf("image '%s'", vol.Image.Reference)
 	}
 	return fmt.Sprintf("'%s'", vol.Name)
 }
@@ -0,0 +1,167 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package automount
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/dwerrors"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+// ProvisionSharedCacheVolumesInto mounts the shared cache volumes defined in the DevWorkspace Operator configuration
+// into all containers in podAdditions. Only shared cache volumes whose namespace and workspace selectors match the
+// DevWorkspace are added, and volumes are always mounted read-only. Should be called after automount resources have
+// been provisioned, so that collisions with automounted volumes are detected as well.
+func ProvisionSharedCacheVolumesInto(podAdditions *v1alpha1.PodAdditions, workspace *common.DevWorkspaceWithConfig, api sync.ClusterAPI) error {
+	if workspace.Config == nil || workspace.Config.Workspace == nil || len(workspace.Config.Workspace.SharedCacheVolumes) == 0 {
+		return nil
+	}
+
+	resources, err := getSharedCacheResources(workspace, api)
+	if err != nil {
+		return err
+	}
+	if len(resources.Volumes) == 0 {
+		return nil
+	}
+
+	if err := checkAutomountVolumesForCollision(podAdditions, resources); err != nil {
+		return err
+	}
+
+	for idx, container := range podAdditions.Containers {
+		podAdditions.Containers[idx].VolumeMounts = append(container.VolumeMounts, resources.VolumeMounts...)
+	}
+	podAdditions.Volumes = append(podAdditions.Volumes, resources.Volumes...)
+
+	return nil
+}
+
+func getSharedCacheResources(workspace *common.DevWorkspaceWithConfig, api sync.ClusterAPI) (*Resources, error) {
+	resources := &Resources{}
+
+	var namespaceLabels labels.Set
+	namespaceLabelsRead := false
+	for _, cache := range workspace.Config.Workspace.SharedCacheVolumes {
+		if err := validateSharedCacheVolume(cache); err != nil {
+			return nil, &dwerrors.FailError{Message: fmt.Sprintf("invalid shared cache volume '%s': %s", cache.Name, err)}
+		}
+
+		if cache.NamespaceSelector != nil {
+			if !namespaceLabelsRead {
+				namespace := &corev1.Namespace{}
+				if err := api.Client.Get(api.Ctx, types.NamespacedName{Name: workspace.Namespace}, namespace); err != nil {
+					return nil, err
+				}
+				namespaceLabels = namespace.Labels
+				namespaceLabelsRead = true
+			}
+			matches, err := matchesLabelSelector(cache.NamespaceSelector, namespaceLabels)
+			if err != nil {
+				return nil, &dwerrors.FailError{Message: fmt.Sprintf("invalid namespaceSelector for shared cache volume '%s': %s", cache.Name, err)}
+			}
+			if !matches {
+				log.V(1).Info("Skipping shared cache volume, namespace does not match selector", "namespace", workspace.Namespace, "cache", cache.Name)
+				continue
+			}
+		}
+
+		if cache.WorkspaceSelector != nil {
+			matches, err := matchesLabelSelector(cache.WorkspaceSelector, workspace.Labels)
+			if err != nil {
+				return nil, &dwerrors.FailError{Message: fmt.Sprintf("invalid workspaceSelector for shared cache volume '%s': %s", cache.Name, err)}
+			}
+			if !matches {
+				log.V(1).Info("Skipping shared cache volume, workspace does not match selector", "workspace", workspace.Name, "cache", cache.Name)
+				continue
+			}
+		}
+
+		volume := corev1.Volume{
+			Name: common.SharedCacheVolumeName(cache.Name),
+		}
+		switch {
+		case cache.PersistentVolumeClaim != nil:
+			pvc := &corev1.PersistentVolumeClaim{}
+			err := api.Client.Get(api.Ctx, types.NamespacedName{Name: cache.PersistentVolumeClaim.ClaimName, Namespace: workspace.Namespace}, pvc)
+			if err != nil {
+				if k8sErrors.IsNotFound(err) {
+					log.V(1).Info("Skipping shared cache volume, PVC does not exist in namespace", "namespace", workspace.Namespace, "cache", cache.Name, "pvc", cache.PersistentVolumeClaim.ClaimName)
+					continue
+				}
+				return nil, err
+			}
+			volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: pvc.Name,
+				ReadOnly:  true,
+			}
+		case cache.Image != nil:
+			volume.Image = cache.Image.DeepCopy()
+		}
+
+		resources.Volumes = append(resources.Volumes, volume)
+		resources.VolumeMounts = append(resources.VolumeMounts, corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: cache.MountPath,
+			SubPath:   cache.SubPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return resources, nil
+}
+
+// validateSharedCacheVolume checks that a shared cache volume defines a usable mount path and exactly one
+// volume source.
+func validateSharedCacheVolume(cache v1alpha1.SharedCacheVolume) error {
+	if cache.MountPath == "" {
+		return fmt.Errorf("mountPath is required")
+	}
+	if strings.Contains(cache.MountPath, ":") {
+		return fmt.Errorf("mountPath cannot contain ':'")
+	}
+	if cache.PersistentVolumeClaim != nil && cache.Image != nil {
+		return fmt.Errorf("only one of persistentVolumeClaim or image may be specified")
+	}
+	if cache.PersistentVolumeClaim == nil && cache.Image == nil {
+		return fmt.Errorf("one of persistentVolumeClaim or image must be specified")
+	}
+	if cache.PersistentVolumeClaim != nil && cache.PersistentVolumeClaim.ClaimName == "" {
+		return fmt.Errorf("persistentVolumeClaim.claimName is required")
+	}
+	if cache.Image != nil && cache.Image.Reference == "" {
+		return fmt.Errorf("image.reference is required")
+	}
+	return nil
+}
+
+func matchesLabelSelector(selector *metav1.LabelSelector, objLabels labels.Set) (bool, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return labelSelector.Matches(objLabels), nil
+}
@@ -0,0 +1,274 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package automount
+
+import (
+	"testing"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/dwerrors"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+func getSharedCacheTestWorkspace(workspaceLabels map[string]string, caches ...v1alpha1.SharedCacheVolume) *common.DevWorkspaceWithConfig {
+	return &common.DevWorkspaceWithConfig{
+		DevWorkspace: &dw.DevWorkspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-workspace",
+				Namespace: testNamespace,
+				Labels:    workspaceLabels,
+			},
+		},
+		Config: &v1alpha1.OperatorConfiguration{
+			Workspace: &v1alpha1.WorkspaceConfig{
+				SharedCacheVolumes: caches,
+			},
+		},
+	}
+}
+
+func getSharedCacheTestPodAdditions() *v1alpha1.PodAdditions {
+	return &v1alpha1.PodAdditions{
+		Containers: []corev1.Container{{
+			Name:  "test-container",
+			Image: "test-image",
+		}},
+		InitContainers: []corev1.Container{{
+			Name:  "test-init-container",
+			Image: "test-image",
+		}},
+	}
+}
+
+func TestProvisionSharedCacheVolumesPVC(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "maven-cache",
+			Namespace: testNamespace,
+		},
+	}
+	testAPI := sync.ClusterAPI{
+		Client: fake.NewClientBuilder().WithObjects(pvc).Build(),
+	}
+	workspace := getSharedCacheTestWorkspace(nil, v1alpha1.SharedCacheVolume{
+		Name:                  "maven",
+		MountPath:             "/home/user/.m2/repository",
+		SubPath:               "repository",
+		PersistentVolumeClaim: &v1alpha1.SharedCachePVCSource{ClaimName: "maven-cache"},
+	})
+	podAdditions := getSharedCacheTestPodAdditions()
+
+	err := ProvisionSharedCacheVolumesInto(podAdditions, workspace, testAPI)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	expectedVolume := corev1.Volume{
+		Name: "shared-cache-maven",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "maven-cache",
+				ReadOnly:  true,
+			},
+		},
+	}
+	expectedVolumeMount := corev1.VolumeMount{
+		Name:      "shared-cache-maven",
+		MountPath: "/home/user/.m2/repository",
+		SubPath:   "repository",
+		ReadOnly:  true,
+	}
+	assert.Equal(t, []corev1.Volume{expectedVolume}, podAdditions.Volumes)
+	assert.Equal(t, []corev1.VolumeMount{expectedVolumeMount}, podAdditions.Containers[0].VolumeMounts)
+	assert.Empty(t, podAdditions.InitContainers[0].VolumeMounts, "Shared cache volumes should not be mounted to init containers")
+}
+
+func TestProvisionSharedCacheVolumesImage(t *testing.T) {
+	testAPI := sync.ClusterAPI{
+		Client: fake.NewClientBuilder().Build(),
+	}
+	workspace := getSharedCacheTestWorkspace(nil, v1alpha1.SharedCacheVolume{
+		Name:      "gomod",
+		MountPath: "/home/user/go/pkg/mod",
+		Image: &corev1.ImageVolumeSource{
+			Reference:  "quay.io/example/gomod-cache:latest",
+			PullPolicy: corev1.PullIfNotPresent,
+		},
+	})
+	podAdditions := getSharedCacheTestPodAdditions()
+
+	err := ProvisionSharedCacheVolumesInto(podAdditions, workspace, testAPI)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, podAdditions.Volumes, 1) {
+		assert.Equal(t, "shared-cache-gomod", podAdditions.Volumes[0].Name)
+		if assert.NotNil(t, podAdditions.Volumes[0].Image) {
+			assert.Equal(t, "quay.io/example/gomod-cache:latest", podAdditions.Volumes[0].Image.Reference)
+		}
+	}
+	if assert.Len(t, podAdditions.Containers[0].VolumeMounts, 1) {
+		assert.True(t, podAdditions.Containers[0].VolumeMounts[0].ReadOnly)
+	}
+}
+
+func TestProvisionSharedCacheVolumesSkipsMissingPVC(t *testing.T) {
+	testAPI := sync.ClusterAPI{
+		Client: fake.NewClientBuilder().Build(),
+	}
+	workspace := getSharedCacheTestWorkspace(nil, v1alpha1.SharedCacheVolume{
+		Name:                  "maven",
+		MountPath:             "/home/user/.m2/repository",
+		PersistentVolumeClaim: &v1alpha1.SharedCachePVCSource{ClaimName: "maven-cache"},
+	})
+	podAdditions := getSharedCacheTestPodAdditions()
+
+	err := ProvisionSharedCacheVolumesInto(podAdditions, workspace, testAPI)
+	assert.NoError(t, err)
+	assert.Empty(t, podAdditions.Volumes)
+	assert.Empty(t, podAdditions.Containers[0].VolumeMounts)
+}
+
+func TestProvisionSharedCacheVolumesSelectors(t *testing.T) {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   testNamespace,
+			Labels: map[string]string{"team": "java"},
+		},
+	}
+	objs := []client.Object{namespace}
+	testAPI := sync.ClusterAPI{
+		Client: fake.NewClientBuilder().WithObjects(objs...).Build(),
+	}
+	image := &corev1.ImageVolumeSource{Reference: "quay.io/example/cache:latest"}
+	workspace := getSharedCacheTestWorkspace(map[string]string{"stack": "maven"},
+		v1alpha1.SharedCacheVolume{
+			Name:              "namespace-match",
+			MountPath:         "/cache/namespace-match",
+			Image:             image,
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "java"}},
+		},
+		v1alpha1.SharedCacheVolume{
+			Name:              "namespace-no-match",
+			MountPath:         "/cache/namespace-no-match",
+			Image:             image,
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "go"}},
+		},
+		v1alpha1.SharedCacheVolume{
+			Name:              "workspace-match",
+			MountPath:         "/cache/workspace-match",
+			Image:             image,
+			WorkspaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"stack": "maven"}},
+		},
+		v1alpha1.SharedCacheVolume{
+			Name:              "workspace-no-match",
+			MountPath:         "/cache/workspace-no-match",
+			Image:             image,
+			WorkspaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"stack": "npm"}},
+		},
+	)
+	podAdditions := getSharedCacheTestPodAdditions()
+
+	err := ProvisionSharedCacheVolumesInto(podAdditions, workspace, testAPI)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var volumeNames []string
+	for _, vol := range podAdditions.Volumes {
+		volumeNames = append(volumeNames, vol.Name)
+	}
+	assert.Equal(t, []string{"shared-cache-namespace-match", "shared-cache-workspace-match"}, volumeNames)
+}
+
+func TestProvisionSharedCacheVolumesInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		cache v1alpha1.SharedCacheVolume
+	}{
+		{
+			name: "Both PVC and image specified",
+			cache: v1alpha1.SharedCacheVolume{
+				Name:                  "test",
+				MountPath:             "/cache",
+				PersistentVolumeClaim: &v1alpha1.SharedCachePVCSource{ClaimName: "test"},
+				Image:                 &corev1.ImageVolumeSource{Reference: "test-image"},
+			},
+		},
+		{
+			name: "No source specified",
+			cache: v1alpha1.SharedCacheVolume{
+				Name:      "test",
+				MountPath: "/cache",
+			},
+		},
+		{
+			name: "Mount path contains colon",
+			cache: v1alpha1.SharedCacheVolume{
+				Name:      "test",
+				MountPath: "/cache:ro",
+				Image:     &corev1.ImageVolumeSource{Reference: "test-image"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAPI := sync.ClusterAPI{
+				Client: fake.NewClientBuilder().Build(),
+			}
+			err := ProvisionSharedCacheVolumesInto(getSharedCacheTestPodAdditions(), getSharedCacheTestWorkspace(nil, tt.cache), testAPI)
+			assert.Error(t, err)
+			assert.IsType(t, &dwerrors.FailError{}, err)
+		})
+	}
+}
+
+func TestProvisionSharedCacheVolumesDetectsCollisions(t *testing.T) {
+	testAPI := sync.ClusterAPI{
+		Client: fake.NewClientBuilder().Build(),
+	}
+	workspace := getSharedCacheTestWorkspace(nil, v1alpha1.SharedCacheVolume{
+		Name:      "npm",
+		MountPath: "/home/user/.npm",
+		Image:     &corev1.ImageVolumeSource{Reference: "quay.io/example/npm-cache:latest"},
+	})
+	podAdditions := getSharedCacheTestPodAdditions()
+	podAdditions.Volumes = []corev1.Volume{{
+		Name: "automount-npm",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "npmrc"},
+			},
+		},
+	}}
+	podAdditions.Containers[0].VolumeMounts = []corev1.VolumeMount{{
+		Name:      "automount-npm",
+		MountPath: "/home/user/.npm",
+	}}
+
+	err := ProvisionSharedCacheVolumesInto(podAdditions, workspace, testAPI)
+	assert.Error(t, err)
+	assert.IsType(t, &dwerrors.FailError{}, err)
+}
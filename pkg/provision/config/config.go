@@ -106,3 +106,19 @@ func GetNamespacePodTolerationsAndNodeSelector(namespace string, api sync.Cluste
 
 	return podTolerations, nodeSelector, nil
 }
+
+// IsStorageProfileAllowed checks whether DevWorkspaces in a namespace may use the storage profile with the given name.
+// Allowed storage profiles are read from a comma-separated annotation on the namespace; if the annotation is not set,
+// all storage profiles are allowed.
+func IsStorageProfileAllowed(namespace *corev1.Namespace, profileName string) bool {
+	allowedProfilesAnnot, ok := namespace.Annotations[constants.NamespaceAllowedStorageProfilesAnnotation]
+	if !ok {
+		return true
+	}
+	for _, allowedProfile := range strings.Split(allowedProfilesAnnot, ",") {
+		if strings.TrimSpace(allowedProfile) == profileName {
+			return true
+		}
+	}
+	return false
+}
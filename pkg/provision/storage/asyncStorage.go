@@ -112,7 +112,11 @@ func (p *AsyncStorageProvisioner) ProvisionStorage(podAdditions *v1alpha1.PodAdd
 
 	if !usingAlternatePVC {
 		// Create common PVC if needed
-		clusterPVC, err := syncCommonPVC(workspace.Namespace, workspace.Config, clusterAPI)
+		profile, err := getStorageProfile(workspace, pvcName, clusterAPI)
+		if err != nil {
+			return err
+		}
+		clusterPVC, err := syncCommonPVC(workspace.Namespace, workspace.Config, profile, clusterAPI)
 		if err != nil {
 			return err
 		}
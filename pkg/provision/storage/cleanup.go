@@ -251,14 +251,20 @@ func commonPVCExists(workspace *common.DevWorkspaceWithConfig, clusterAPI sync.C
 // common PVC is running in.
 // Returns an empty string if no such pod exists.
 func getTargetNodeName(workspace *common.DevWorkspaceWithConfig, clusterAPI sync.ClusterAPI) (string, error) {
+	return getTargetNodeNameForPVC(workspace.Namespace, workspace.Config.Workspace.PVCName, clusterAPI)
+}
 
+// getTargetNodeNameForPVC returns the node name of the node a running devworkspace pod that mounts the PVC
+// with the given name is running in.
+// Returns an empty string if no such pod exists.
+func getTargetNodeNameForPVC(namespace, pvcName string, clusterAPI sync.ClusterAPI) (string, error) {
 	labelSelector, err := labels.Parse(constants.DevWorkspaceIDLabel)
 	if err != nil {
 		return "", err
 	}
 
 	listOptions := &client.ListOptions{
-		Namespace:     workspace.Namespace,
+		Namespace:     namespace,
 		LabelSelector: labelSelector,
 	}
 
@@ -268,7 +274,7 @@ func getTargetNodeName(workspace *common.DevWorkspaceWithConfig, clusterAPI sync
 		return "", err
 	}
 
-	return getNodeNameWithPVC(found, workspace.Config.Workspace.PVCName), nil
+	return getNodeNameWithPVC(found, pvcName), nil
 }
 
 func getNodeNameWithPVC(list *corev1.PodList, pvcName string) string {
@@ -0,0 +1,165 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/dwerrors"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+// setCloneDataSource configures the per-workspace PVC spec pvc to be pre-populated from the PVC of the DevWorkspace
+// referenced by the clone-from attribute. The data source is only resolved when the PVC does not exist on the cluster
+// yet, as the data source of a PVC cannot be changed after creation; once the cloned PVC is bound, any VolumeSnapshot
+// created for cloning is removed.
+func setCloneDataSource(pvc *corev1.PersistentVolumeClaim, workspace *common.DevWorkspaceWithConfig, clusterAPI sync.ClusterAPI) error {
+	sourceName := workspace.Spec.Template.Attributes.GetString(constants.CloneFromAttribute, nil)
+	if sourceName == "" {
+		return nil
+	}
+	snapshotName := common.CloneSourceVolumeSnapshotName(workspace.Status.DevWorkspaceId)
+	snapshotClassName := ""
+	if workspace.Config.Workspace.VolumeSnapshotClassName != nil {
+		snapshotClassName = *workspace.Config.Workspace.VolumeSnapshotClassName
+	}
+
+	clusterPVC := &corev1.PersistentVolumeClaim{}
+	err := clusterAPI.Client.Get(clusterAPI.Ctx, types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}, clusterPVC)
+	switch {
+	case err == nil:
+		if snapshotClassName != "" && clusterPVC.Status.Phase == corev1.ClaimBound {
+			return deleteCloneSourceSnapshot(snapshotName, workspace.Namespace, clusterAPI)
+		}
+		return nil
+	case !k8sErrors.IsNotFound(err):
+		return err
+	}
+
+	if sourceName == workspace.Name {
+		return &dwerrors.FailError{Message: "DevWorkspace cannot be cloned from itself"}
+	}
+	sourceWorkspace := &dw.DevWorkspace{}
+	err = clusterAPI.Client.Get(clusterAPI.Ctx, types.NamespacedName{Name: sourceName, Namespace: workspace.Namespace}, sourceWorkspace)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return &dwerrors.FailError{Message: fmt.Sprintf("DevWorkspace '%s' to clone from does not exist in namespace", sourceName)}
+		}
+		return err
+	}
+	if sourceWorkspace.Spec.Template.Attributes.GetString(constants.DevWorkspaceStorageTypeAttribute, nil) != constants.PerWorkspaceStorageClassType {
+		return &dwerrors.FailError{Message: fmt.Sprintf("DevWorkspace '%s' to clone from does not use %s storage", sourceName, constants.PerWorkspaceStorageClassType)}
+	}
+	if sourceWorkspace.Status.DevWorkspaceId == "" {
+		return &dwerrors.RetryError{
+			Message:      fmt.Sprintf("Waiting for DevWorkspace '%s' to clone from to be assigned an ID", sourceName),
+			RequeueAfter: 5 * time.Second,
+		}
+	}
+
+	sourcePVC := &corev1.PersistentVolumeClaim{}
+	sourcePVCName := common.PerWorkspacePVCName(sourceWorkspace.Status.DevWorkspaceId)
+	err = clusterAPI.Client.Get(clusterAPI.Ctx, types.NamespacedName{Name: sourcePVCName, Namespace: workspace.Namespace}, sourcePVC)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return &dwerrors.FailError{Message: fmt.Sprintf("DevWorkspace '%s' to clone from does not have persistent storage", sourceName)}
+		}
+		return err
+	}
+
+	// Cloned volumes must use the same storage class as the source volume and be at least as large
+	pvc.Spec.StorageClassName = sourcePVC.Spec.StorageClassName
+	sourceSize := sourcePVC.Spec.Resources.Requests[corev1.ResourceStorage]
+	if pvcSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; pvcSize.Cmp(sourceSize) < 0 {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = sourceSize
+	}
+
+	if snapshotClassName == "" {
+		pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: sourcePVCName,
+		}
+		return nil
+	}
+
+	if err := syncCloneSourceSnapshot(snapshotName, sourcePVCName, snapshotClassName, workspace, clusterAPI); err != nil {
+		return err
+	}
+	apiGroup := VolumeSnapshotAPIGroup
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     VolumeSnapshotKind,
+		Name:     snapshotName,
+	}
+	return nil
+}
+
+// syncCloneSourceSnapshot creates a VolumeSnapshot of the source PVC if it does not exist yet, and returns a
+// RetryError until the VolumeSnapshot is ready to be used as a data source.
+func syncCloneSourceSnapshot(snapshotName, sourcePVCName, snapshotClassName string, workspace *common.DevWorkspaceWithConfig, clusterAPI sync.ClusterAPI) error {
+	snapshot := NewVolumeSnapshot()
+	err := clusterAPI.Client.Get(clusterAPI.Ctx, types.NamespacedName{Name: snapshotName, Namespace: workspace.Namespace}, snapshot)
+	switch {
+	case err == nil:
+		readyToUse, errMsg := GetVolumeSnapshotStatus(snapshot)
+		if errMsg != "" {
+			return &dwerrors.FailError{Message: fmt.Sprintf("Failed to create VolumeSnapshot of DevWorkspace storage to clone from: %s", errMsg)}
+		}
+		if readyToUse {
+			return nil
+		}
+	case meta.IsNoMatchError(err):
+		return &dwerrors.FailError{Message: "Cloning DevWorkspaces with a VolumeSnapshotClass requires VolumeSnapshots to be supported on the cluster", Err: err}
+	case k8sErrors.IsNotFound(err):
+		snapshot = GetVolumeSnapshotSpec(snapshotName, workspace.Namespace, sourcePVCName, snapshotClassName, map[string]string{
+			constants.DevWorkspaceIDLabel: workspace.Status.DevWorkspaceId,
+		})
+		if err := controllerutil.SetControllerReference(workspace.DevWorkspace, snapshot, clusterAPI.Scheme); err != nil {
+			return err
+		}
+		if err := clusterAPI.Client.Create(clusterAPI.Ctx, snapshot); err != nil && !k8sErrors.IsAlreadyExists(err) {
+			return err
+		}
+	default:
+		return err
+	}
+
+	return &dwerrors.RetryError{
+		Message:      "Waiting for VolumeSnapshot of DevWorkspace storage to clone from to be ready",
+		RequeueAfter: 5 * time.Second,
+	}
+}
+
+func deleteCloneSourceSnapshot(snapshotName, namespace string, clusterAPI sync.ClusterAPI) error {
+	snapshot := NewVolumeSnapshot()
+	snapshot.SetName(snapshotName)
+	snapshot.SetNamespace(namespace)
+	err := clusterAPI.Client.Delete(clusterAPI.Ctx, snapshot)
+	if err != nil && !k8sErrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
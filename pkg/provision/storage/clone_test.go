@@ -0,0 +1,210 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/dwerrors"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+const cloneTestNamespace = "test-ns"
+
+func getCloneTestWorkspace(name, workspaceId string, attrs map[string]string) *dw.DevWorkspace {
+	workspace := &dw.DevWorkspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cloneTestNamespace,
+			UID:       types.UID(name + "-uid"),
+		},
+		Status: dw.DevWorkspaceStatus{
+			DevWorkspaceId: workspaceId,
+		},
+	}
+	workspace.Spec.Template.Attributes = attributes.Attributes{}
+	for key, value := range attrs {
+		workspace.Spec.Template.Attributes.PutString(key, value)
+	}
+	return workspace
+}
+
+func getCloneTestPVC(workspaceId, size string, storageClass *string) *corev1.PersistentVolumeClaim {
+	pvc, _ := getPVCSpec(common.PerWorkspacePVCName(workspaceId), cloneTestNamespace, storageClass, resource.MustParse(size), nil)
+	return pvc
+}
+
+func getCloneTestClusterAPI(objs ...client.Object) sync.ClusterAPI {
+	return sync.ClusterAPI{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Scheme: scheme,
+		Logger: zap.New(zap.UseDevMode(true)),
+		Ctx:    context.Background(),
+	}
+}
+
+func getCloneTestWorkspaceWithConfig(snapshotClass *string) *common.DevWorkspaceWithConfig {
+	return &common.DevWorkspaceWithConfig{
+		DevWorkspace: getCloneTestWorkspace("clone", "clone-id", map[string]string{
+			constants.DevWorkspaceStorageTypeAttribute: constants.PerWorkspaceStorageClassType,
+			constants.CloneFromAttribute:               "source",
+		}),
+		Config: &v1alpha1.OperatorConfiguration{
+			Workspace: &v1alpha1.WorkspaceConfig{
+				VolumeSnapshotClassName: snapshotClass,
+			},
+		},
+	}
+}
+
+func TestCloneFromPVCDataSource(t *testing.T) {
+	sourceWorkspace := getCloneTestWorkspace("source", "source-id", map[string]string{
+		constants.DevWorkspaceStorageTypeAttribute: constants.PerWorkspaceStorageClassType,
+	})
+	sourcePVC := getCloneTestPVC("source-id", "20Gi", pointer.String("fast"))
+	clusterAPI := getCloneTestClusterAPI(sourceWorkspace, sourcePVC)
+
+	workspace := getCloneTestWorkspaceWithConfig(nil)
+	pvc := getCloneTestPVC("clone-id", "5Gi", nil)
+
+	err := setCloneDataSource(pvc, workspace, clusterAPI)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, &corev1.TypedLocalObjectReference{
+		Kind: "PersistentVolumeClaim",
+		Name: "storage-source-id",
+	}, pvc.Spec.DataSource)
+	assert.Equal(t, pointer.String("fast"), pvc.Spec.StorageClassName, "Cloned PVC should use the source PVC's storage class")
+	storageSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	assert.Equal(t, "20Gi", storageSize.String(), "Cloned PVC should be at least as large as source PVC")
+}
+
+func TestCloneFromVolumeSnapshot(t *testing.T) {
+	sourceWorkspace := getCloneTestWorkspace("source", "source-id", map[string]string{
+		constants.DevWorkspaceStorageTypeAttribute: constants.PerWorkspaceStorageClassType,
+	})
+	sourcePVC := getCloneTestPVC("source-id", "5Gi", nil)
+	clusterAPI := getCloneTestClusterAPI(sourceWorkspace, sourcePVC)
+
+	workspace := getCloneTestWorkspaceWithConfig(pointer.String("csi-snapclass"))
+	pvc := getCloneTestPVC("clone-id", "5Gi", nil)
+
+	err := setCloneDataSource(pvc, workspace, clusterAPI)
+	assert.IsType(t, &dwerrors.RetryError{}, err, "Should wait for VolumeSnapshot to be ready")
+
+	snapshot := NewVolumeSnapshot()
+	err = clusterAPI.Client.Get(clusterAPI.Ctx, types.NamespacedName{Name: "clone-id-clone-source", Namespace: cloneTestNamespace}, snapshot)
+	if !assert.NoError(t, err, "VolumeSnapshot should be created") {
+		return
+	}
+	sourcePVCName, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+	assert.Equal(t, "storage-source-id", sourcePVCName)
+	snapshotClass, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+	assert.Equal(t, "csi-snapclass", snapshotClass)
+
+	assert.NoError(t, unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse"))
+	assert.NoError(t, clusterAPI.Client.Update(clusterAPI.Ctx, snapshot))
+
+	err = setCloneDataSource(pvc, workspace, clusterAPI)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, &corev1.TypedLocalObjectReference{
+		APIGroup: pointer.String(VolumeSnapshotAPIGroup),
+		Kind:     VolumeSnapshotKind,
+		Name:     "clone-id-clone-source",
+	}, pvc.Spec.DataSource)
+}
+
+func TestCloneDeletesSnapshotWhenPVCIsBound(t *testing.T) {
+	snapshot := GetVolumeSnapshotSpec("clone-id-clone-source", cloneTestNamespace, "storage-source-id", "csi-snapclass", nil)
+	clonedPVC := getCloneTestPVC("clone-id", "5Gi", nil)
+	clonedPVC.Status.Phase = corev1.ClaimBound
+	clusterAPI := getCloneTestClusterAPI(snapshot, clonedPVC)
+
+	workspace := getCloneTestWorkspaceWithConfig(pointer.String("csi-snapclass"))
+	pvc := getCloneTestPVC("clone-id", "5Gi", nil)
+
+	err := setCloneDataSource(pvc, workspace, clusterAPI)
+	assert.NoError(t, err)
+	assert.Nil(t, pvc.Spec.DataSource, "Data source should not be set for existing PVC")
+
+	err = clusterAPI.Client.Get(clusterAPI.Ctx, types.NamespacedName{Name: "clone-id-clone-source", Namespace: cloneTestNamespace}, NewVolumeSnapshot())
+	assert.Error(t, err, "VolumeSnapshot should be deleted once cloned PVC is bound")
+}
+
+func TestCloneFailsForInvalidSource(t *testing.T) {
+	tests := []struct {
+		name string
+		objs []client.Object
+	}{
+		{
+			name: "Source workspace does not exist",
+		},
+		{
+			name: "Source workspace does not use per-workspace storage",
+			objs: []client.Object{
+				getCloneTestWorkspace("source", "source-id", nil),
+				getCloneTestPVC("source-id", "5Gi", nil),
+			},
+		},
+		{
+			name: "Source workspace does not have a PVC",
+			objs: []client.Object{
+				getCloneTestWorkspace("source", "source-id", map[string]string{
+					constants.DevWorkspaceStorageTypeAttribute: constants.PerWorkspaceStorageClassType,
+				}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterAPI := getCloneTestClusterAPI(tt.objs...)
+			err := setCloneDataSource(getCloneTestPVC("clone-id", "5Gi", nil), getCloneTestWorkspaceWithConfig(nil), clusterAPI)
+			assert.IsType(t, &dwerrors.FailError{}, err)
+		})
+	}
+}
+
+func TestGetProvisionerRejectsCloneForUnsupportedStorage(t *testing.T) {
+	workspace := getCloneTestWorkspaceWithConfig(nil)
+	workspace.Spec.Template.Attributes.PutString(constants.DevWorkspaceStorageTypeAttribute, constants.CommonStorageClassType)
+	_, err := GetProvisioner(workspace)
+	assert.ErrorIs(t, err, CloneUnsupportedStorageStrategy)
+
+	workspace.Spec.Template.Attributes.PutString(constants.DevWorkspaceStorageTypeAttribute, constants.PerWorkspaceStorageClassType)
+	provisioner, err := GetProvisioner(workspace)
+	assert.NoError(t, err)
+	assert.IsType(t, &PerWorkspaceStorageProvisioner{}, provisioner)
+}
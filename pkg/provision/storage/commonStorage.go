@@ -74,7 +74,11 @@ func (p *CommonStorageProvisioner) ProvisionStorage(podAdditions *v1alpha1.PodAd
 	}
 
 	if !usingAlternatePVC {
-		commonPVC, err := syncCommonPVC(workspace.Namespace, workspace.Config, clusterAPI)
+		profile, err := getStorageProfile(workspace, pvcName, clusterAPI)
+		if err != nil {
+			return err
+		}
+		commonPVC, err := syncCommonPVC(workspace.Namespace, workspace.Config, profile, clusterAPI)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,219 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/internal/images"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	nsconfig "github.com/devfile/devworkspace-operator/pkg/provision/config"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+const (
+	// orphanedDirectoryMinAgeMinutes is the minimum time since a directory in a common PVC was last modified
+	// before it can be considered orphaned. This avoids removing directories for DevWorkspaces that were
+	// created after the list of existing DevWorkspace IDs was computed.
+	orphanedDirectoryMinAgeMinutes = 60
+
+	orphanedStorageCleanupScript = `
+set -e
+cd "$PVC_MOUNT_PATH"
+count=0
+reclaimed=0
+for dir in */; do
+  id="${dir%/}"
+  [ -d "$id" ] || continue
+  [ "$id" = "lost+found" ] && continue
+  case " $DEVWORKSPACE_IDS " in
+    *" $id "*) continue ;;
+  esac
+  if [ -n "$(find "$id" -maxdepth 0 -mmin -"$MIN_AGE_MINUTES")" ]; then
+    echo "Skipping recently modified directory $id"
+    continue
+  fi
+  size=$(du -sk "$id" | cut -f1)
+  if [ "$DRY_RUN" = "true" ]; then
+    echo "Dry run: would remove orphaned directory $id (${size}KiB)"
+  else
+    echo "Removing orphaned directory $id (${size}KiB)"
+    rm -rf "$id"
+  fi
+  count=$((count + 1))
+  reclaimed=$((reclaimed + size))
+done
+echo "{\"orphanedDirectories\":$count,\"reclaimedBytes\":$((reclaimed * 1024))}" > /dev/termination-log
+`
+)
+
+// OrphanedStorageCleanupResult is the result reported by an orphaned storage cleanup job through its
+// termination message.
+type OrphanedStorageCleanupResult struct {
+	// OrphanedDirectories is the number of orphaned workspace directories found in the PVC
+	OrphanedDirectories int `json:"orphanedDirectories"`
+	// ReclaimedBytes is the total size of orphaned workspace directories. In dry-run mode, this is
+	// the amount of space that would have been reclaimed.
+	ReclaimedBytes int64 `json:"reclaimedBytes"`
+}
+
+// ParseOrphanedStorageCleanupResult parses the termination message of an orphaned storage cleanup job container.
+func ParseOrphanedStorageCleanupResult(message string) (*OrphanedStorageCleanupResult, error) {
+	result := &OrphanedStorageCleanupResult{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(message)), result); err != nil {
+		return nil, fmt.Errorf("failed to parse orphaned storage cleanup result: %w", err)
+	}
+	return result, nil
+}
+
+// IsCommonPVCName returns whether a PVC with the given name is used as a common PVC for DevWorkspaces, i.e. if
+// workspace storage is stored on subpaths named after DevWorkspace IDs within the PVC.
+func IsCommonPVCName(pvcName string, config *v1alpha1.OperatorConfiguration) bool {
+	return pvcName == config.Workspace.PVCName || pvcName == constants.CheCommonPVCName
+}
+
+// GetOrphanedStorageCleanupJob returns a job that removes all top-level directories in the common PVC that do not
+// correspond to one of workspaceIds. If dryRun is true, the job only reports the orphaned directories without
+// removing them. The result of the job is written to the container's termination message and can be read using
+// ParseOrphanedStorageCleanupResult.
+func GetOrphanedStorageCleanupJob(pvc *corev1.PersistentVolumeClaim, workspaceIds []string, dryRun bool, config *v1alpha1.OperatorConfiguration, clusterAPI sync.ClusterAPI) (*batchv1.Job, error) {
+	sortedIds := make([]string, len(workspaceIds))
+	copy(sortedIds, workspaceIds)
+	sort.Strings(sortedIds)
+
+	env := []corev1.EnvVar{
+		{Name: "PVC_MOUNT_PATH", Value: pvcClaimMountPath},
+		{Name: "DEVWORKSPACE_IDS", Value: strings.Join(sortedIds, " ")},
+		{Name: "DRY_RUN", Value: strconv.FormatBool(dryRun)},
+		{Name: "MIN_AGE_MINUTES", Value: strconv.Itoa(orphanedDirectoryMinAgeMinutes)},
+	}
+	return getCommonPVCScriptJob(pvc, common.OrphanedStorageCleanupJobName(pvc.Name), constants.DevWorkspaceOrphanedStorageCleanupJobLabel,
+		"cleanup-orphaned-storage", orphanedStorageCleanupScript, env, config, clusterAPI)
+}
+
+// getCommonPVCScriptJob returns a job that mounts the common PVC pvc and runs script in a container named containerName.
+// The job is scheduled on the node the PVC is currently attached to, if any, so that it can run while workspaces using
+// the PVC are running. The job and its pod are labelled with jobLabel.
+func getCommonPVCScriptJob(pvc *corev1.PersistentVolumeClaim, jobName, jobLabel, containerName, script string, env []corev1.EnvVar,
+	config *v1alpha1.OperatorConfiguration, clusterAPI sync.ClusterAPI) (*batchv1.Job, error) {
+	targetNode, err := getTargetNodeNameForPVC(pvc.Namespace, pvc.Name, clusterAPI)
+	if err != nil {
+		clusterAPI.Logger.Error(err, "Error getting target node for job", "job", jobName)
+	}
+
+	jobLabels := map[string]string{
+		jobLabel: "true",
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: pvc.Namespace,
+			Labels:    jobLabels,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:  &cleanupJobCompletions,
+			BackoffLimit: &cleanupJobBackoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: jobLabels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:   "Never",
+					SecurityContext: config.Workspace.PodSecurityContext,
+					Volumes: []corev1.Volume{
+						{
+							Name: pvc.Name,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: pvc.Name,
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:    containerName,
+							Image:   images.GetPVCCleanupJobImage(),
+							Command: []string{"/bin/sh"},
+							Args:    []string{"-c", script},
+							Env:     env,
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceMemory: pvcCleanupPodMemoryRequest,
+									corev1.ResourceCPU:    pvcCleanupPodCPURequest,
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceMemory: pvcCleanupPodMemoryLimit,
+									corev1.ResourceCPU:    pvcCleanupPodCPULimit,
+								},
+							},
+							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      pvc.Name,
+									MountPath: pvcClaimMountPath,
+								},
+							},
+						},
+					},
+					Affinity: &corev1.Affinity{},
+				},
+			},
+		},
+	}
+
+	if targetNode != "" {
+		job.Spec.Template.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      corev1.LabelHostname,
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{targetNode},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	podTolerations, nodeSelector, err := nsconfig.GetNamespacePodTolerationsAndNodeSelector(pvc.Namespace, clusterAPI)
+	if err != nil {
+		return nil, err
+	}
+	if len(podTolerations) > 0 {
+		job.Spec.Template.Spec.Tolerations = podTolerations
+	}
+	if len(nodeSelector) > 0 {
+		job.Spec.Template.Spec.NodeSelector = nodeSelector
+	}
+
+	return job, nil
+}
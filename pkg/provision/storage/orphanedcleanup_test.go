@@ -0,0 +1,127 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/infrastructure"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+func TestGetOrphanedStorageCleanupJob(t *testing.T) {
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+
+	namespace := "test-ns"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "claim-devworkspace",
+			Namespace: namespace,
+		},
+	}
+	runningPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-workspace-pod",
+			Namespace: namespace,
+			Labels: map[string]string{
+				constants.DevWorkspaceIDLabel: "workspace-a",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node",
+			Volumes: []corev1.Volume{{
+				Name: "claim-devworkspace",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "claim-devworkspace"},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+		runningPod,
+	).Build()
+
+	clusterAPI := sync.ClusterAPI{
+		Client: fakeClient,
+		Scheme: scheme,
+		Logger: zap.New(zap.UseDevMode(true)),
+		Ctx:    context.Background(),
+	}
+	config := &v1alpha1.OperatorConfiguration{
+		Workspace: &v1alpha1.WorkspaceConfig{
+			PVCName: "claim-devworkspace",
+		},
+	}
+
+	job, err := GetOrphanedStorageCleanupJob(pvc, []string{"workspace-b", "workspace-a"}, true, config, clusterAPI)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "cleanup-orphaned-claim-devworkspace", job.Name)
+	assert.Equal(t, namespace, job.Namespace)
+	assert.Equal(t, "true", job.Labels[constants.DevWorkspaceOrphanedStorageCleanupJobLabel])
+
+	container := job.Spec.Template.Spec.Containers[0]
+	env := map[string]string{}
+	for _, envVar := range container.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "workspace-a workspace-b", env["DEVWORKSPACE_IDS"], "Workspace IDs should be passed to job sorted")
+	assert.Equal(t, "true", env["DRY_RUN"])
+	assert.Equal(t, "claim-devworkspace", container.VolumeMounts[0].Name)
+	assert.Equal(t, "claim-devworkspace", job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+
+	nodeAffinity := job.Spec.Template.Spec.Affinity.NodeAffinity
+	if assert.NotNil(t, nodeAffinity, "Job should be scheduled on node where PVC is mounted") {
+		assert.Equal(t, []string{"test-node"}, nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+	}
+}
+
+func TestParseOrphanedStorageCleanupResult(t *testing.T) {
+	result, err := ParseOrphanedStorageCleanupResult("{\"orphanedDirectories\":2,\"reclaimedBytes\":4096}\n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, result.OrphanedDirectories)
+		assert.Equal(t, int64(4096), result.ReclaimedBytes)
+	}
+
+	_, err = ParseOrphanedStorageCleanupResult("not json")
+	assert.Error(t, err)
+}
+
+func TestIsCommonPVCName(t *testing.T) {
+	config := &v1alpha1.OperatorConfiguration{
+		Workspace: &v1alpha1.WorkspaceConfig{
+			PVCName: "custom-claim",
+		},
+	}
+	assert.True(t, IsCommonPVCName("custom-claim", config))
+	assert.True(t, IsCommonPVCName(constants.CheCommonPVCName, config))
+	assert.False(t, IsCommonPVCName("storage-workspace-a", config))
+}
@@ -177,8 +177,11 @@ func (p *PerWorkspaceStorageProvisioner) rewriteContainerVolumeMounts(
 	return nil
 }
 
-func getPVCSize(workspace *common.DevWorkspaceWithConfig, namespacedConfig *nsconfig.NamespacedConfig) (*resource.Quantity, error) {
+func getPVCSize(workspace *common.DevWorkspaceWithConfig, namespacedConfig *nsconfig.NamespacedConfig, profile *v1alpha1.StorageProfile) (*resource.Quantity, error) {
 	defaultPVCSize := *workspace.Config.Workspace.DefaultStorageSize.PerWorkspace
+	if profile != nil && profile.DefaultSize != nil {
+		defaultPVCSize = *profile.DefaultSize
+	}
 
 	// Calculate required PVC size based on workspace volumes
 	allVolumeSizesDefined := true
@@ -207,6 +210,10 @@ func getPVCSize(workspace *common.DevWorkspaceWithConfig, namespacedConfig *nsco
 		return requiredPVCSize, nil
 	}
 
+	if profile != nil && profile.DefaultSize != nil {
+		return &defaultPVCSize, nil
+	}
+
 	if namespacedConfig != nil && namespacedConfig.PerWorkspacePVCSize != "" {
 		pvcSize, err := resource.ParseQuantity(namespacedConfig.PerWorkspacePVCSize)
 		if err != nil {
@@ -224,22 +231,32 @@ func syncPerWorkspacePVC(workspace *common.DevWorkspaceWithConfig, clusterAPI sy
 		return nil, fmt.Errorf("failed to read namespace-specific configuration: %w", err)
 	}
 
-	pvcSize, err := getPVCSize(workspace, namespacedConfig)
+	profile, err := getStorageProfile(workspace, common.PerWorkspacePVCName(workspace.Status.DevWorkspaceId), clusterAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	pvcSize, err := getPVCSize(workspace, namespacedConfig, profile)
 	if err != nil {
 		return nil, err
 	}
 
-	storageClass := workspace.Config.Workspace.StorageClassName
-	pvc, err := getPVCSpec(common.PerWorkspacePVCName(workspace.Status.DevWorkspaceId), workspace.Namespace, storageClass, *pvcSize, workspace.Config.Workspace.StorageAccessMode)
+	storageClass := getProfileStorageClass(profile, workspace.Config)
+	pvc, err := getPVCSpec(common.PerWorkspacePVCName(workspace.Status.DevWorkspaceId), workspace.Namespace, storageClass, *pvcSize, getProfileAccessModes(profile, workspace.Config))
 	if err != nil {
 		return nil, err
 	}
+	applyStorageProfile(pvc, profile)
 	if pvc.Labels == nil {
 		pvc.Labels = map[string]string{}
 	}
 	pvc.Labels[constants.DevWorkspaceIDLabel] = workspace.Status.DevWorkspaceId
 	pvc.Labels[constants.DevWorkspacePVCTypeLabel] = constants.PerWorkspaceStorageClassType
 
+	if err := setCloneDataSource(pvc, workspace, clusterAPI); err != nil {
+		return nil, err
+	}
+
 	if err := controllerutil.SetControllerReference(workspace.DevWorkspace, pvc, clusterAPI.Scheme); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,130 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package storage
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/dwerrors"
+	nsconfig "github.com/devfile/devworkspace-operator/pkg/provision/config"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+// getStorageProfile returns the storage profile selected by the DevWorkspace's storage-profile attribute, or nil if
+// the attribute is not set. Returns a FailError if the profile is not defined in the workspace's config, or if the PVC
+// pvcName does not exist yet and the profile is not allowed in the DevWorkspace's namespace.
+//
+// The namespace's allowed storage profiles are only checked when the PVC is created, matching the webhook, which only
+// checks them when a DevWorkspace is created or its storage profile is changed. This way, modifying the allowed
+// storage profiles of a namespace does not fail DevWorkspaces whose storage was already provisioned.
+func getStorageProfile(workspace *common.DevWorkspaceWithConfig, pvcName string, clusterAPI sync.ClusterAPI) (*v1alpha1.StorageProfile, error) {
+	if !workspace.Spec.Template.Attributes.Exists(constants.StorageProfileAttribute) {
+		return nil, nil
+	}
+	var attrErr error
+	profileName := workspace.Spec.Template.Attributes.GetString(constants.StorageProfileAttribute, &attrErr)
+	if attrErr != nil {
+		return nil, &dwerrors.FailError{
+			Message: fmt.Sprintf("Failed to read attribute %s", constants.StorageProfileAttribute),
+			Err:     attrErr,
+		}
+	}
+
+	var profile *v1alpha1.StorageProfile
+	for idx, storageProfile := range workspace.Config.Workspace.StorageProfiles {
+		if storageProfile.Name == profileName {
+			profile = &workspace.Config.Workspace.StorageProfiles[idx]
+			break
+		}
+	}
+	if profile == nil {
+		return nil, &dwerrors.FailError{
+			Message: fmt.Sprintf("Storage profile %s is not defined in the DevWorkspace Operator configuration", profileName),
+		}
+	}
+
+	existingPVC := &corev1.PersistentVolumeClaim{}
+	err := clusterAPI.Client.Get(clusterAPI.Ctx, types.NamespacedName{Name: pvcName, Namespace: workspace.Namespace}, existingPVC)
+	if err == nil {
+		return profile, nil
+	} else if !k8sErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := clusterAPI.Client.Get(clusterAPI.Ctx, types.NamespacedName{Name: workspace.Namespace}, namespace); err != nil {
+		return nil, err
+	}
+	if !nsconfig.IsStorageProfileAllowed(namespace, profileName) {
+		return nil, &dwerrors.FailError{
+			Message: fmt.Sprintf("Storage profile %s is not allowed in namespace %s", profileName, workspace.Namespace),
+		}
+	}
+
+	return profile, nil
+}
+
+// getProfileStorageClass returns the storage class that should be used for PVCs created with a storage profile,
+// falling back to the global storage class if the profile does not define one.
+func getProfileStorageClass(profile *v1alpha1.StorageProfile, config *v1alpha1.OperatorConfiguration) *string {
+	if profile != nil && profile.StorageClassName != nil {
+		return profile.StorageClassName
+	}
+	return config.Workspace.StorageClassName
+}
+
+// getProfileAccessModes returns the access modes that should be used for PVCs created with a storage profile,
+// falling back to the global access modes if the profile does not define any.
+func getProfileAccessModes(profile *v1alpha1.StorageProfile, config *v1alpha1.OperatorConfiguration) []corev1.PersistentVolumeAccessMode {
+	if profile != nil && len(profile.StorageAccessMode) > 0 {
+		return profile.StorageAccessMode
+	}
+	return config.Workspace.StorageAccessMode
+}
+
+// applyStorageProfile sets the options of a storage profile that are not covered by getPVCSpec on a PVC spec.
+func applyStorageProfile(pvc *corev1.PersistentVolumeClaim, profile *v1alpha1.StorageProfile) {
+	if profile == nil {
+		return
+	}
+	if profile.VolumeMode != nil {
+		volumeMode := *profile.VolumeMode
+		pvc.Spec.VolumeMode = &volumeMode
+	}
+}
+
+// checkCommonPVCMatchesProfile verifies that a common PVC that already exists on the cluster is compatible with the
+// storage profile requested by a DevWorkspace. As PVCs cannot be modified after creation, a DevWorkspace that requests
+// a different storage class than the existing common PVC cannot be started using the common storage strategy.
+func checkCommonPVCMatchesProfile(pvc *corev1.PersistentVolumeClaim, profile *v1alpha1.StorageProfile) error {
+	if profile == nil || profile.StorageClassName == nil {
+		return nil
+	}
+	if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != *profile.StorageClassName {
+		return &dwerrors.FailError{
+			Message: fmt.Sprintf("Storage profile %s requires storage class %s, but the existing PVC %s uses storage class %s. Use the per-workspace storage type to select this profile",
+				profile.Name, *profile.StorageClassName, pvc.Name, *pvc.Spec.StorageClassName),
+		}
+	}
+	return nil
+}
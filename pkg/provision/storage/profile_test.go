@@ -0,0 +1,251 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/dwerrors"
+)
+
+func getProfileTestNamespace(allowedProfiles *string) *corev1.Namespace {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: cloneTestNamespace,
+		},
+	}
+	if allowedProfiles != nil {
+		namespace.Annotations = map[string]string{
+			constants.NamespaceAllowedStorageProfilesAnnotation: *allowedProfiles,
+		}
+	}
+	return namespace
+}
+
+func getProfileTestWorkspaceWithConfig(storageType, profileName string) *common.DevWorkspaceWithConfig {
+	attrs := map[string]string{
+		constants.DevWorkspaceStorageTypeAttribute: storageType,
+	}
+	if profileName != "" {
+		attrs[constants.StorageProfileAttribute] = profileName
+	}
+	fastSize := resource.MustParse("50Gi")
+	blockMode := corev1.PersistentVolumeBlock
+	workspace := getCloneTestWorkspace("profile", "profile-id", attrs)
+	workspace.Spec.Template.Components = []dw.Component{
+		{
+			Name:           "data",
+			ComponentUnion: dw.ComponentUnion{Volume: &dw.VolumeComponent{}},
+		},
+	}
+	return &common.DevWorkspaceWithConfig{
+		DevWorkspace: workspace,
+		Config: &v1alpha1.OperatorConfiguration{
+			Workspace: &v1alpha1.WorkspaceConfig{
+				PVCName:           "claim-devworkspace",
+				StorageClassName:  pointer.String("standard"),
+				StorageAccessMode: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				DefaultStorageSize: &v1alpha1.StorageSizes{
+					Common:       resource.NewQuantity(10*1024*1024*1024, resource.BinarySI),
+					PerWorkspace: resource.NewQuantity(5*1024*1024*1024, resource.BinarySI),
+				},
+				StorageProfiles: []v1alpha1.StorageProfile{
+					{
+						Name:              "fast",
+						StorageClassName:  pointer.String("fast-ssd"),
+						StorageAccessMode: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+						DefaultSize:       &fastSize,
+						VolumeMode:        &blockMode,
+					},
+					{
+						Name: "default-class",
+					},
+				},
+			},
+		},
+	}
+}
+
+// syncProfileTestPVC calls syncFn until the PVC is created on the cluster, as syncing a new object returns a RetryError
+func syncProfileTestPVC(syncFn func() (*corev1.PersistentVolumeClaim, error)) (*corev1.PersistentVolumeClaim, error) {
+	pvc, err := syncFn()
+	var retryErr *dwerrors.RetryError
+	if errors.As(err, &retryErr) {
+		return syncFn()
+	}
+	return pvc, err
+}
+
+func TestGetStorageProfile(t *testing.T) {
+	tests := []struct {
+		name            string
+		profile         string
+		allowedProfiles *string
+		existingPVC     bool
+		expectedProfile string
+		expectedErr     string
+	}{
+		{
+			name: "Returns nil when attribute is not set",
+		},
+		{
+			name:            "Returns profile when namespace does not restrict profiles",
+			profile:         "fast",
+			expectedProfile: "fast",
+		},
+		{
+			name:            "Returns profile when profile is in namespace allow-list",
+			profile:         "fast",
+			allowedProfiles: pointer.String("default-class, fast"),
+			expectedProfile: "fast",
+		},
+		{
+			name:            "Fails when profile is not in namespace allow-list",
+			profile:         "fast",
+			allowedProfiles: pointer.String("default-class"),
+			expectedErr:     "Storage profile fast is not allowed in namespace test-ns",
+		},
+		{
+			name:            "Returns profile not in namespace allow-list when PVC already exists",
+			profile:         "fast",
+			allowedProfiles: pointer.String("default-class"),
+			existingPVC:     true,
+			expectedProfile: "fast",
+		},
+		{
+			name:        "Fails when profile is not defined",
+			profile:     "unknown",
+			expectedErr: "Storage profile unknown is not defined in the DevWorkspace Operator configuration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspace := getProfileTestWorkspaceWithConfig(constants.PerWorkspaceStorageClassType, tt.profile)
+			pvcName := common.PerWorkspacePVCName(workspace.Status.DevWorkspaceId)
+			objs := []client.Object{getProfileTestNamespace(tt.allowedProfiles)}
+			if tt.existingPVC {
+				existingPVC, err := getPVCSpec(pvcName, cloneTestNamespace, pointer.String("fast-ssd"), resource.MustParse("50Gi"), nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+				objs = append(objs, existingPVC)
+			}
+			clusterAPI := getCloneTestClusterAPI(objs...)
+
+			profile, err := getStorageProfile(workspace, pvcName, clusterAPI)
+			if tt.expectedErr != "" {
+				var failErr *dwerrors.FailError
+				if assert.ErrorAs(t, err, &failErr) {
+					assert.Equal(t, tt.expectedErr, failErr.Message)
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			if tt.expectedProfile == "" {
+				assert.Nil(t, profile)
+			} else if assert.NotNil(t, profile) {
+				assert.Equal(t, tt.expectedProfile, profile.Name)
+			}
+		})
+	}
+}
+
+func TestPerWorkspacePVCUsesStorageProfile(t *testing.T) {
+	workspace := getProfileTestWorkspaceWithConfig(constants.PerWorkspaceStorageClassType, "fast")
+	clusterAPI := getCloneTestClusterAPI(getProfileTestNamespace(nil))
+
+	pvc, err := syncProfileTestPVC(func() (*corev1.PersistentVolumeClaim, error) {
+		return syncPerWorkspacePVC(workspace, clusterAPI)
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, pointer.String("fast-ssd"), pvc.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+	assert.Equal(t, corev1.PersistentVolumeBlock, *pvc.Spec.VolumeMode)
+	storageSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	assert.Equal(t, "50Gi", storageSize.String(), "Profile default size should take precedence over global default size")
+}
+
+func TestPerWorkspacePVCFallsBackToGlobalConfig(t *testing.T) {
+	workspace := getProfileTestWorkspaceWithConfig(constants.PerWorkspaceStorageClassType, "default-class")
+	clusterAPI := getCloneTestClusterAPI(getProfileTestNamespace(nil))
+
+	pvc, err := syncProfileTestPVC(func() (*corev1.PersistentVolumeClaim, error) {
+		return syncPerWorkspacePVC(workspace, clusterAPI)
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, pointer.String("standard"), pvc.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvc.Spec.AccessModes)
+	assert.Nil(t, pvc.Spec.VolumeMode)
+	storageSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	assert.Equal(t, "5Gi", storageSize.String())
+}
+
+func TestCommonPVCUsesStorageProfile(t *testing.T) {
+	workspace := getProfileTestWorkspaceWithConfig(constants.PerUserStorageClassType, "fast")
+	clusterAPI := getCloneTestClusterAPI(getProfileTestNamespace(nil))
+	profile, err := getStorageProfile(workspace, "claim-devworkspace", clusterAPI)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	pvc, err := syncProfileTestPVC(func() (*corev1.PersistentVolumeClaim, error) {
+		return syncCommonPVC(workspace.Namespace, workspace.Config, profile, clusterAPI)
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, pointer.String("fast-ssd"), pvc.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+	storageSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	assert.Equal(t, "50Gi", storageSize.String())
+}
+
+func TestCommonPVCWithDifferentStorageClassFails(t *testing.T) {
+	workspace := getProfileTestWorkspaceWithConfig(constants.PerUserStorageClassType, "fast")
+	existingPVC, err := getPVCSpec("claim-devworkspace", cloneTestNamespace, pointer.String("standard"), resource.MustParse("10Gi"), nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	clusterAPI := getCloneTestClusterAPI(getProfileTestNamespace(nil), existingPVC)
+	profile, err := getStorageProfile(workspace, "claim-devworkspace", clusterAPI)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = syncCommonPVC(workspace.Namespace, workspace.Config, profile, clusterAPI)
+	var failErr *dwerrors.FailError
+	if assert.ErrorAs(t, err, &failErr) {
+		assert.Contains(t, failErr.Message, "uses storage class standard")
+	}
+}
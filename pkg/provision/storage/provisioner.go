@@ -17,6 +17,7 @@ package storage
 
 import (
 	"errors"
+	"fmt"
 
 	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/devworkspace-operator/pkg/common"
@@ -29,6 +30,8 @@ import (
 // UnsupportedStorageStrategy is used when the controller is configured with an invalid storage strategy
 var UnsupportedStorageStrategy = errors.New("configured storage type not supported")
 
+var CloneUnsupportedStorageStrategy = fmt.Errorf("the %s attribute is only supported for the %s storage type", constants.CloneFromAttribute, constants.PerWorkspaceStorageClassType)
+
 // Provisioner is an interface for rewriting volumeMounts in a pod according to a storage policy (e.g. common PVC for all mounts, etc.)
 type Provisioner interface {
 	// ProvisionStorage rewrites the volumes and volumeMounts in podAdditions to match the current storage policy and syncs any
@@ -48,6 +51,9 @@ type Provisioner interface {
 // GetProvisioner returns the storage provisioner that should be used for the current workspace
 func GetProvisioner(workspace *common.DevWorkspaceWithConfig) (Provisioner, error) {
 	storageClass := workspace.Spec.Template.Attributes.GetString(constants.DevWorkspaceStorageTypeAttribute, nil)
+	if workspace.Spec.Template.Attributes.Exists(constants.CloneFromAttribute) && storageClass != constants.PerWorkspaceStorageClassType {
+		return nil, CloneUnsupportedStorageStrategy
+	}
 	if storageClass == "" {
 		return &CommonStorageProvisioner{}, nil
 	}
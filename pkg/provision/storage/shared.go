@@ -87,23 +87,26 @@ func isEphemeral(volume *dw.VolumeComponent) bool {
 	return volume.Ephemeral != nil && *volume.Ephemeral
 }
 
-func syncCommonPVC(namespace string, config *v1alpha1.OperatorConfiguration, clusterAPI sync.ClusterAPI) (*corev1.PersistentVolumeClaim, error) {
+func syncCommonPVC(namespace string, config *v1alpha1.OperatorConfiguration, profile *v1alpha1.StorageProfile, clusterAPI sync.ClusterAPI) (*corev1.PersistentVolumeClaim, error) {
 	namespacedConfig, err := nsconfig.ReadNamespacedConfig(namespace, clusterAPI)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read namespace-specific configuration: %w", err)
 	}
 	pvcSize := *config.Workspace.DefaultStorageSize.Common
-	if namespacedConfig != nil && namespacedConfig.CommonPVCSize != "" {
+	if profile != nil && profile.DefaultSize != nil {
+		pvcSize = *profile.DefaultSize
+	} else if namespacedConfig != nil && namespacedConfig.CommonPVCSize != "" {
 		pvcSize, err = resource.ParseQuantity(namespacedConfig.CommonPVCSize)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	pvc, err := getPVCSpec(config.Workspace.PVCName, namespace, config.Workspace.StorageClassName, pvcSize, config.Workspace.StorageAccessMode)
+	pvc, err := getPVCSpec(config.Workspace.PVCName, namespace, getProfileStorageClass(profile, config), pvcSize, getProfileAccessModes(profile, config))
 	if err != nil {
 		return nil, err
 	}
+	applyStorageProfile(pvc, profile)
 	if pvc.Labels == nil {
 		pvc.Labels = map[string]string{}
 	}
@@ -129,6 +132,9 @@ func syncCommonPVC(namespace string, config *v1alpha1.OperatorConfiguration, clu
 	if !ok {
 		return nil, errors.New("tried to sync common PVC to cluster but did not get a PVC back")
 	}
+	if err := checkCommonPVCMatchesProfile(currPVC, profile); err != nil {
+		return nil, err
+	}
 	// TODO: Does not work for WaitFirstConsumer storage type; needs to be improved.
 	// if currPVC.Status.Phase != corev1.ClaimBound {
 	// 	return nil, &NotReadyError{
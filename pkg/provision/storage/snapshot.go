@@ -0,0 +1,70 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package storage
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// VolumeSnapshots are managed as unstructured objects, as the external-snapshotter API is not a dependency of
+// the DevWorkspace Operator and the VolumeSnapshot CRDs are not guaranteed to be installed on the cluster.
+
+const (
+	VolumeSnapshotAPIGroup = "snapshot.storage.k8s.io"
+	VolumeSnapshotKind     = "VolumeSnapshot"
+)
+
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   VolumeSnapshotAPIGroup,
+	Version: "v1",
+	Kind:    VolumeSnapshotKind,
+}
+
+// NewVolumeSnapshot returns an empty unstructured VolumeSnapshot that can be used to read VolumeSnapshots from
+// the cluster.
+func NewVolumeSnapshot() *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	return snapshot
+}
+
+// GetVolumeSnapshotSpec returns a VolumeSnapshot of the PVC pvcName that uses the VolumeSnapshotClass
+// snapshotClassName. If snapshotClassName is empty, the cluster's default VolumeSnapshotClass is used.
+func GetVolumeSnapshotSpec(name, namespace, pvcName, snapshotClassName string, labels map[string]string) *unstructured.Unstructured {
+	snapshot := NewVolumeSnapshot()
+	snapshot.SetName(name)
+	snapshot.SetNamespace(namespace)
+	snapshot.SetLabels(labels)
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvcName,
+		},
+	}
+	if snapshotClassName != "" {
+		spec["volumeSnapshotClassName"] = snapshotClassName
+	}
+	snapshot.Object["spec"] = spec
+	return snapshot
+}
+
+// GetVolumeSnapshotStatus returns whether a VolumeSnapshot is ready to be used as a data source, and the error
+// message reported in the VolumeSnapshot's status, if any.
+func GetVolumeSnapshotStatus(snapshot *unstructured.Unstructured) (readyToUse bool, errMsg string) {
+	readyToUse, _, _ = unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	errMsg, _, _ = unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	return readyToUse, errMsg
+}
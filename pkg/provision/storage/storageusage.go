@@ -0,0 +1,191 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/common"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+// maxTerminationMessageSize is the maximum size of the termination message of a container, in bytes. Kubernetes
+// truncates longer messages.
+const maxTerminationMessageSize = 4096
+
+// storageUsageScript computes the size of the workspace directory of each DevWorkspace ID in DEVWORKSPACE_IDS.
+// As the result is written to the termination message of the container, the IDs passed to a single job are limited
+// by splitStorageUsageWorkspaceIds.
+const storageUsageScript = `
+set -e
+cd "$PVC_MOUNT_PATH"
+usage=""
+for id in $DEVWORKSPACE_IDS; do
+  [ -d "$id" ] || continue
+  size=$(du -sk "$id" 2>/dev/null | cut -f1)
+  usage="${usage:+$usage,}\"$id\":$((size * 1024))"
+done
+echo "{\"usage\":{$usage}}" > /dev/termination-log
+`
+
+// perWorkspaceStorageUsageScript computes the size of a per-workspace PVC and reports it for DEVWORKSPACE_ID in the
+// same format as storageUsageScript.
+const perWorkspaceStorageUsageScript = `
+set -e
+size=$(du -sk "$PVC_MOUNT_PATH" 2>/dev/null | cut -f1)
+echo "{\"usage\":{\"$DEVWORKSPACE_ID\":$((size * 1024))}}" > /dev/termination-log
+`
+
+// StorageUsageResult is the result reported by a storage usage job through its termination message.
+type StorageUsageResult struct {
+	// Usage maps DevWorkspace IDs to the size of their workspace directory in the common PVC, in bytes.
+	// DevWorkspaces that do not have a directory in the PVC are not included.
+	Usage map[string]int64 `json:"usage"`
+}
+
+// ParseStorageUsageResult parses the termination message of a storage usage job container.
+func ParseStorageUsageResult(message string) (*StorageUsageResult, error) {
+	result := &StorageUsageResult{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(message)), result); err != nil {
+		return nil, fmt.Errorf("failed to parse storage usage result: %w", err)
+	}
+	return result, nil
+}
+
+// GetStorageUsageJobs returns the jobs that compute the size of the workspace directories of workspaceIds in the
+// common PVC. As the result of a job is written to the container's termination message, which is limited in size,
+// workspaceIds are split across as many jobs as required to report the usage of every workspace directory. All jobs
+// have the same name and must be run one after another. The result of each job can be read using
+// ParseStorageUsageResult.
+func GetStorageUsageJobs(pvc *corev1.PersistentVolumeClaim, workspaceIds []string, config *v1alpha1.OperatorConfiguration, clusterAPI sync.ClusterAPI) ([]*batchv1.Job, error) {
+	var jobs []*batchv1.Job
+	for _, batch := range splitStorageUsageWorkspaceIds(workspaceIds) {
+		env := []corev1.EnvVar{
+			{Name: "PVC_MOUNT_PATH", Value: pvcClaimMountPath},
+			{Name: "DEVWORKSPACE_IDS", Value: strings.Join(batch, " ")},
+		}
+		job, err := getCommonPVCScriptJob(pvc, common.StorageUsageJobName(pvc.Name), constants.DevWorkspaceStorageUsageJobLabel,
+			"storage-usage", storageUsageScript, env, config, clusterAPI)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// splitStorageUsageWorkspaceIds sorts workspaceIds and splits them into batches for which the result of
+// storageUsageScript is guaranteed to fit in the termination message of a container, assuming the largest possible
+// usage for each workspace directory.
+func splitStorageUsageWorkspaceIds(workspaceIds []string) [][]string {
+	sortedIds := make([]string, len(workspaceIds))
+	copy(sortedIds, workspaceIds)
+	sort.Strings(sortedIds)
+
+	// The result has the form {"usage":{"<id>":<bytes>,...}} followed by a newline
+	const resultOverhead = len(`{"usage":{}}`) + 1
+	maxEntrySize := func(workspaceId string) int {
+		return len(`"":,`) + len(workspaceId) + len(strconv.FormatInt(math.MaxInt64, 10))
+	}
+
+	var batches [][]string
+	var batch []string
+	batchSize := resultOverhead
+	for _, workspaceId := range sortedIds {
+		entrySize := maxEntrySize(workspaceId)
+		if len(batch) > 0 && batchSize+entrySize > maxTerminationMessageSize {
+			batches = append(batches, batch)
+			batch, batchSize = nil, resultOverhead
+		}
+		batch = append(batch, workspaceId)
+		batchSize += entrySize
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// GetPerWorkspaceStorageUsageJob returns a job that computes the size of the per-workspace PVC of the DevWorkspace
+// with ID workspaceId. The job is scheduled on the node the PVC is mounted on, if any, so that the usage of PVCs with
+// the ReadWriteOnce access mode can be computed while the DevWorkspace is running. The result of the job can be read
+// using ParseStorageUsageResult.
+func GetPerWorkspaceStorageUsageJob(pvc *corev1.PersistentVolumeClaim, workspaceId string, config *v1alpha1.OperatorConfiguration, clusterAPI sync.ClusterAPI) (*batchv1.Job, error) {
+	env := []corev1.EnvVar{
+		{Name: "PVC_MOUNT_PATH", Value: pvcClaimMountPath},
+		{Name: "DEVWORKSPACE_ID", Value: workspaceId},
+	}
+	return getCommonPVCScriptJob(pvc, common.StorageUsageJobName(pvc.Name), constants.DevWorkspaceStorageUsageJobLabel,
+		"storage-usage", perWorkspaceStorageUsageScript, env, config, clusterAPI)
+}
+
+// nodeStatsSummary is the subset of the kubelet stats summary API (/stats/summary) that is required to
+// read the usage of volumes.
+type nodeStatsSummary struct {
+	Pods []struct {
+		Volumes []struct {
+			UsedBytes *uint64 `json:"usedBytes,omitempty"`
+			PVCRef    *struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"pvcRef,omitempty"`
+		} `json:"volume,omitempty"`
+	} `json:"pods"`
+}
+
+// ParsePVCUsageFromNodeStatsSummary returns the used bytes of each PVC mounted by a pod on a node, as reported
+// by the kubelet stats summary of that node.
+func ParsePVCUsageFromNodeStatsSummary(data []byte) (map[types.NamespacedName]int64, error) {
+	summary := &nodeStatsSummary{}
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, fmt.Errorf("failed to parse node stats summary: %w", err)
+	}
+	usage := map[types.NamespacedName]int64{}
+	for _, pod := range summary.Pods {
+		for _, volume := range pod.Volumes {
+			if volume.PVCRef == nil || volume.UsedBytes == nil {
+				continue
+			}
+			usage[types.NamespacedName{Name: volume.PVCRef.Name, Namespace: volume.PVCRef.Namespace}] = int64(*volume.UsedBytes)
+		}
+	}
+	return usage, nil
+}
+
+// FormatStorageUsage returns a human-readable representation of a size in bytes, e.g. "1.5 GiB".
+func FormatStorageUsage(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit && exp < 4; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTP"[exp])
+}
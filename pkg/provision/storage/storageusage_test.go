@@ -0,0 +1,231 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	"github.com/devfile/devworkspace-operator/pkg/infrastructure"
+	"github.com/devfile/devworkspace-operator/pkg/provision/sync"
+)
+
+func TestGetStorageUsageJobs(t *testing.T) {
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+
+	namespace := "test-ns"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "claim-devworkspace",
+			Namespace: namespace,
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+	).Build()
+	clusterAPI := sync.ClusterAPI{
+		Client: fakeClient,
+		Scheme: scheme,
+		Logger: zap.New(zap.UseDevMode(true)),
+		Ctx:    context.Background(),
+	}
+	config := &v1alpha1.OperatorConfiguration{
+		Workspace: &v1alpha1.WorkspaceConfig{
+			PVCName: "claim-devworkspace",
+		},
+	}
+
+	jobs, err := GetStorageUsageJobs(pvc, []string{"workspace-b", "workspace-a"}, config, clusterAPI)
+	if !assert.NoError(t, err) || !assert.Len(t, jobs, 1) {
+		return
+	}
+	job := jobs[0]
+
+	assert.Equal(t, "storage-usage-claim-devworkspace", job.Name)
+	assert.Equal(t, namespace, job.Namespace)
+	assert.Equal(t, "true", job.Labels[constants.DevWorkspaceStorageUsageJobLabel])
+	assert.Equal(t, "true", job.Spec.Template.Labels[constants.DevWorkspaceStorageUsageJobLabel])
+
+	container := job.Spec.Template.Spec.Containers[0]
+	env := map[string]string{}
+	for _, envVar := range container.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "workspace-a workspace-b", env["DEVWORKSPACE_IDS"], "Workspace IDs should be passed to job sorted")
+	assert.Equal(t, "claim-devworkspace", job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Nil(t, job.Spec.Template.Spec.Affinity.NodeAffinity, "Job should not be pinned to a node if PVC is not mounted")
+}
+
+func TestGetStorageUsageJobsSplitsWorkspaceIds(t *testing.T) {
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+
+	namespace := "test-ns"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "claim-devworkspace",
+			Namespace: namespace,
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+	).Build()
+	clusterAPI := sync.ClusterAPI{
+		Client: fakeClient,
+		Scheme: scheme,
+		Logger: zap.New(zap.UseDevMode(true)),
+		Ctx:    context.Background(),
+	}
+	config := &v1alpha1.OperatorConfiguration{
+		Workspace: &v1alpha1.WorkspaceConfig{
+			PVCName: "claim-devworkspace",
+		},
+	}
+
+	var workspaceIds []string
+	for i := 0; i < 200; i++ {
+		workspaceIds = append(workspaceIds, fmt.Sprintf("workspace%016x", i))
+	}
+
+	jobs, err := GetStorageUsageJobs(pvc, workspaceIds, config, clusterAPI)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Greater(t, len(jobs), 1, "Workspace IDs should be split across multiple jobs")
+
+	var jobWorkspaceIds []string
+	for _, job := range jobs {
+		assert.Equal(t, "storage-usage-claim-devworkspace", job.Name)
+		var ids []string
+		for _, envVar := range job.Spec.Template.Spec.Containers[0].Env {
+			if envVar.Name == "DEVWORKSPACE_IDS" {
+				ids = strings.Split(envVar.Value, " ")
+			}
+		}
+		jobWorkspaceIds = append(jobWorkspaceIds, ids...)
+
+		// Result reported by the job if every workspace directory used the largest possible amount of storage
+		result := &StorageUsageResult{Usage: map[string]int64{}}
+		for _, id := range ids {
+			result.Usage[id] = math.MaxInt64
+		}
+		message, err := json.Marshal(result)
+		if assert.NoError(t, err) {
+			assert.LessOrEqual(t, len(message)+1, maxTerminationMessageSize, "Result of job should fit in termination message")
+		}
+	}
+	assert.Equal(t, workspaceIds, jobWorkspaceIds, "Every workspace ID should be passed to exactly one job")
+}
+
+func TestGetPerWorkspaceStorageUsageJob(t *testing.T) {
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+
+	namespace := "test-ns"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "storage-workspace-a",
+			Namespace: namespace,
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+	).Build()
+	clusterAPI := sync.ClusterAPI{
+		Client: fakeClient,
+		Scheme: scheme,
+		Logger: zap.New(zap.UseDevMode(true)),
+		Ctx:    context.Background(),
+	}
+	config := &v1alpha1.OperatorConfiguration{
+		Workspace: &v1alpha1.WorkspaceConfig{},
+	}
+
+	job, err := GetPerWorkspaceStorageUsageJob(pvc, "workspace-a", config, clusterAPI)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "storage-usage-storage-workspace-a", job.Name)
+	assert.Equal(t, "true", job.Labels[constants.DevWorkspaceStorageUsageJobLabel])
+	container := job.Spec.Template.Spec.Containers[0]
+	env := map[string]string{}
+	for _, envVar := range container.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "workspace-a", env["DEVWORKSPACE_ID"])
+	assert.Equal(t, "storage-workspace-a", job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+}
+
+func TestParseStorageUsageResult(t *testing.T) {
+	result, err := ParseStorageUsageResult("{\"usage\":{\"workspace-a\":4096,\"workspace-b\":1048576}}\n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]int64{"workspace-a": 4096, "workspace-b": 1048576}, result.Usage)
+	}
+
+	result, err = ParseStorageUsageResult("{\"usage\":{}}")
+	if assert.NoError(t, err) {
+		assert.Empty(t, result.Usage)
+	}
+
+	_, err = ParseStorageUsageResult("not json")
+	assert.Error(t, err)
+}
+
+func TestParsePVCUsageFromNodeStatsSummary(t *testing.T) {
+	summary := `{
+  "node": {"nodeName": "test-node"},
+  "pods": [
+    {
+      "podRef": {"name": "workspace-pod", "namespace": "test-ns"},
+      "volume": [
+        {"name": "claim-devworkspace", "usedBytes": 2048, "pvcRef": {"name": "storage-workspace-a", "namespace": "test-ns"}},
+        {"name": "kube-api-access", "usedBytes": 12}
+      ]
+    },
+    {
+      "podRef": {"name": "other-pod", "namespace": "other-ns"}
+    }
+  ]
+}`
+	usage, err := ParsePVCUsageFromNodeStatsSummary([]byte(summary))
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[types.NamespacedName]int64{
+			{Name: "storage-workspace-a", Namespace: "test-ns"}: 2048,
+		}, usage)
+	}
+
+	_, err = ParsePVCUsageFromNodeStatsSummary([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestFormatStorageUsage(t *testing.T) {
+	assert.Equal(t, "512 B", FormatStorageUsage(512))
+	assert.Equal(t, "1.0 KiB", FormatStorageUsage(1024))
+	assert.Equal(t, "1.5 MiB", FormatStorageUsage(1536*1024))
+	assert.Equal(t, "2.0 GiB", FormatStorageUsage(2*1024*1024*1024))
+}
@@ -17,12 +17,15 @@ package secrets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
 	"github.com/devfile/devworkspace-operator/pkg/constants"
 	"github.com/devfile/devworkspace-operator/pkg/infrastructure"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/encryption"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +34,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ErrSharedSymmetricEncryptionKey is returned when the encryption key secret shared by all namespaces contains a
+// symmetric key, which is not copied to workspace namespaces as it would allow decrypting the backups of all namespaces.
+var ErrSharedSymmetricEncryptionKey = errors.New("shared encryption key secret contains a symmetric key")
+
 // GetRegistryAuthSecret retrieves the registry authentication secret for accessing backup images
 // based on the operator configuration.
 func GetNamespaceRegistryAuthSecret(ctx context.Context, c client.Client, workspace *dw.DevWorkspace,
@@ -44,9 +51,13 @@ func HandleRegistryAuthSecret(ctx context.Context, c client.Client, workspace *d
 ) (*corev1.Secret, error) {
 	if dwOperatorConfig.Workspace == nil ||
 		dwOperatorConfig.Workspace.BackupCronJob == nil ||
-		dwOperatorConfig.Workspace.BackupCronJob.Registry == nil {
+		(dwOperatorConfig.Workspace.BackupCronJob.Registry == nil && dwOperatorConfig.Workspace.BackupCronJob.S3 == nil) {
 		return nil, fmt.Errorf("backup/restore configuration not properly set in DevWorkspaceOperatorConfig")
 	}
+	if dwOperatorConfig.Workspace.BackupCronJob.Registry == nil {
+		// Backups are stored in S3-compatible object storage, see HandleS3CredentialsSecret
+		return nil, nil
+	}
 
 	registryAuthSecret := &corev1.Secret{}
 	err := c.Get(ctx, client.ObjectKey{
@@ -98,13 +109,166 @@ func HandleRegistryAuthSecret(ctx context.Context, c client.Client, workspace *d
 	return CopySecret(ctx, c, workspace, registryAuthSecret, scheme, log)
 }
 
+// GetNamespaceS3CredentialsSecret retrieves the secret with the credentials used to access S3-compatible object
+// storage for restoring backups, based on the operator configuration.
+func GetNamespaceS3CredentialsSecret(ctx context.Context, c client.Client, workspace *dw.DevWorkspace,
+	dwOperatorConfig *controllerv1alpha1.OperatorConfiguration, log logr.Logger,
+) (*corev1.Secret, error) {
+	return HandleS3CredentialsSecret(ctx, c, workspace, dwOperatorConfig, "", log)
+}
+
+// HandleS3CredentialsSecret returns the secret with the credentials used to access S3-compatible object storage
+// in the workspace namespace. If the secret does not exist in the workspace namespace, it is copied from the operator
+// namespace: the secret named "<credentialsSecret>-<workspace namespace>" is preferred, as its credentials can be
+// limited to the backups of the namespace, and the secret configured in the operator configuration is used otherwise.
+// Returns nil if backups are not stored in S3-compatible object storage or if no credentials are configured.
+func HandleS3CredentialsSecret(ctx context.Context, c client.Client, workspace *dw.DevWorkspace,
+	dwOperatorConfig *controllerv1alpha1.OperatorConfiguration, operatorConfigNamespace string, log logr.Logger,
+) (*corev1.Secret, error) {
+	if dwOperatorConfig.Workspace == nil || dwOperatorConfig.Workspace.BackupCronJob == nil ||
+		dwOperatorConfig.Workspace.BackupCronJob.S3 == nil || dwOperatorConfig.Workspace.BackupCronJob.S3.CredentialsSecret == "" {
+		return nil, nil
+	}
+
+	credentialsSecret := dwOperatorConfig.Workspace.BackupCronJob.S3.CredentialsSecret
+	return handleBackupSecret(ctx, c, workspace,
+		[]string{GetNamespaceS3CredentialsSecretName(credentialsSecret, workspace.Namespace), credentialsSecret},
+		constants.DevWorkspaceBackupS3CredentialsSecretName, operatorConfigNamespace, nil, log)
+}
+
+// GetNamespaceS3CredentialsSecretName returns the name of the secret in the operator namespace with the credentials
+// used to access S3-compatible object storage for the DevWorkspaces in namespace.
+func GetNamespaceS3CredentialsSecretName(credentialsSecret, namespace string) string {
+	return credentialsSecret + "-" + namespace
+}
+
+// GetNamespaceEncryptionKeySecret retrieves the secret with the key used to decrypt backups when restoring them, based
+// on the operator configuration.
+func GetNamespaceEncryptionKeySecret(ctx context.Context, c client.Client, workspace *dw.DevWorkspace,
+	dwOperatorConfig *controllerv1alpha1.OperatorConfiguration, log logr.Logger,
+) (*corev1.Secret, error) {
+	return HandleEncryptionKeySecret(ctx, c, workspace, dwOperatorConfig, "", log)
+}
+
+// HandleEncryptionKeySecret returns the secret with the key used to encrypt and decrypt backups in the workspace
+// namespace. If the secret does not exist in the workspace namespace, it is copied from the operator namespace: the
+// secret named "<keySecret>-<workspace namespace>" is copied as is, as its key is only used for the backups of the
+// namespace. Otherwise, only the public key of the secret configured in the operator configuration is copied, as its
+// private or symmetric key would allow decrypting the backups of all namespaces. Returns nil if no encryption key is
+// configured.
+func HandleEncryptionKeySecret(ctx context.Context, c client.Client, workspace *dw.DevWorkspace,
+	dwOperatorConfig *controllerv1alpha1.OperatorConfiguration, operatorConfigNamespace string, log logr.Logger,
+) (*corev1.Secret, error) {
+	if dwOperatorConfig.Workspace == nil || dwOperatorConfig.Workspace.BackupCronJob == nil ||
+		dwOperatorConfig.Workspace.BackupCronJob.Encryption == nil || dwOperatorConfig.Workspace.BackupCronJob.Encryption.KeySecret == "" {
+		return nil, nil
+	}
+	keySecret := dwOperatorConfig.Workspace.BackupCronJob.Encryption.KeySecret
+	namespaceKeySecret := GetNamespaceEncryptionKeySecretName(keySecret, workspace.Namespace)
+	sharedData := func(secret *corev1.Secret) (map[string][]byte, error) {
+		return getSharedEncryptionKeyData(secret, namespaceKeySecret)
+	}
+	return handleBackupSecret(ctx, c, workspace, []string{namespaceKeySecret, keySecret},
+		constants.DevWorkspaceBackupEncryptionKeySecretName, operatorConfigNamespace, sharedData, log)
+}
+
+// GetNamespaceEncryptionKeySecretName returns the name of the secret in the operator namespace with the key used to
+// encrypt and decrypt the backups of the DevWorkspaces in namespace.
+func GetNamespaceEncryptionKeySecretName(keySecret, namespace string) string {
+	return keySecret + "-" + namespace
+}
+
+// getSharedEncryptionKeyData returns the data of an encryption key secret shared by all namespaces that is copied to
+// workspace namespaces. Only the public key is copied; if the secret does not contain it, it is derived from the private
+// key. A symmetric key cannot be shared, as it decrypts the backups of all namespaces: namespaceKeySecret, which is
+// copied as is, must be used instead.
+func getSharedEncryptionKeyData(secret *corev1.Secret, namespaceKeySecret string) (map[string][]byte, error) {
+	if key := secret.Data[backup.EncryptionKeySecretKey]; len(key) > 0 {
+		return nil, fmt.Errorf("%w %s: create secret %s with a key for the namespace, or use a key pair",
+			ErrSharedSymmetricEncryptionKey, secret.Name, namespaceKeySecret)
+	}
+	data := map[string][]byte{}
+	if publicKey := secret.Data[backup.EncryptionPublicKeySecretKey]; len(publicKey) > 0 {
+		data[backup.EncryptionPublicKeySecretKey] = publicKey
+	} else if privateKey := secret.Data[backup.EncryptionPrivateKeySecretKey]; len(privateKey) > 0 {
+		publicKey, err := encryption.PublicKeyFromPrivateKey(string(privateKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key secret %s: %w", secret.Name, err)
+		}
+		data[backup.EncryptionPublicKeySecretKey] = []byte(publicKey)
+	}
+	return data, nil
+}
+
+// handleBackupSecret returns the secret named namespaceSecretName in the workspace namespace. If it does not exist,
+// the first secret in secretNames that exists in the operator namespace is copied. If none of the secrets exist, the
+// error returned when reading the last one is returned. The last secret in secretNames is shared by all namespaces;
+// if sharedData is not nil, only the data it returns is copied from that secret.
+func handleBackupSecret(ctx context.Context, c client.Client, workspace *dw.DevWorkspace,
+	secretNames []string, namespaceSecretName, operatorConfigNamespace string,
+	sharedData func(*corev1.Secret) (map[string][]byte, error), log logr.Logger,
+) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, client.ObjectKey{
+		Name:      namespaceSecretName,
+		Namespace: workspace.Namespace}, secret)
+	if err == nil {
+		return secret, nil
+	}
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+
+	if operatorConfigNamespace == "" {
+		resolvedNS, nsErr := infrastructure.GetNamespace()
+		if nsErr != nil {
+			return nil, fmt.Errorf("cannot resolve operator namespace to copy secret %s: %w", secretNames[len(secretNames)-1], nsErr)
+		}
+		operatorConfigNamespace = resolvedNS
+	}
+
+	for idx, secretName := range secretNames {
+		err = c.Get(ctx, client.ObjectKey{
+			Name:      secretName,
+			Namespace: operatorConfigNamespace}, secret)
+		if k8sErrors.IsNotFound(err) && idx < len(secretNames)-1 {
+			continue
+		}
+		if err != nil {
+			log.Error(err, "Failed to get secret for backup",
+				"secretName", secretName,
+				"namespace", operatorConfigNamespace)
+			return nil, err
+		}
+		if idx > 0 {
+			log.Info("Namespace-specific secret for backup not found, copying shared secret to workspace namespace",
+				"secretName", secretName,
+				"namespaceSecretName", secretNames[0],
+				"namespace", workspace.Namespace)
+		}
+		if idx == len(secretNames)-1 && sharedData != nil {
+			data, err := sharedData(secret)
+			if err != nil {
+				return nil, err
+			}
+			secret = &corev1.Secret{ObjectMeta: secret.ObjectMeta, Type: secret.Type, Data: data}
+		}
+		break
+	}
+	return copySecret(ctx, c, workspace, secret, namespaceSecretName, log)
+}
+
 // CopySecret copies the given secret from the operator namespace to the workspace namespace.
 // It NEVER overwrites an existing secret: if a secret already exists in the workspace namespace,
 // it returns the existing secret without modification.
 func CopySecret(ctx context.Context, c client.Client, workspace *dw.DevWorkspace, sourceSecret *corev1.Secret, scheme *runtime.Scheme, log logr.Logger) (namespaceSecret *corev1.Secret, err error) {
+	return copySecret(ctx, c, workspace, sourceSecret, constants.DevWorkspaceBackupAuthSecretName, log)
+}
+
+func copySecret(ctx context.Context, c client.Client, workspace *dw.DevWorkspace, sourceSecret *corev1.Secret, name string, log logr.Logger) (*corev1.Secret, error) {
 	desiredSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      constants.DevWorkspaceBackupAuthSecretName,
+			Name:      name,
 			Namespace: workspace.Namespace,
 			Labels: map[string]string{
 				constants.DevWorkspaceWatchSecretLabel: "true",
@@ -114,25 +278,25 @@ func CopySecret(ctx context.Context, c client.Client, workspace *dw.DevWorkspace
 		Type: sourceSecret.Type,
 	}
 
-	err = c.Create(ctx, desiredSecret)
+	err := c.Create(ctx, desiredSecret)
 	if err != nil {
 		if k8sErrors.IsAlreadyExists(err) {
 			// Race condition - secret was created between Get and Create
 			// Fetch and return it (respect what's there)
 			if err := c.Get(ctx, client.ObjectKey{
-				Name:      constants.DevWorkspaceBackupAuthSecretName,
+				Name:      name,
 				Namespace: workspace.Namespace,
 			}, sourceSecret); err != nil {
 				return nil, err
 			}
-			log.Info("Registry auth secret was created concurrently, using existing secret",
-				"secretName", constants.DevWorkspaceBackupAuthSecretName)
+			log.Info("Secret was created concurrently, using existing secret",
+				"secretName", name)
 			return sourceSecret, nil
 		}
 		return nil, err
 	}
 
-	log.Info("Successfully copied registry auth secret to workspace namespace",
+	log.Info("Successfully copied secret to workspace namespace",
 		"name", desiredSecret.Name, "namespace", workspace.Namespace)
 	return desiredSecret, nil
 }
@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"filippo.io/age"
 	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	controllerv1alpha1 "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -408,3 +409,257 @@ var _ = Describe("CopySecret", func() {
 		Expect(result.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
 	})
 })
+
+var _ = Describe("HandleS3CredentialsSecret", func() {
+	const (
+		workspaceNS = "user-namespace"
+		operatorNS  = "devworkspace-controller"
+	)
+
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		log    = zap.New(zap.UseDevMode(true)).WithName("SecretsTest")
+	)
+
+	makeS3Config := func(credentialsSecret string) *controllerv1alpha1.OperatorConfiguration {
+		return &controllerv1alpha1.OperatorConfiguration{
+			Workspace: &controllerv1alpha1.WorkspaceConfig{
+				BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+					S3: &controllerv1alpha1.S3Config{
+						Endpoint:          "https://s3.example.com",
+						Bucket:            "backups",
+						CredentialsSecret: credentialsSecret,
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = buildScheme()
+	})
+
+	It("returns nil when no credentials secret is configured", func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		result, err := secrets.HandleS3CredentialsSecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeS3Config(""), operatorNS, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("copies the credentials secret from the operator namespace", func() {
+		operatorSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: operatorNS},
+			Data: map[string][]byte{
+				"accessKeyId":     []byte("access-key"),
+				"secretAccessKey": []byte("secret-key"),
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(operatorSecret).Build()
+
+		result, err := secrets.HandleS3CredentialsSecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeS3Config("s3-credentials"), operatorNS, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.Name).To(Equal(constants.DevWorkspaceBackupS3CredentialsSecretName))
+		Expect(result.Namespace).To(Equal(workspaceNS))
+
+		copied := &corev1.Secret{}
+		err = fakeClient.Get(ctx, client.ObjectKey{
+			Name:      constants.DevWorkspaceBackupS3CredentialsSecretName,
+			Namespace: workspaceNS,
+		}, copied)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(copied.Data).To(HaveKeyWithValue("accessKeyId", []byte("access-key")))
+		Expect(copied.Labels).To(HaveKeyWithValue(constants.DevWorkspaceWatchSecretLabel, "true"))
+	})
+
+	It("prefers the namespace-specific credentials secret in the operator namespace", func() {
+		sharedSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: operatorNS},
+			Data:       map[string][]byte{"accessKeyId": []byte("shared-key")},
+		}
+		namespaceSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials-" + workspaceNS, Namespace: operatorNS},
+			Data:       map[string][]byte{"accessKeyId": []byte("namespace-key")},
+		}
+		otherNamespaceSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials-other-namespace", Namespace: operatorNS},
+			Data:       map[string][]byte{"accessKeyId": []byte("other-key")},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sharedSecret, namespaceSecret, otherNamespaceSecret).Build()
+
+		result, err := secrets.HandleS3CredentialsSecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeS3Config("s3-credentials"), operatorNS, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.Name).To(Equal(constants.DevWorkspaceBackupS3CredentialsSecretName))
+		Expect(result.Data["accessKeyId"]).To(Equal([]byte("namespace-key")))
+	})
+
+	It("returns an error when no credentials secret exists", func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		_, err := secrets.HandleS3CredentialsSecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeS3Config("s3-credentials"), operatorNS, log)
+		Expect(k8sErrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("prefers the credentials secret in the workspace namespace", func() {
+		userSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.DevWorkspaceBackupS3CredentialsSecretName, Namespace: workspaceNS},
+			Data:       map[string][]byte{"accessKeyId": []byte("user-key")},
+		}
+		operatorSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: operatorNS},
+			Data:       map[string][]byte{"accessKeyId": []byte("operator-key")},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(userSecret, operatorSecret).Build()
+
+		result, err := secrets.HandleS3CredentialsSecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeS3Config("s3-credentials"), operatorNS, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.Data["accessKeyId"]).To(Equal([]byte("user-key")))
+	})
+
+	It("does not require a registry auth secret when only S3 is configured", func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		result, err := secrets.HandleRegistryAuthSecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeS3Config("s3-credentials"), operatorNS, scheme, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+})
+
+var _ = Describe("HandleEncryptionKeySecret", func() {
+	const (
+		workspaceNS = "user-namespace"
+		operatorNS  = "devworkspace-controller"
+	)
+
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		log    = zap.New(zap.UseDevMode(true)).WithName("SecretsTest")
+	)
+
+	makeEncryptionConfig := func(keySecret string) *controllerv1alpha1.OperatorConfiguration {
+		return &controllerv1alpha1.OperatorConfiguration{
+			Workspace: &controllerv1alpha1.WorkspaceConfig{
+				BackupCronJob: &controllerv1alpha1.BackupCronJobConfig{
+					Registry:   &controllerv1alpha1.RegistryConfig{Path: "example.registry.io/org"},
+					Encryption: &controllerv1alpha1.BackupEncryptionConfig{KeySecret: keySecret},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = buildScheme()
+	})
+
+	It("returns nil when no encryption key is configured", func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		result, err := secrets.HandleEncryptionKeySecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeEncryptionConfig(""), operatorNS, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("copies the namespace-specific symmetric encryption key secret from the operator namespace", func() {
+		operatorSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-key-" + workspaceNS, Namespace: operatorNS},
+			Data:       map[string][]byte{"key": []byte("c2VjcmV0")},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(operatorSecret).Build()
+
+		result, err := secrets.HandleEncryptionKeySecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeEncryptionConfig("backup-key"), operatorNS, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.Name).To(Equal(constants.DevWorkspaceBackupEncryptionKeySecretName))
+		Expect(result.Namespace).To(Equal(workspaceNS))
+		Expect(result.Data).To(HaveKeyWithValue("key", []byte("c2VjcmV0")))
+	})
+
+	It("does not copy the symmetric key of the shared encryption key secret", func() {
+		operatorSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-key", Namespace: operatorNS},
+			Data:       map[string][]byte{"key": []byte("c2VjcmV0")},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(operatorSecret).Build()
+
+		result, err := secrets.HandleEncryptionKeySecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeEncryptionConfig("backup-key"), operatorNS, log)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("backup-key-" + workspaceNS))
+		Expect(result).To(BeNil())
+
+		copied := &corev1.Secret{}
+		err = fakeClient.Get(ctx, client.ObjectKey{Name: constants.DevWorkspaceBackupEncryptionKeySecretName, Namespace: workspaceNS}, copied)
+		Expect(k8sErrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("does not copy the private key of the shared encryption key secret", func() {
+		identity, err := age.GenerateX25519Identity()
+		Expect(err).NotTo(HaveOccurred())
+		operatorSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-key", Namespace: operatorNS},
+			Data: map[string][]byte{
+				"publicKey":  []byte(identity.Recipient().String()),
+				"privateKey": []byte(identity.String()),
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(operatorSecret).Build()
+
+		result, err := secrets.HandleEncryptionKeySecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeEncryptionConfig("backup-key"), operatorNS, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.Data).To(HaveKeyWithValue("publicKey", []byte(identity.Recipient().String())))
+		Expect(result.Data).NotTo(HaveKey("privateKey"))
+	})
+
+	It("derives the public key when the shared encryption key secret only contains the private key", func() {
+		identity, err := age.GenerateX25519Identity()
+		Expect(err).NotTo(HaveOccurred())
+		operatorSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-key", Namespace: operatorNS},
+			Data:       map[string][]byte{"privateKey": []byte(identity.String())},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(operatorSecret).Build()
+
+		result, err := secrets.HandleEncryptionKeySecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeEncryptionConfig("backup-key"), operatorNS, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.Data).To(HaveKeyWithValue("publicKey", []byte(identity.Recipient().String())))
+		Expect(result.Data).NotTo(HaveKey("privateKey"))
+	})
+
+	It("prefers the namespace-specific encryption key secret and copies it as is", func() {
+		identity, err := age.GenerateX25519Identity()
+		Expect(err).NotTo(HaveOccurred())
+		sharedSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-key", Namespace: operatorNS},
+			Data:       map[string][]byte{"key": []byte("c2hhcmVk")},
+		}
+		namespaceSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-key-" + workspaceNS, Namespace: operatorNS},
+			Data: map[string][]byte{
+				"publicKey":  []byte(identity.Recipient().String()),
+				"privateKey": []byte(identity.String()),
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sharedSecret, namespaceSecret).Build()
+
+		result, err := secrets.HandleEncryptionKeySecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeEncryptionConfig("backup-key"), operatorNS, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.Data).To(Equal(namespaceSecret.Data))
+	})
+
+	It("returns error when the encryption key secret is not found in the operator namespace", func() {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		result, err := secrets.HandleEncryptionKeySecret(ctx, fakeClient, makeWorkspace(workspaceNS), makeEncryptionConfig("backup-key"), operatorNS, log)
+		Expect(k8sErrors.IsNotFound(err)).To(BeTrue())
+		Expect(result).To(BeNil())
+	})
+})
@@ -0,0 +1,266 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package archive creates and extracts the gzip-compressed tar archives used to store workspace backups.
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is a file, directory or symbolic link to be archived.
+type Entry struct {
+	// Path is the path of the entry relative to the archived directory, using forward slashes
+	Path string
+	// IsDir is true if the entry is a directory
+	IsDir bool
+	// Size is the size of the entry's content in bytes. It is zero for directories and symbolic links
+	Size int64
+}
+
+// List returns the entries in srcDir that can be archived, in lexical order. Directories are listed before their
+// contents. Sockets, devices and named pipes are skipped.
+func List(srcDir string) ([]Entry, error) {
+	var entries []Entry
+	err := filepath.WalkDir(srcDir, func(path string, dirEntry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			return err
+		}
+		entry := Entry{Path: filepath.ToSlash(relPath), IsDir: info.IsDir()}
+		switch {
+		case info.Mode().IsRegular():
+			entry.Size = info.Size()
+		case info.IsDir(), info.Mode()&fs.ModeSymlink != 0:
+		default:
+			return nil
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", srcDir, err)
+	}
+	return entries, nil
+}
+
+// Create writes a gzip-compressed tar archive of the given entries of srcDir to destFile. Directories are not
+// archived recursively; their contents must be listed as separate entries. Entry names in the archive are prefixed
+// with "./", matching the output of `tar -czf destFile -C srcDir .`
+//
+// The archive is reproducible: archiving unchanged entries results in an identical file, so that the archive's
+// digest can be used to detect whether its content changed since a previous backup.
+func Create(srcDir string, entries []Entry, destFile string) error {
+	out, err := os.Create(destFile)
+	if err != nil {
+		return fmt.Errorf("failed to create archive %s: %w", destFile, err)
+	}
+	defer out.Close()
+
+	// The gzip header does not contain a modification time or file name unless set explicitly
+	gzipWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, entry := range entries {
+		name := "./" + entry.Path
+		if entry.IsDir {
+			name += "/"
+		}
+		if err := addEntry(tarWriter, filepath.Join(srcDir, filepath.FromSlash(entry.Path)), name); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", entry.Path, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write archive %s: %w", destFile, err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write archive %s: %w", destFile, err)
+	}
+	return out.Close()
+}
+
+func addEntry(tarWriter *tar.Writer, path, name string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	var linkTarget string
+	if info.Mode()&fs.ModeSymlink != 0 {
+		if linkTarget, err = os.Readlink(path); err != nil {
+			return err
+		}
+	} else if !info.Mode().IsRegular() && !info.IsDir() {
+		// Skip sockets, devices and named pipes
+		return nil
+	}
+
+	header, err := tar.FileInfoHeader(info, linkTarget)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	// Access and change times differ between backups of unchanged files and are not restored
+	header.AccessTime = time.Time{}
+	header.ChangeTime = time.Time{}
+	header.ModTime = header.ModTime.Truncate(time.Second)
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	// Copy exactly the size recorded in the header, as the file may be modified while it is archived
+	_, err = io.CopyN(tarWriter, file, header.Size)
+	return err
+}
+
+// Extract extracts a gzip-compressed tar archive into destDir. Entries that would be written outside destDir,
+// either directly or through a symbolic link, are rejected.
+func Extract(archiveFile, destDir string) error {
+	in, err := os.Open(archiveFile)
+	if err != nil {
+		return fmt.Errorf("failed to open archive %s: %w", archiveFile, err)
+	}
+	defer in.Close()
+
+	gzipReader, err := gzip.NewReader(in)
+	if err != nil {
+		return fmt.Errorf("failed to read archive %s: %w", archiveFile, err)
+	}
+	defer gzipReader.Close()
+
+	destDir, err = filepath.Abs(destDir)
+	if err != nil {
+		return err
+	}
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive %s: %w", archiveFile, err)
+		}
+		if err := extractEntry(tarReader, header, destDir); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+	}
+}
+
+func extractEntry(tarReader *tar.Reader, header *tar.Header, destDir string) error {
+	target := filepath.Join(destDir, filepath.Clean("/"+header.Name))
+	if target == destDir && header.Typeflag == tar.TypeDir {
+		return nil
+	}
+	if err := checkWithinDir(destDir, filepath.Dir(target)); err != nil {
+		return err
+	}
+	mode := fs.FileMode(header.Mode).Perm()
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		if err := checkWithinDir(destDir, target); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(target, mode|0700); err != nil {
+			return err
+		}
+		return os.Chmod(target, mode|0700)
+	case tar.TypeReg:
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		// Do not follow a symbolic link that was extracted earlier when writing the file
+		if info, err := os.Lstat(target); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+			if err := os.Remove(target); err != nil {
+				return err
+			}
+		}
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(file, tarReader); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	case tar.TypeSymlink:
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.Symlink(header.Linkname, target)
+	default:
+		// Hard links and special files are not created by Create and are ignored
+		return nil
+	}
+}
+
+// checkWithinDir returns an error if path, after resolving symbolic links, is not within dir.
+func checkWithinDir(dir, path string) error {
+	resolved := path
+	// Resolve the longest existing prefix of path, as the remaining directories will be created during extraction
+	for {
+		evaluated, err := filepath.EvalSymlinks(resolved)
+		if err == nil {
+			resolved = filepath.Join(evaluated, strings.TrimPrefix(path, resolved))
+			break
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		parent := filepath.Dir(resolved)
+		if parent == resolved {
+			break
+		}
+		resolved = parent
+	}
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if resolved != resolvedDir && !strings.HasPrefix(resolved, resolvedDir+string(filepath.Separator)) {
+		return fmt.Errorf("path %s is outside of %s", path, dir)
+	}
+	return nil
+}
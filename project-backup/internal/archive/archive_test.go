@@ -0,0 +1,258 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles creates files in dir from a map of slash-separated paths to contents. Paths ending in "/" are created as
+// directories, and contents starting with "->" as symbolic links to the rest of the contents.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for path, content := range files {
+		fullPath := filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		switch {
+		case path[len(path)-1] == '/':
+			require.NoError(t, os.MkdirAll(fullPath, 0755))
+		case len(content) > 2 && content[:2] == "->":
+			require.NoError(t, os.Symlink(content[2:], fullPath))
+		default:
+			require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+		}
+	}
+}
+
+// writeArchive writes a gzip-compressed tar archive containing headers to a file in a temporary directory. Regular
+// files contain their name.
+func writeArchive(t *testing.T, headers []*tar.Header) string {
+	archiveFile := filepath.Join(t.TempDir(), "archive.tar.gz")
+	out, err := os.Create(archiveFile)
+	require.NoError(t, err)
+	defer out.Close()
+	gzipWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, header := range headers {
+		if header.Typeflag == tar.TypeReg {
+			header.Size = int64(len(header.Name))
+		}
+		require.NoError(t, tarWriter.WriteHeader(header))
+		if header.Typeflag == tar.TypeReg {
+			_, err := tarWriter.Write([]byte(header.Name))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return archiveFile
+}
+
+func TestCreateAndExtract(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "Empty directory",
+			files: map[string]string{},
+		},
+		{
+			name: "Files in nested directories",
+			files: map[string]string{
+				"README.md":                 "readme",
+				"project/main.go":           "package main",
+				"project/.git/HEAD":         "ref: refs/heads/main",
+				"project/empty/":            "",
+				"other-project/data/a.json": "{}",
+			},
+		},
+		{
+			name: "Symbolic links",
+			files: map[string]string{
+				"project/main.go":     "package main",
+				"project/link.go":     "->main.go",
+				"project/dangling":    "->does-not-exist",
+				"project/parent-link": "->..",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srcDir := t.TempDir()
+			writeFiles(t, srcDir, tt.files)
+			entries, err := List(srcDir)
+			require.NoError(t, err)
+
+			archiveFile := filepath.Join(t.TempDir(), "archive.tar.gz")
+			require.NoError(t, Create(srcDir, entries, archiveFile))
+			destDir := t.TempDir()
+			require.NoError(t, Extract(archiveFile, destDir))
+
+			restored, err := List(destDir)
+			require.NoError(t, err)
+			assert.Equal(t, entries, restored)
+			for path, content := range tt.files {
+				srcPath := filepath.Join(srcDir, filepath.FromSlash(path))
+				destPath := filepath.Join(destDir, filepath.FromSlash(path))
+				srcInfo, err := os.Lstat(srcPath)
+				require.NoError(t, err)
+				destInfo, err := os.Lstat(destPath)
+				require.NoError(t, err, "%s should be restored", path)
+				assert.Equal(t, srcInfo.Mode(), destInfo.Mode(), "mode of %s", path)
+				switch {
+				case srcInfo.Mode()&os.ModeSymlink != 0:
+					link, err := os.Readlink(destPath)
+					require.NoError(t, err)
+					assert.Equal(t, content[2:], link)
+				case srcInfo.Mode().IsRegular():
+					data, err := os.ReadFile(destPath)
+					require.NoError(t, err)
+					assert.Equal(t, content, string(data))
+				}
+			}
+		})
+	}
+}
+
+func TestCreateIsReproducible(t *testing.T) {
+	srcDir := t.TempDir()
+	writeFiles(t, srcDir, map[string]string{
+		"project/main.go": "package main",
+		"project/link.go": "->main.go",
+	})
+	entries, err := List(srcDir)
+	require.NoError(t, err)
+
+	first := filepath.Join(t.TempDir(), "first.tar.gz")
+	second := filepath.Join(t.TempDir(), "second.tar.gz")
+	require.NoError(t, Create(srcDir, entries, first))
+	// Reading files changes their access time, which must not be part of the archive
+	_, err = os.ReadFile(filepath.Join(srcDir, "project", "main.go"))
+	require.NoError(t, err)
+	require.NoError(t, Create(srcDir, entries, second))
+
+	firstBytes, err := os.ReadFile(first)
+	require.NoError(t, err)
+	secondBytes, err := os.ReadFile(second)
+	require.NoError(t, err)
+	assert.Equal(t, firstBytes, secondBytes)
+}
+
+func TestCreateSubsetOfEntries(t *testing.T) {
+	srcDir := t.TempDir()
+	writeFiles(t, srcDir, map[string]string{
+		"a/file": "a",
+		"b/file": "b",
+	})
+	archiveFile := filepath.Join(t.TempDir(), "archive.tar.gz")
+	require.NoError(t, Create(srcDir, []Entry{{Path: "b", IsDir: true}, {Path: "b/file", Size: 1}}, archiveFile))
+
+	destDir := t.TempDir()
+	require.NoError(t, Extract(archiveFile, destDir))
+	restored, err := List(destDir)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{{Path: "b", IsDir: true}, {Path: "b/file", Size: 1}}, restored)
+}
+
+func TestExtractRejectsEntriesOutsideDestination(t *testing.T) {
+	outsideDir := t.TempDir()
+	tests := []struct {
+		name    string
+		headers []*tar.Header
+		// wantErr is true if the archive is rejected
+		wantErr bool
+		// wantFiles are the regular files expected in the destination directory, by slash-separated path
+		wantFiles []string
+	}{
+		{
+			name: "Parent directory references stay within the destination",
+			headers: []*tar.Header{
+				{Name: "../../escaped", Typeflag: tar.TypeReg, Mode: 0644},
+				{Name: "./dir/../../../also-escaped", Typeflag: tar.TypeReg, Mode: 0644},
+			},
+			wantFiles: []string{"escaped", "also-escaped"},
+		},
+		{
+			name: "Absolute paths stay within the destination",
+			headers: []*tar.Header{
+				{Name: filepath.Join(outsideDir, "absolute"), Typeflag: tar.TypeReg, Mode: 0644},
+			},
+			wantFiles: []string{filepath.ToSlash(filepath.Join(outsideDir, "absolute"))[1:]},
+		},
+		{
+			name: "File through symbolic link to absolute path",
+			headers: []*tar.Header{
+				{Name: "./link", Typeflag: tar.TypeSymlink, Linkname: outsideDir},
+				{Name: "./link/escaped", Typeflag: tar.TypeReg, Mode: 0644},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Directory through symbolic link to parent directory",
+			headers: []*tar.Header{
+				{Name: "./dir/", Typeflag: tar.TypeDir, Mode: 0755},
+				{Name: "./dir/link", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+				{Name: "./dir/link/escaped/", Typeflag: tar.TypeDir, Mode: 0755},
+			},
+			wantErr: true,
+		},
+		{
+			name: "File replacing symbolic link is not written through the link",
+			headers: []*tar.Header{
+				{Name: "./link", Typeflag: tar.TypeSymlink, Linkname: filepath.Join(outsideDir, "target")},
+				{Name: "./link", Typeflag: tar.TypeReg, Mode: 0644},
+			},
+			wantFiles: []string{"link"},
+		},
+		{
+			name: "Symbolic link within the destination",
+			headers: []*tar.Header{
+				{Name: "./dir/", Typeflag: tar.TypeDir, Mode: 0755},
+				{Name: "./link", Typeflag: tar.TypeSymlink, Linkname: "dir"},
+				{Name: "./link/file", Typeflag: tar.TypeReg, Mode: 0644},
+			},
+			wantFiles: []string{"dir/file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archiveFile := writeArchive(t, tt.headers)
+			destDir := t.TempDir()
+
+			err := Extract(archiveFile, destDir)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "is outside of")
+			} else {
+				require.NoError(t, err)
+			}
+			for _, path := range tt.wantFiles {
+				info, err := os.Lstat(filepath.Join(destDir, filepath.FromSlash(path)))
+				require.NoError(t, err, "%s should be extracted", path)
+				assert.True(t, info.Mode().IsRegular(), "%s should be a regular file", path)
+			}
+			outside, err := os.ReadDir(outsideDir)
+			require.NoError(t, err)
+			assert.Empty(t, outside, "Nothing should be written outside of the destination directory")
+		})
+	}
+}
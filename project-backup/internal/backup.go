@@ -0,0 +1,282 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/encryption"
+	"github.com/devfile/devworkspace-operator/project-backup/internal/archive"
+)
+
+// Backup archives the workspace data in opts.SourcePath and pushes it as an OCI artifact tagged opts.BackupImage to the
+// backup target, i.e. an OCI registry or S3-compatible object storage.
+//
+// The workspace data is split into multiple layers (see splitIntoLayers). Layers that are unchanged since a previous
+// backup are already present in the backup target and are not uploaded again. If an encryption key is configured,
+// layers are encrypted before they are uploaded. The data of each volume in opts.Volumes is stored in separate layers,
+// annotated with the name of the volume.
+func Backup(ctx context.Context, opts *Options) (*backup.Result, error) {
+	target, err := NewTarget(opts.BackupImage, opts)
+	if err != nil {
+		return nil, err
+	}
+	return backupTo(ctx, target, opts)
+}
+
+// backupTo backs up the workspace data in opts.SourcePath to target (see Backup).
+func backupTo(ctx context.Context, target Target, opts *Options) (*backup.Result, error) {
+	log.Printf("Backing up DevWorkspace %s in namespace %s to %s", opts.WorkspaceName, opts.WorkspaceNamespace, opts.BackupImage)
+	startTime := time.Now()
+
+	if info, err := os.Stat(opts.SourcePath); err != nil || !info.IsDir() {
+		return nil, NewExitError(backup.ExitCodeSourceNotFound, fmt.Errorf("backup source path %s is not a directory", opts.SourcePath))
+	}
+	entries, err := archive.List(opts.SourcePath)
+	if err != nil {
+		return nil, NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "devworkspace-backup-")
+	if err != nil {
+		return nil, NewExitError(backup.ExitCodeArchiveFailed, fmt.Errorf("failed to create temporary directory: %w", err))
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var previousLayers map[string]ocispec.Descriptor
+	if opts.EncryptionKey != nil {
+		log.Printf("Backup layers will be encrypted")
+		previousLayers = getPreviousEncryptedLayers(ctx, target)
+	}
+
+	result := &backup.Result{}
+	layers, err := pushLayers(ctx, target, opts.SourcePath, "", entries, tmpDir, opts.EncryptionKey, previousLayers, result)
+	if err != nil {
+		return nil, err
+	}
+	for _, volume := range opts.Volumes {
+		volumePath := filepath.Join(opts.VolumesPath, volume)
+		if info, err := os.Stat(volumePath); err != nil || !info.IsDir() {
+			log.Printf("Volume %s does not exist in %s, skipping it", volume, opts.VolumesPath)
+			continue
+		}
+		volumeEntries, err := archive.List(volumePath)
+		if err != nil {
+			return nil, NewExitError(backup.ExitCodeArchiveFailed, err)
+		}
+		if len(volumeEntries) == 0 {
+			log.Printf("Volume %s is empty, skipping it", volume)
+			continue
+		}
+		log.Printf("Backing up volume %s", volume)
+		volumeLayers, err := pushLayers(ctx, target, volumePath, volume, volumeEntries, tmpDir, opts.EncryptionKey, previousLayers, result)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, volumeLayers...)
+	}
+	result.Layers = len(layers)
+	log.Printf("Backup contains %d layers of total size %s, %d layers were unchanged since a previous backup",
+		result.Layers, formatBytes(result.Size), result.ReusedLayers)
+
+	var manifest ocispec.Descriptor
+	err = withRetries(ctx, "upload backup manifest", backup.ExitCodeTransferFailed, func() error {
+		manifest, err = oras.PackManifest(ctx, target, oras.PackManifestVersion1_1, BackupArtifactType, oras.PackManifestOptions{
+			Layers: layers,
+			ManifestAnnotations: map[string]string{
+				DevWorkspaceNameAnnotation:      opts.WorkspaceName,
+				DevWorkspaceNamespaceAnnotation: opts.WorkspaceNamespace,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// In addition to the "latest" tag, each backup is tagged with the time it was created so that previous backups
+	// remain available until they are pruned
+	backupTag := backup.BackupTag(startTime)
+	for _, tag := range []string{target.Reference(), backupTag} {
+		err = withRetries(ctx, "tag backup manifest", backup.ExitCodeTransferFailed, func() error {
+			return target.Tag(ctx, manifest, tag)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.Image = formatReference(target, backupTag)
+	result.Digest = manifest.Digest.String()
+
+	if opts.Retention.IsEnabled() {
+		// Failing to prune old backups does not fail the backup, as it will be retried after the next backup
+		result.PrunedBackups, err = Prune(ctx, target, opts)
+		if err != nil {
+			log.Printf("Warning: failed to prune old backups: %s", err)
+		}
+		result.DryRun = opts.DryRun
+	}
+
+	// Failing to list available backups does not fail the backup, as the list is refreshed after the next backup
+	result.Backups, err = listAvailableBackups(ctx, target)
+	if err != nil {
+		log.Printf("Warning: failed to list available backups: %s", err)
+	}
+
+	result.DurationSeconds = time.Since(startTime).Seconds()
+	log.Printf("Backup completed successfully: %s@%s (uploaded %s in %.0fs)",
+		opts.BackupImage, manifest.Digest, formatBytes(result.UploadedSize), result.DurationSeconds)
+	return result, nil
+}
+
+// pushLayers splits entries of srcDir into layers (see splitIntoLayers) and pushes them to the backup target. If volume
+// is not empty, the layers are annotated with it. The sizes of the layers are added to result.
+func pushLayers(ctx context.Context, target Target, srcDir, volume string, entries []archive.Entry, tmpDir string,
+	key *encryption.Key, previousLayers map[string]ocispec.Descriptor, result *backup.Result,
+) ([]ocispec.Descriptor, error) {
+	var layers []ocispec.Descriptor
+	for idx, layerEntries := range splitIntoLayers(entries) {
+		layerName := fmt.Sprintf(BackupLayerNameFormat, idx)
+		if volume != "" {
+			layerName = fmt.Sprintf(BackupVolumeLayerNameFormat, volume, idx)
+		}
+		layer, uploaded, err := pushLayer(ctx, target, srcDir, layerEntries, filepath.Join(tmpDir, layerName), key, previousLayers)
+		if err != nil {
+			return nil, err
+		}
+		// A layer reused from a previous backup carries the annotations it had in that backup, and may be shared with
+		// other layers
+		annotations := map[string]string{}
+		maps.Copy(annotations, layer.Annotations)
+		delete(annotations, LayerVolumeAnnotation)
+		if volume != "" {
+			annotations[LayerVolumeAnnotation] = volume
+		}
+		layer.Annotations = annotations
+		layers = append(layers, layer)
+		result.Size += layer.Size
+		if uploaded {
+			result.UploadedSize += layer.Size
+		} else {
+			result.ReusedLayers++
+		}
+	}
+	return layers, nil
+}
+
+// pushLayer archives entries of srcDir into archivePath and uploads the archive to the backup target, unless it is already
+// present. The archive is removed once it is uploaded. Returns the descriptor of the layer and whether it was uploaded.
+//
+// If key is not nil, the archive is encrypted before it is uploaded. As encrypted layers differ even if their contents
+// are unchanged, the layer of a previous backup with the same fingerprint (see getPreviousEncryptedLayers) is reused
+// instead.
+func pushLayer(ctx context.Context, target Target, srcDir string, entries []archive.Entry, archivePath string,
+	key *encryption.Key, previousLayers map[string]ocispec.Descriptor,
+) (ocispec.Descriptor, bool, error) {
+	if err := archive.Create(srcDir, entries, archivePath); err != nil {
+		return ocispec.Descriptor{}, false, NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+	defer os.Remove(archivePath)
+	layer, err := getArchiveDescriptor(archivePath)
+	if err != nil {
+		return ocispec.Descriptor{}, false, NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+
+	if key != nil {
+		fingerprint := key.Fingerprint(layer.Digest.String())
+		if previous, ok := previousLayers[fingerprint]; ok {
+			var exists bool
+			err = withRetries(ctx, "check backup layer", backup.ExitCodeTransferFailed, func() error {
+				exists, err = target.Exists(ctx, previous)
+				return err
+			})
+			if err != nil {
+				return ocispec.Descriptor{}, false, err
+			}
+			if exists {
+				log.Printf("Layer %s (%s) is unchanged", layer.Annotations[ocispec.AnnotationTitle], formatBytes(previous.Size))
+				return previous, false, nil
+			}
+		}
+		encryptedPath := archivePath + ".enc"
+		defer os.Remove(encryptedPath)
+		layer, err = encryptLayer(archivePath, encryptedPath, key, fingerprint)
+		if err != nil {
+			return ocispec.Descriptor{}, false, err
+		}
+		archivePath = encryptedPath
+	}
+
+	uploaded := false
+	err = withRetries(ctx, "upload backup layer", backup.ExitCodeTransferFailed, func() error {
+		exists, err := target.Exists(ctx, layer)
+		if err != nil {
+			return err
+		}
+		if exists {
+			log.Printf("Layer %s (%s) is unchanged", layer.Annotations[ocispec.AnnotationTitle], formatBytes(layer.Size))
+			return nil
+		}
+		file, err := os.Open(archivePath)
+		if err != nil {
+			return NewExitError(backup.ExitCodeArchiveFailed, err)
+		}
+		defer file.Close()
+		message := fmt.Sprintf("Uploading layer %s", layer.Annotations[ocispec.AnnotationTitle])
+		if err := target.Push(ctx, layer, newProgressReader(file, message, layer.Size)); err != nil {
+			return err
+		}
+		uploaded = true
+		return nil
+	})
+	return layer, uploaded, err
+}
+
+// getArchiveDescriptor returns the descriptor of an archive layer of a backup artifact
+func getArchiveDescriptor(archivePath string) (ocispec.Descriptor, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	archiveDigest, err := digest.FromReader(file)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to compute digest of %s: %w", archivePath, err)
+	}
+	return ocispec.Descriptor{
+		MediaType: BackupArchiveMediaType,
+		Digest:    archiveDigest,
+		Size:      info.Size(),
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: filepath.Base(archivePath),
+		},
+	}, nil
+}
@@ -0,0 +1,186 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/project-backup/internal/archive"
+)
+
+// writeWorkspaceFiles creates files in dir from a map of slash-separated paths to contents.
+func writeWorkspaceFiles(t *testing.T, dir string, files map[string]string) {
+	for path, content := range files {
+		fullPath := filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+}
+
+// assertSameContents checks that the files in actualDir match the files in expectedDir.
+func assertSameContents(t *testing.T, expectedDir, actualDir string) {
+	expected, err := archive.List(expectedDir)
+	require.NoError(t, err)
+	actual, err := archive.List(actualDir)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	for _, entry := range expected {
+		if entry.IsDir {
+			continue
+		}
+		expectedData, err := os.ReadFile(filepath.Join(expectedDir, filepath.FromSlash(entry.Path)))
+		require.NoError(t, err)
+		actualData, err := os.ReadFile(filepath.Join(actualDir, filepath.FromSlash(entry.Path)))
+		require.NoError(t, err)
+		assert.Equal(t, expectedData, actualData, "contents of %s", entry.Path)
+	}
+}
+
+// newBackupOptions returns options for backing up a workspace with projects and a "home" volume, and creates the
+// workspace's files.
+func newBackupOptions(t *testing.T) *Options {
+	opts := &Options{
+		SourcePath:         t.TempDir(),
+		VolumesPath:        t.TempDir(),
+		Volumes:            []string{"home", "missing"},
+		WorkspaceName:      "test-workspace",
+		WorkspaceNamespace: "test-ns",
+	}
+	writeWorkspaceFiles(t, opts.SourcePath, map[string]string{
+		"project/README.md":    "readme",
+		"project/src/main.go":  "package main",
+		"other-project/a.json": "{}",
+	})
+	writeWorkspaceFiles(t, opts.VolumesPath, map[string]string{
+		"home/.bashrc":         "export EDITOR=vi",
+		"home/.config/app.ini": "[app]",
+	})
+	return opts
+}
+
+// newRestoreOptions returns options for restoring a backup of opts into empty directories.
+func newRestoreOptions(t *testing.T, opts *Options) *Options {
+	restoreOpts := &Options{
+		ProjectsRoot:  t.TempDir(),
+		VolumesPath:   t.TempDir(),
+		Volumes:       opts.Volumes,
+		EncryptionKey: opts.EncryptionKey,
+	}
+	for _, volume := range restoreOpts.Volumes {
+		require.NoError(t, os.MkdirAll(filepath.Join(restoreOpts.VolumesPath, volume), 0755))
+	}
+	return restoreOpts
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	tests := []struct {
+		name      string
+		newTarget func(t *testing.T) Target
+	}{
+		{
+			name: "Registry",
+			newTarget: func(t *testing.T) Target {
+				return newMemoryTarget()
+			},
+		},
+		{
+			name: "S3",
+			newTarget: func(t *testing.T) Target {
+				target, _ := newTestS3Target(t, "s3://backups/test-ns/test-workspace:latest")
+				return target
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			target := tt.newTarget(t)
+			opts := newBackupOptions(t)
+
+			result, err := backupTo(ctx, target, opts)
+			require.NoError(t, err)
+			assert.Equal(t, 2, result.Layers, "Projects and the home volume should be stored in separate layers")
+			assert.Zero(t, result.ReusedLayers)
+			assert.Equal(t, result.Size, result.UploadedSize)
+			require.Len(t, result.Backups, 1)
+			assert.Equal(t, formatReference(target, result.Backups[0].Tag), result.Image)
+			assert.Equal(t, result.Digest, result.Backups[0].Digest)
+
+			// Backing up unchanged data does not upload any layers
+			unchanged, err := backupTo(ctx, target, opts)
+			require.NoError(t, err)
+			assert.Equal(t, 2, unchanged.ReusedLayers)
+			assert.Zero(t, unchanged.UploadedSize)
+
+			restoreOpts := newRestoreOptions(t, opts)
+			require.NoError(t, restoreFrom(ctx, target, restoreOpts))
+			assertSameContents(t, opts.SourcePath, restoreOpts.ProjectsRoot)
+			assertSameContents(t, filepath.Join(opts.VolumesPath, "home"), filepath.Join(restoreOpts.VolumesPath, "home"))
+		})
+	}
+}
+
+func TestBackupS3Location(t *testing.T) {
+	ctx := context.Background()
+	_, server := newTestS3Target(t, "s3://backups/test-ns/test-workspace:latest")
+	opts := newBackupOptions(t)
+	opts.BackupImage = "s3://backups/test-ns/test-workspace:latest"
+	opts.S3Endpoint = server.URL
+
+	result, err := Backup(ctx, opts)
+	require.NoError(t, err)
+	assert.Contains(t, server.Objects, "test-ns/test-workspace/tags/latest")
+	assert.Contains(t, server.Objects, "test-ns/test-workspace/tags/"+result.Backups[0].Tag)
+
+	restoreOpts := newRestoreOptions(t, opts)
+	restoreOpts.BackupImage = result.Image
+	restoreOpts.S3Endpoint = server.URL
+	require.NoError(t, Restore(ctx, restoreOpts))
+	assertSameContents(t, opts.SourcePath, restoreOpts.ProjectsRoot)
+}
+
+func TestBackupReusesUnchangedLayers(t *testing.T) {
+	ctx := context.Background()
+	target := newMemoryTarget()
+	opts := newBackupOptions(t)
+	first, err := backupTo(ctx, target, opts)
+	require.NoError(t, err)
+
+	// Only the layer containing the modified file is uploaded again
+	writeWorkspaceFiles(t, opts.SourcePath, map[string]string{"project/README.md": "modified readme"})
+	second, err := backupTo(ctx, target, opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Digest, second.Digest)
+	assert.Equal(t, 1, second.ReusedLayers)
+	assert.NotZero(t, second.UploadedSize)
+	assert.Less(t, second.UploadedSize, second.Size)
+}
+
+func TestBackupFailsIfSourceDoesNotExist(t *testing.T) {
+	opts := newBackupOptions(t)
+	opts.SourcePath = filepath.Join(opts.SourcePath, "missing")
+
+	_, err := backupTo(context.Background(), newMemoryTarget(), opts)
+	require.Error(t, err)
+	assert.Equal(t, backup.ExitCodeSourceNotFound, AsExitError(err).Code)
+}
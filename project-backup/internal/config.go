@@ -0,0 +1,295 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/encryption"
+)
+
+const (
+	// BackupArtifactType is the artifact type of the OCI manifest used to store a workspace backup
+	BackupArtifactType = "application/vnd.devworkspace.backup.artifact.v1+json"
+	// BackupLayerNameFormat is the format of the names of archive layers in the backup artifact
+	BackupLayerNameFormat = "devworkspace-backup-%d.tar.gz"
+	// BackupVolumeLayerNameFormat is the format of the names of archive layers that contain the data of a volume
+	BackupVolumeLayerNameFormat = "devworkspace-backup-%s-%d.tar.gz"
+	// BackupArchiveMediaType is the media type of the archive layers in the backup artifact, which are
+	// gzip-compressed tarballs
+	BackupArchiveMediaType = ocispec.MediaTypeImageLayerGzip
+	// BackupEncryptedArchiveMediaType is the media type of archive layers that are encrypted with the configured
+	// encryption key
+	BackupEncryptedArchiveMediaType = BackupArchiveMediaType + "+encrypted"
+	// LayerFingerprintAnnotation is the annotation of encrypted archive layers that contains the fingerprint of the
+	// unencrypted layer, used to recognize layers that are unchanged since a previous backup
+	LayerFingerprintAnnotation = "devworkspace.backup.layer.fingerprint"
+	// LayerVolumeAnnotation is the annotation of archive layers that contain the data of a volume rather than the
+	// projects. Its value is the name of the volume.
+	LayerVolumeAnnotation = "devworkspace.backup.layer.volume"
+	// BackupTag is the tag used for the most recent backup of a workspace
+	BackupTag = "latest"
+
+	// Annotations applied to the backup artifact manifest
+	DevWorkspaceNameAnnotation      = "devworkspace.name"
+	DevWorkspaceNamespaceAnnotation = "devworkspace.namespace"
+
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCAPath    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// Options contains the configuration for backing up or restoring a workspace, read from environment variables.
+type Options struct {
+	// SourcePath is the directory to back up (BACKUP_SOURCE_PATH)
+	SourcePath string
+	// BackupImage is the full reference of the backup artifact, including tag
+	BackupImage string
+	// WorkspaceName is the name of the DevWorkspace being backed up (DEVWORKSPACE_NAME)
+	WorkspaceName string
+	// WorkspaceNamespace is the namespace of the DevWorkspace being backed up (DEVWORKSPACE_NAMESPACE)
+	WorkspaceNamespace string
+	// ProjectsRoot is the directory that backups are restored into (PROJECTS_ROOT)
+	ProjectsRoot string
+	// Volumes are the names of volumes that are backed up or restored in addition to the projects (BACKUP_VOLUMES)
+	Volumes []string
+	// VolumesPath is the directory that contains the data of each volume in a subdirectory named after the volume
+	// (BACKUP_VOLUMES_PATH)
+	VolumesPath string
+	// Retention defines which backups are kept in the registry after a successful backup
+	Retention backup.RetentionPolicy
+	// DryRun logs backups that would be removed from the registry instead of removing them
+	DryRun bool
+
+	// RegistryAuthFile is the path to a docker config file with registry credentials (REGISTRY_AUTH_FILE)
+	RegistryAuthFile string
+	// CAFiles are additional PEM-encoded CA bundles used to verify the registry's certificate
+	CAFiles []string
+	// Insecure disables TLS certificate verification for the registry
+	Insecure bool
+	// PlainHTTP uses HTTP instead of HTTPS to access the registry
+	PlainHTTP bool
+
+	// S3Endpoint is the URL of the S3-compatible service used for backup locations starting with s3://
+	S3Endpoint string
+	// S3Region is the region of the S3 bucket
+	S3Region string
+	// S3AccessKeyID and S3SecretAccessKey are the credentials used to access the S3 bucket
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+
+	// EncryptionKey is the key used to encrypt backup layers when backing up, and to decrypt them when restoring
+	EncryptionKey *encryption.Key
+	// EncryptionRequired determines whether backups must be encrypted (BACKUP_ENCRYPTION_REQUIRED)
+	EncryptionRequired bool
+}
+
+// ReadBackupOptions reads the options required to back up a workspace from the environment.
+func ReadBackupOptions() (*Options, error) {
+	opts := &Options{
+		SourcePath:         os.Getenv("BACKUP_SOURCE_PATH"),
+		WorkspaceName:      os.Getenv("DEVWORKSPACE_NAME"),
+		WorkspaceNamespace: os.Getenv("DEVWORKSPACE_NAMESPACE"),
+	}
+	registry := os.Getenv("DEVWORKSPACE_BACKUP_REGISTRY")
+	for envVar, value := range map[string]string{
+		"BACKUP_SOURCE_PATH":           opts.SourcePath,
+		"DEVWORKSPACE_BACKUP_REGISTRY": registry,
+		"DEVWORKSPACE_NAMESPACE":       opts.WorkspaceNamespace,
+		"DEVWORKSPACE_NAME":            opts.WorkspaceName,
+	} {
+		if value == "" {
+			return nil, NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("missing environment variable %s", envVar))
+		}
+	}
+	// Remove trailing slash from registry path to avoid double slashes in image reference
+	opts.BackupImage = fmt.Sprintf("%s/%s/%s:%s", strings.TrimRight(registry, "/"), opts.WorkspaceNamespace, opts.WorkspaceName, BackupTag)
+	if err := readRetentionOptions(opts); err != nil {
+		return nil, err
+	}
+	readRegistryOptions(opts)
+	if err := readEncryptionOptions(opts, false); err != nil {
+		return nil, err
+	}
+	if err := readVolumeOptions(opts); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// ReadDeleteOptions reads the options required to delete all backups of a workspace from the environment.
+func ReadDeleteOptions() (*Options, error) {
+	opts := &Options{
+		WorkspaceName:      os.Getenv("DEVWORKSPACE_NAME"),
+		WorkspaceNamespace: os.Getenv("DEVWORKSPACE_NAMESPACE"),
+	}
+	registry := os.Getenv("DEVWORKSPACE_BACKUP_REGISTRY")
+	for envVar, value := range map[string]string{
+		"DEVWORKSPACE_BACKUP_REGISTRY": registry,
+		"DEVWORKSPACE_NAMESPACE":       opts.WorkspaceNamespace,
+		"DEVWORKSPACE_NAME":            opts.WorkspaceName,
+	} {
+		if value == "" {
+			return nil, NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("missing environment variable %s", envVar))
+		}
+	}
+	opts.BackupImage = fmt.Sprintf("%s/%s/%s:%s", strings.TrimRight(registry, "/"), opts.WorkspaceNamespace, opts.WorkspaceName, BackupTag)
+	if err := readRetentionOptions(opts); err != nil {
+		return nil, err
+	}
+	readRegistryOptions(opts)
+	return opts, nil
+}
+
+// ReadRestoreOptions reads the options required to restore a workspace from the environment.
+func ReadRestoreOptions() (*Options, error) {
+	opts := &Options{
+		BackupImage:  os.Getenv("BACKUP_IMAGE"),
+		ProjectsRoot: os.Getenv("PROJECTS_ROOT"),
+	}
+	if opts.BackupImage == "" {
+		return nil, NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("missing environment variable BACKUP_IMAGE"))
+	}
+	if opts.ProjectsRoot == "" {
+		return nil, NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("missing environment variable PROJECTS_ROOT"))
+	}
+	readRegistryOptions(opts)
+	if err := readEncryptionOptions(opts, true); err != nil {
+		return nil, err
+	}
+	if err := readVolumeOptions(opts); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func readRetentionOptions(opts *Options) error {
+	for envVar, value := range map[string]*int{
+		backup.RetentionKeepLastEnvVar:   &opts.Retention.KeepLast,
+		backup.RetentionKeepDailyEnvVar:  &opts.Retention.KeepDaily,
+		backup.RetentionKeepWeeklyEnvVar: &opts.Retention.KeepWeekly,
+	} {
+		if err := readIntEnvVar(envVar, value); err != nil {
+			return err
+		}
+	}
+	maxAgeSeconds := 0
+	if err := readIntEnvVar(backup.RetentionMaxAgeEnvVar, &maxAgeSeconds); err != nil {
+		return err
+	}
+	opts.Retention.MaxAge = time.Duration(maxAgeSeconds) * time.Second
+	opts.DryRun = os.Getenv(backup.RetentionDryRunEnvVar) == "true"
+	return nil
+}
+
+func readVolumeOptions(opts *Options) error {
+	volumes := os.Getenv(backup.VolumesEnvVar)
+	if volumes == "" {
+		return nil
+	}
+	opts.VolumesPath = os.Getenv(backup.VolumesPathEnvVar)
+	if opts.VolumesPath == "" {
+		return NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("missing environment variable %s", backup.VolumesPathEnvVar))
+	}
+	for _, volume := range strings.Split(volumes, ",") {
+		// Volume names are used as directory names, so they must not refer to other directories
+		if volume == "" || volume == "." || volume == ".." || strings.ContainsRune(volume, '/') {
+			return NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("invalid volume name %q in environment variable %s", volume, backup.VolumesEnvVar))
+		}
+		opts.Volumes = append(opts.Volumes, volume)
+	}
+	return nil
+}
+
+func readIntEnvVar(envVar string, value *int) error {
+	str := os.Getenv(envVar)
+	if str == "" {
+		return nil
+	}
+	parsed, err := strconv.Atoi(str)
+	if err != nil || parsed < 0 {
+		return NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("invalid value %q for environment variable %s", str, envVar))
+	}
+	*value = parsed
+	return nil
+}
+
+func readRegistryOptions(opts *Options) {
+	opts.RegistryAuthFile = os.Getenv("REGISTRY_AUTH_FILE")
+	if caFile := os.Getenv("REGISTRY_CA_FILE"); caFile != "" {
+		opts.CAFiles = append(opts.CAFiles, caFile)
+	}
+	parseExtraArgs(os.Getenv("ORAS_EXTRA_ARGS"), opts)
+
+	opts.S3Endpoint = os.Getenv(backup.S3EndpointEnvVar)
+	opts.S3Region = os.Getenv(backup.S3RegionEnvVar)
+	opts.S3AccessKeyID = os.Getenv(backup.S3AccessKeyIDEnvVar)
+	opts.S3SecretAccessKey = os.Getenv(backup.S3SecretAccessKeyEnvVar)
+}
+
+// readEncryptionOptions reads the key used to encrypt backups or, if restore is true, to decrypt them. A symmetric key
+// takes precedence over an X25519 key.
+func readEncryptionOptions(opts *Options, restore bool) error {
+	opts.EncryptionRequired = os.Getenv(backup.EncryptionRequiredEnvVar) == "true"
+
+	var err error
+	if symmetricKey := os.Getenv(backup.EncryptionKeyEnvVar); symmetricKey != "" {
+		opts.EncryptionKey, err = encryption.ParseSymmetricKey(symmetricKey)
+	} else if privateKey := os.Getenv(backup.EncryptionPrivateKeyEnvVar); privateKey != "" {
+		opts.EncryptionKey, err = encryption.ParsePrivateKey(privateKey)
+	} else if publicKey := os.Getenv(backup.EncryptionPublicKeyEnvVar); publicKey != "" && !restore {
+		opts.EncryptionKey, err = encryption.ParsePublicKey(publicKey)
+	}
+	if err != nil {
+		return NewExitError(backup.ExitCodeInvalidConfiguration, err)
+	}
+
+	if opts.EncryptionRequired && !restore && opts.EncryptionKey == nil {
+		return NewExitError(backup.ExitCodeInvalidConfiguration,
+			fmt.Errorf("backup encryption is required, but no encryption key is configured"))
+	}
+	return nil
+}
+
+// parseExtraArgs reads registry options from the ORAS_EXTRA_ARGS environment variable, which contains arguments that
+// were previously passed to the oras CLI. Only the arguments that affect how the registry is accessed are supported;
+// other arguments are ignored with a warning.
+func parseExtraArgs(extraArgs string, opts *Options) {
+	args := strings.Fields(extraArgs)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--insecure":
+			opts.Insecure = true
+		case arg == "--plain-http":
+			opts.PlainHTTP = true
+		case arg == "--ca-file" && i+1 < len(args):
+			opts.CAFiles = append(opts.CAFiles, args[i+1])
+			i++
+		case strings.HasPrefix(arg, "--ca-file="):
+			opts.CAFiles = append(opts.CAFiles, strings.TrimPrefix(arg, "--ca-file="))
+		default:
+			log.Printf("Warning: ignoring unsupported argument %q in ORAS_EXTRA_ARGS", arg)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/encryption"
+)
+
+// encryptLayer encrypts the archive layer in archivePath into encryptedPath and returns the descriptor of the encrypted
+// layer. The fingerprint of the unencrypted layer is stored in the LayerFingerprintAnnotation of the descriptor.
+func encryptLayer(archivePath, encryptedPath string, key *encryption.Key, fingerprint string) (ocispec.Descriptor, error) {
+	in, err := os.Open(archivePath)
+	if err != nil {
+		return ocispec.Descriptor{}, NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+	defer in.Close()
+	out, err := os.Create(encryptedPath)
+	if err != nil {
+		return ocispec.Descriptor{}, NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+	defer out.Close()
+
+	writer, err := encryption.NewWriter(out, key)
+	if err != nil {
+		return ocispec.Descriptor{}, NewExitError(backup.ExitCodeEncryptionFailed, err)
+	}
+	if _, err := io.Copy(writer, in); err != nil {
+		return ocispec.Descriptor{}, NewExitError(backup.ExitCodeEncryptionFailed, fmt.Errorf("failed to encrypt %s: %w", archivePath, err))
+	}
+	if err := writer.Close(); err != nil {
+		return ocispec.Descriptor{}, NewExitError(backup.ExitCodeEncryptionFailed, fmt.Errorf("failed to encrypt %s: %w", archivePath, err))
+	}
+	if err := out.Close(); err != nil {
+		return ocispec.Descriptor{}, NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+
+	layer, err := getArchiveDescriptor(encryptedPath)
+	if err != nil {
+		return ocispec.Descriptor{}, NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+	layer.MediaType = BackupEncryptedArchiveMediaType
+	layer.Annotations[ocispec.AnnotationTitle] = filepath.Base(archivePath)
+	layer.Annotations[LayerFingerprintAnnotation] = fingerprint
+	return layer, nil
+}
+
+// decryptLayer decrypts the encrypted archive layer in encryptedPath into archivePath.
+func decryptLayer(encryptedPath, archivePath string, key *encryption.Key) error {
+	in, err := os.Open(encryptedPath)
+	if err != nil {
+		return NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+	defer in.Close()
+	out, err := os.Create(archivePath)
+	if err != nil {
+		return NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+	defer out.Close()
+
+	reader, err := encryption.NewReader(in, key)
+	if err != nil {
+		return NewExitError(backup.ExitCodeEncryptionFailed, err)
+	}
+	if _, err := io.Copy(out, reader); err != nil {
+		return NewExitError(backup.ExitCodeEncryptionFailed, err)
+	}
+	return out.Close()
+}
+
+// getPreviousEncryptedLayers returns the encrypted layers of the most recent backup in the backup target, by the
+// fingerprint of the unencrypted layer. Failing to read the previous backup is not an error, as it only prevents
+// unchanged layers from being reused.
+func getPreviousEncryptedLayers(ctx context.Context, target Target) map[string]ocispec.Descriptor {
+	layers := map[string]ocispec.Descriptor{}
+	manifestDesc, err := target.Resolve(ctx, target.Reference())
+	if err != nil {
+		if !errors.Is(err, errdef.ErrNotFound) {
+			log.Printf("Warning: failed to read previous backup, all layers will be uploaded: %s", err)
+		}
+		return layers
+	}
+	manifestBytes, err := content.FetchAll(ctx, target, manifestDesc)
+	if err != nil {
+		log.Printf("Warning: failed to read previous backup, all layers will be uploaded: %s", err)
+		return layers
+	}
+	manifest := ocispec.Manifest{}
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		log.Printf("Warning: failed to parse previous backup, all layers will be uploaded: %s", err)
+		return layers
+	}
+	for _, layer := range manifest.Layers {
+		if fingerprint := layer.Annotations[LayerFingerprintAnnotation]; layer.MediaType == BackupEncryptedArchiveMediaType && fingerprint != "" {
+			layers[fingerprint] = layer
+		}
+	}
+	return layers
+}
@@ -0,0 +1,187 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/encryption"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/encryption/encryptiontest"
+)
+
+func TestEncryptLayer(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "devworkspace-backup-0.tar.gz")
+	require.NoError(t, os.WriteFile(archivePath, []byte("archive contents"), 0644))
+	key := encryptiontest.NewSymmetricKey(t)
+
+	layer, err := encryptLayer(archivePath, archivePath+".enc", key, "fingerprint")
+	require.NoError(t, err)
+	assert.Equal(t, BackupEncryptedArchiveMediaType, layer.MediaType)
+	assert.Equal(t, "devworkspace-backup-0.tar.gz", layer.Annotations[ocispec.AnnotationTitle])
+	assert.Equal(t, "fingerprint", layer.Annotations[LayerFingerprintAnnotation])
+
+	encrypted, err := os.ReadFile(archivePath + ".enc")
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "archive contents")
+	assert.Equal(t, int64(len(encrypted)), layer.Size)
+
+	require.NoError(t, decryptLayer(archivePath+".enc", filepath.Join(dir, "decrypted"), key))
+	decrypted, err := os.ReadFile(filepath.Join(dir, "decrypted"))
+	require.NoError(t, err)
+	assert.Equal(t, "archive contents", string(decrypted))
+
+	err = decryptLayer(archivePath+".enc", filepath.Join(dir, "decrypted"), encryptiontest.NewSymmetricKey(t))
+	require.Error(t, err)
+	assert.Equal(t, backup.ExitCodeEncryptionFailed, AsExitError(err).Code)
+}
+
+func TestGetPreviousEncryptedLayers(t *testing.T) {
+	ctx := context.Background()
+	encryptedLayer := ocispec.Descriptor{
+		MediaType:   BackupEncryptedArchiveMediaType,
+		Annotations: map[string]string{LayerFingerprintAnnotation: "fingerprint-1"},
+	}
+	tests := []struct {
+		name string
+		// layers are the layers of the latest backup; if nil, there is no previous backup
+		layers []ocispec.Descriptor
+		want   []string
+	}{
+		{
+			name: "No previous backup",
+			want: []string{},
+		},
+		{
+			name:   "Encrypted layers by fingerprint",
+			layers: []ocispec.Descriptor{encryptedLayer},
+			want:   []string{"fingerprint-1"},
+		},
+		{
+			name: "Unencrypted layers and layers without fingerprint are ignored",
+			layers: []ocispec.Descriptor{
+				{MediaType: BackupArchiveMediaType, Annotations: map[string]string{LayerFingerprintAnnotation: "unencrypted"}},
+				{MediaType: BackupEncryptedArchiveMediaType},
+				encryptedLayer,
+			},
+			want: []string{"fingerprint-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := newMemoryTarget()
+			if tt.layers != nil {
+				var layers []ocispec.Descriptor
+				for idx, layer := range tt.layers {
+					pushed, err := oras.PushBytes(ctx, target, layer.MediaType, []byte{byte(idx)})
+					require.NoError(t, err)
+					pushed.Annotations = layer.Annotations
+					layers = append(layers, pushed)
+				}
+				manifest, err := oras.PackManifest(ctx, target, oras.PackManifestVersion1_1, BackupArtifactType, oras.PackManifestOptions{Layers: layers})
+				require.NoError(t, err)
+				require.NoError(t, target.Tag(ctx, manifest, target.Reference()))
+			}
+
+			previous := getPreviousEncryptedLayers(ctx, target)
+			var fingerprints []string
+			for fingerprint, layer := range previous {
+				assert.Equal(t, fingerprint, layer.Annotations[LayerFingerprintAnnotation])
+				fingerprints = append(fingerprints, fingerprint)
+			}
+			assert.ElementsMatch(t, tt.want, fingerprints)
+		})
+	}
+}
+
+func TestEncryptedBackupAndRestore(t *testing.T) {
+	symmetricKey := encryptiontest.NewSymmetricKey(t)
+	publicKey, privateKey := encryptiontest.NewX25519Keys(t)
+	tests := []struct {
+		name       string
+		backupKey  *encryption.Key
+		restoreKey *encryption.Key
+		// wantCode is the exit code of the restore, or zero if it succeeds
+		wantCode int
+	}{
+		{
+			name:       "Symmetric key",
+			backupKey:  symmetricKey,
+			restoreKey: symmetricKey,
+		},
+		{
+			name:       "Public key for backup and private key for restore",
+			backupKey:  publicKey,
+			restoreKey: privateKey,
+		},
+		{
+			name:       "Public key cannot restore",
+			backupKey:  publicKey,
+			restoreKey: publicKey,
+			wantCode:   backup.ExitCodeEncryptionFailed,
+		},
+		{
+			name:      "No key cannot restore",
+			backupKey: symmetricKey,
+			wantCode:  backup.ExitCodeEncryptionFailed,
+		},
+		{
+			name:       "Wrong key cannot restore",
+			backupKey:  symmetricKey,
+			restoreKey: encryptiontest.NewSymmetricKey(t),
+			wantCode:   backup.ExitCodeEncryptionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			target := newMemoryTarget()
+			opts := newBackupOptions(t)
+			opts.EncryptionKey = tt.backupKey
+
+			result, err := backupTo(ctx, target, opts)
+			require.NoError(t, err)
+			assert.Zero(t, result.ReusedLayers)
+
+			// Encrypted layers differ between backups, so unchanged layers are found by their fingerprint
+			unchanged, err := backupTo(ctx, target, opts)
+			require.NoError(t, err)
+			assert.Equal(t, result.Layers, unchanged.ReusedLayers)
+			assert.Zero(t, unchanged.UploadedSize)
+
+			restoreOpts := newRestoreOptions(t, opts)
+			restoreOpts.EncryptionKey = tt.restoreKey
+			err = restoreFrom(ctx, target, restoreOpts)
+			if tt.wantCode != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, AsExitError(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assertSameContents(t, opts.SourcePath, restoreOpts.ProjectsRoot)
+			assertSameContents(t, filepath.Join(opts.VolumesPath, "home"), filepath.Join(restoreOpts.VolumesPath, "home"))
+		})
+	}
+}
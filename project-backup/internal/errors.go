@@ -0,0 +1,123 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/s3"
+)
+
+// terminationMessagePath is the default path Kubernetes reads a container's termination message from
+const terminationMessagePath = "/dev/termination-log"
+
+// maxTerminationMessageLength is the maximum size of a termination message accepted by Kubernetes
+const maxTerminationMessageLength = 4096
+
+// ExitError is an error that determines the exit code of the workspace-recovery binary.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+func NewExitError(code int, err error) *ExitError {
+	return &ExitError{Code: code, Err: err}
+}
+
+// AsExitError converts err to an ExitError. Errors that are not already an ExitError are treated as unknown errors.
+func AsExitError(err error) *ExitError {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr
+	}
+	return NewExitError(backup.ExitCodeUnknownError, err)
+}
+
+// classifyRegistryError wraps an error returned while accessing the backup target in an ExitError, using defaultCode
+// if the error does not indicate a more specific problem.
+func classifyRegistryError(err error, defaultCode int) *ExitError {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr
+	}
+
+	var errResp *errcode.ErrorResponse
+	if errors.As(err, &errResp) {
+		switch errResp.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return NewExitError(backup.ExitCodeAuthenticationFailed, err)
+		case http.StatusNotFound:
+			return NewExitError(backup.ExitCodeBackupNotFound, err)
+		}
+	}
+	var s3ErrResp *s3.ErrorResponse
+	if errors.As(err, &s3ErrResp) {
+		switch s3ErrResp.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return NewExitError(backup.ExitCodeAuthenticationFailed, err)
+		case http.StatusNotFound:
+			return NewExitError(backup.ExitCodeBackupNotFound, err)
+		}
+	}
+	if errors.Is(err, errdef.ErrNotFound) {
+		return NewExitError(backup.ExitCodeBackupNotFound, err)
+	}
+
+	var netErr net.Error
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certInvalidErr x509.CertificateInvalidError
+	if errors.As(err, &netErr) || errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &certInvalidErr) {
+		return NewExitError(backup.ExitCodeRegistryUnreachable, err)
+	}
+
+	return NewExitError(defaultCode, err)
+}
+
+// isRetriable returns whether an operation that failed with err may succeed if retried.
+func isRetriable(err error) bool {
+	switch classifyRegistryError(err, backup.ExitCodeTransferFailed).Code {
+	case backup.ExitCodeAuthenticationFailed, backup.ExitCodeBackupNotFound, backup.ExitCodeInvalidConfiguration:
+		return false
+	}
+	return true
+}
+
+// WriteTerminationMessage writes message to the container's termination message file, so that it can be read by
+// the controller from the pod's status.
+func WriteTerminationMessage(message string) error {
+	if len(message) > maxTerminationMessageLength {
+		message = message[:maxTerminationMessageLength-3] + "..."
+	}
+	return os.WriteFile(terminationMessagePath, []byte(message), 0644)
+}
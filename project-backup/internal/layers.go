@@ -0,0 +1,64 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"hash/fnv"
+
+	"github.com/devfile/devworkspace-operator/project-backup/internal/archive"
+)
+
+const (
+	// minLayerSize is the size of content after which a layer may be ended
+	minLayerSize = 16 * 1024 * 1024
+	// maxLayerSize is the size of content after which a layer is always ended. A single file larger than
+	// maxLayerSize is stored in its own layer.
+	maxLayerSize = 256 * 1024 * 1024
+	// layerBoundaryMask selects the paths after which a layer is ended once it contains at least minLayerSize
+	// bytes. On average, one in 64 paths is a layer boundary.
+	layerBoundaryMask = 63
+)
+
+// splitIntoLayers splits the entries of a backup into groups that are archived as separate layers.
+//
+// Layer boundaries are chosen based on a hash of entry paths rather than on offsets, so that adding, removing or
+// modifying a file only changes the layer that contains it (and at most the following layer, until the next boundary).
+// Layers containing unchanged files are identical to the layers of a previous backup and do not need to be uploaded
+// again.
+func splitIntoLayers(entries []archive.Entry) [][]archive.Entry {
+	var layers [][]archive.Entry
+	var current []archive.Entry
+	var currentSize int64
+	for _, entry := range entries {
+		current = append(current, entry)
+		currentSize += entry.Size
+		if currentSize >= maxLayerSize || (currentSize >= minLayerSize && isLayerBoundary(entry.Path)) {
+			layers = append(layers, current)
+			current = nil
+			currentSize = 0
+		}
+	}
+	if len(current) > 0 || len(layers) == 0 {
+		layers = append(layers, current)
+	}
+	return layers
+}
+
+func isLayerBoundary(path string) bool {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(path))
+	return hash.Sum32()&layerBoundaryMask == 0
+}
@@ -0,0 +1,139 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/devfile/devworkspace-operator/project-backup/internal/archive"
+)
+
+const mebibyte = 1024 * 1024
+
+// filesOfSize returns count entries of the given size, named so that their order matches the order of their names.
+func filesOfSize(prefix string, count int, size int64) []archive.Entry {
+	var entries []archive.Entry
+	for i := range count {
+		entries = append(entries, archive.Entry{Path: fmt.Sprintf("%s/file-%05d", prefix, i), Size: size})
+	}
+	return entries
+}
+
+func layerSizes(layers [][]archive.Entry) []int64 {
+	var sizes []int64
+	for _, layer := range layers {
+		var size int64
+		for _, entry := range layer {
+			size += entry.Size
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes
+}
+
+func TestSplitIntoLayers(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []archive.Entry
+		// check verifies the layers in addition to the invariants checked for all cases
+		check func(t *testing.T, layers [][]archive.Entry)
+	}{
+		{
+			name:    "No entries result in a single empty layer",
+			entries: nil,
+			check: func(t *testing.T, layers [][]archive.Entry) {
+				assert.Equal(t, [][]archive.Entry{nil}, layers)
+			},
+		},
+		{
+			name:    "Small workspace is stored in a single layer",
+			entries: append([]archive.Entry{{Path: "project", IsDir: true}}, filesOfSize("project", 100, 1024)...),
+			check: func(t *testing.T, layers [][]archive.Entry) {
+				assert.Len(t, layers, 1)
+			},
+		},
+		{
+			name:    "Layers end at boundaries once they exceed the minimum size",
+			entries: filesOfSize("project", 2000, 1*mebibyte),
+			check: func(t *testing.T, layers [][]archive.Entry) {
+				assert.Greater(t, len(layers), 1)
+				for idx, layer := range layers[:len(layers)-1] {
+					assert.True(t, isLayerBoundary(layer[len(layer)-1].Path) || layerSizes(layers)[idx] >= maxLayerSize,
+						"layer %d should end at a boundary or at the maximum size", idx)
+				}
+			},
+		},
+		{
+			name:    "Layers do not exceed the maximum size",
+			entries: filesOfSize("large", 10, 100*mebibyte),
+			check: func(t *testing.T, layers [][]archive.Entry) {
+				for idx, size := range layerSizes(layers) {
+					assert.LessOrEqual(t, size, int64(maxLayerSize+100*mebibyte), "size of layer %d", idx)
+				}
+			},
+		},
+		{
+			name:    "File larger than the maximum size is stored in its own layer",
+			entries: []archive.Entry{{Path: "small", Size: 1024}, {Path: "huge", Size: 2 * maxLayerSize}, {Path: "tail", Size: 1024}},
+			check: func(t *testing.T, layers [][]archive.Entry) {
+				assert.Equal(t, [][]archive.Entry{
+					{{Path: "small", Size: 1024}, {Path: "huge", Size: 2 * maxLayerSize}},
+					{{Path: "tail", Size: 1024}},
+				}, layers)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layers := splitIntoLayers(tt.entries)
+			var flattened []archive.Entry
+			for _, layer := range layers {
+				flattened = append(flattened, layer...)
+			}
+			assert.Equal(t, tt.entries, flattened, "layers should contain all entries in order")
+			tt.check(t, layers)
+		})
+	}
+}
+
+func TestSplitIntoLayersIsStableAcrossChanges(t *testing.T) {
+	entries := filesOfSize("project", 2000, 1*mebibyte)
+	layers := splitIntoLayers(entries)
+	assert.Greater(t, len(layers), 3)
+
+	// Modifying a file in a layer only changes that layer
+	modified := make([]archive.Entry, len(entries))
+	copy(modified, entries)
+	changedIdx := len(layers[0]) + 1
+	modified[changedIdx].Size += 1024
+	modifiedLayers := splitIntoLayers(modified)
+	assert.Equal(t, len(layers), len(modifiedLayers))
+	for idx := range layers {
+		if idx == 1 {
+			assert.NotEqual(t, layers[idx], modifiedLayers[idx])
+			continue
+		}
+		assert.Equal(t, layers[idx], modifiedLayers[idx], "layer %d should be unchanged", idx)
+	}
+
+	// Adding a file to the first layer does not change layers after the next boundary
+	added := append([]archive.Entry{{Path: "added", Size: 1024}}, entries...)
+	addedLayers := splitIntoLayers(added)
+	assert.Equal(t, layers[1:], addedLayers[1:])
+}
@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"time"
+)
+
+const progressLogInterval = 10 * time.Second
+
+// progressReader wraps a reader and periodically logs how much of the expected total has been read.
+type progressReader struct {
+	reader    io.Reader
+	operation string
+	total     int64
+	read      int64
+	lastLog   time.Time
+}
+
+func newProgressReader(reader io.Reader, operation string, total int64) *progressReader {
+	return &progressReader{
+		reader:    reader,
+		operation: operation,
+		total:     total,
+		lastLog:   time.Now(),
+	}
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.reader.Read(buf)
+	p.read += int64(n)
+	if time.Since(p.lastLog) >= progressLogInterval || (err == io.EOF && p.read > 0) {
+		p.logProgress()
+	}
+	return n, err
+}
+
+func (p *progressReader) logProgress() {
+	p.lastLog = time.Now()
+	if p.total > 0 {
+		log.Printf("%s: %s of %s (%d%%)", p.operation, formatBytes(p.read), formatBytes(p.total), p.read*100/p.total)
+	} else {
+		log.Printf("%s: %s", p.operation, formatBytes(p.read))
+	}
+}
+
+// formatBytes formats a number of bytes using binary units, e.g. "1.5 MiB"
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
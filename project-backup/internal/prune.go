@@ -0,0 +1,171 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+)
+
+// Prune removes backups of a workspace that are not kept by the retention policy opts.Retention from the backup target.
+// Returns the number of removed backups; in dry-run mode, the number of backups that would have been removed.
+func Prune(ctx context.Context, target Target, opts *Options) (int, error) {
+	tags, err := listTags(ctx, target)
+	if err != nil {
+		return 0, err
+	}
+	toPrune := backup.SelectBackupsToPrune(tags, opts.Retention, time.Now())
+	if len(toPrune) == 0 {
+		log.Printf("No backups to prune (%s)", opts.Retention)
+		return 0, nil
+	}
+	pruneTags := map[string]bool{}
+	for _, tag := range toPrune {
+		pruneTags[tag] = true
+	}
+	var keepTags []string
+	for _, tag := range tags {
+		if !pruneTags[tag] {
+			keepTags = append(keepTags, tag)
+		}
+	}
+	return deleteBackups(ctx, target, toPrune, keepTags, opts.DryRun)
+}
+
+// DeleteAll removes all backups of a workspace from the backup target. Returns the number of removed backups; in dry-run
+// mode, the number of backups that would have been removed.
+func DeleteAll(ctx context.Context, opts *Options) (int, error) {
+	log.Printf("Deleting all backups of DevWorkspace %s in namespace %s", opts.WorkspaceName, opts.WorkspaceNamespace)
+	target, err := NewTarget(opts.BackupImage, opts)
+	if err != nil {
+		return 0, err
+	}
+	tags, err := listTags(ctx, target)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := deleteBackups(ctx, target, tags, nil, opts.DryRun)
+	if err != nil {
+		return deleted, err
+	}
+	log.Printf("Deleted %d backups", deleted)
+	return deleted, nil
+}
+
+func listTags(ctx context.Context, target Target) ([]string, error) {
+	var tags []string
+	err := withRetries(ctx, "list backups", backup.ExitCodeTransferFailed, func() error {
+		tags = nil
+		err := target.Tags(ctx, "", func(page []string) error {
+			tags = append(tags, page...)
+			return nil
+		})
+		if errors.Is(err, errdef.ErrNotFound) {
+			// The repository does not exist yet
+			return nil
+		}
+		return err
+	})
+	return tags, err
+}
+
+// listAvailableBackups returns the most recent backups of a workspace in the backup target, from newest to oldest.
+func listAvailableBackups(ctx context.Context, target Target) ([]backup.AvailableBackup, error) {
+	tags, err := listTags(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	var backups []backup.AvailableBackup
+	for _, tag := range backup.SelectAvailableBackups(tags) {
+		desc, err := resolveTag(ctx, target, tag)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, backup.AvailableBackup{Tag: tag, Digest: desc.Digest.String()})
+	}
+	return backups, nil
+}
+
+// deleteBackups removes the manifests tagged with any of tags from the backup target. Manifests that are also tagged with
+// any of keepTags (e.g. the "latest" tag) are not removed.
+func deleteBackups(ctx context.Context, target Target, tags, keepTags []string, dryRun bool) (int, error) {
+	keepDigests := map[string]bool{}
+	for _, tag := range keepTags {
+		desc, err := resolveTag(ctx, target, tag)
+		if err != nil {
+			return 0, err
+		}
+		keepDigests[desc.Digest.String()] = true
+	}
+
+	deleted := 0
+	deletedDigests := map[string]bool{}
+	for _, tag := range tags {
+		desc, err := resolveTag(ctx, target, tag)
+		if errors.Is(err, errdef.ErrNotFound) {
+			// The tag was removed together with a manifest that was already deleted through another tag
+			continue
+		}
+		if err != nil {
+			return deleted, err
+		}
+		if keepDigests[desc.Digest.String()] {
+			log.Printf("Not deleting backup %s as it is still referenced by another tag", tag)
+			continue
+		}
+		if deletedDigests[desc.Digest.String()] {
+			continue
+		}
+		deletedDigests[desc.Digest.String()] = true
+		if dryRun {
+			log.Printf("[dry-run] Would delete backup %s (%s)", tag, desc.Digest)
+			deleted++
+			continue
+		}
+		log.Printf("Deleting backup %s (%s)", tag, desc.Digest)
+		err = withRetries(ctx, "delete backup", backup.ExitCodeTransferFailed, func() error {
+			err := target.Delete(ctx, desc)
+			if errors.Is(err, errdef.ErrNotFound) {
+				// The manifest was already deleted through another tag
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete backup %s: %w", tag, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+func resolveTag(ctx context.Context, target Target, tag string) (ocispec.Descriptor, error) {
+	var desc ocispec.Descriptor
+	err := withRetries(ctx, "resolve backup "+tag, backup.ExitCodeTransferFailed, func() error {
+		var err error
+		desc, err = target.Resolve(ctx, tag)
+		return err
+	})
+	return desc, err
+}
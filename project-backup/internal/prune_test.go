@@ -0,0 +1,103 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteBackups(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		keepTags []string
+		dryRun   bool
+		// wantDeleted is the number of deleted backups
+		wantDeleted int
+		// wantTags are the tags that remain in the target
+		wantTags []string
+	}{
+		{
+			name:        "Deletes backups by tag",
+			tags:        []string{"backup-1", "backup-2"},
+			keepTags:    []string{"latest", "backup-3"},
+			wantDeleted: 2,
+			wantTags:    []string{"backup-3", "latest"},
+		},
+		{
+			name:        "Does not delete backups referenced by a kept tag",
+			tags:        []string{"backup-1", "backup-2", "backup-3"},
+			keepTags:    []string{"latest"},
+			wantDeleted: 2,
+			wantTags:    []string{"backup-3", "latest"},
+		},
+		{
+			name:        "Deletes all backups",
+			tags:        []string{"backup-1", "backup-2", "backup-3", "latest"},
+			wantDeleted: 3,
+			wantTags:    nil,
+		},
+		{
+			name:        "Skips tags removed with a previously deleted backup",
+			tags:        []string{"backup-1", "backup-1-copy"},
+			keepTags:    []string{"latest"},
+			wantDeleted: 1,
+			wantTags:    []string{"backup-2", "backup-3", "latest"},
+		},
+		{
+			name:        "Dry run does not delete backups",
+			tags:        []string{"backup-1", "backup-1-copy", "backup-2"},
+			keepTags:    []string{"latest"},
+			dryRun:      true,
+			wantDeleted: 2,
+			wantTags:    []string{"backup-1", "backup-1-copy", "backup-2", "backup-3", "latest"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			target := newMemoryTarget()
+			pushManifest(t, target, "backup-1", "backup-1", "backup-1-copy")
+			pushManifest(t, target, "backup-2", "backup-2")
+			pushManifest(t, target, "backup-3", "backup-3", "latest")
+
+			deleted, err := deleteBackups(ctx, target, tt.tags, tt.keepTags, tt.dryRun)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDeleted, deleted)
+			tags, err := listTags(ctx, target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTags, tags)
+		})
+	}
+}
+
+func TestListAvailableBackups(t *testing.T) {
+	target := newMemoryTarget()
+	older := pushManifest(t, target, "older", "backup-20260101T000000Z")
+	newer := pushManifest(t, target, "newer", "backup-20260102T000000Z", "latest")
+
+	backups, err := listAvailableBackups(context.Background(), target)
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, "backup-20260102T000000Z", backups[0].Tag)
+	assert.Equal(t, newer.Digest.String(), backups[0].Digest)
+	assert.Equal(t, "backup-20260101T000000Z", backups[1].Tag)
+	assert.Equal(t, older.Digest.String(), backups[1].Digest)
+}
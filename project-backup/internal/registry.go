@@ -0,0 +1,156 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras-go/v2/registry/remote/retry"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+)
+
+const (
+	maxAttempts  = 3
+	retryBackoff = 5 * time.Second
+)
+
+// NewRepository returns a client for the repository containing reference, configured with the credentials and
+// TLS settings in opts.
+func NewRepository(reference string, opts *Options) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(reference)
+	if err != nil {
+		return nil, NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("invalid backup image reference %s: %w", reference, err))
+	}
+	repo.PlainHTTP = opts.PlainHTTP
+
+	tlsConfig, err := getTLSConfig(opts)
+	if err != nil {
+		return nil, NewExitError(backup.ExitCodeInvalidConfiguration, err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	credential, err := getCredentialFunc(repo.Reference.Registry, opts)
+	if err != nil {
+		return nil, NewExitError(backup.ExitCodeInvalidConfiguration, err)
+	}
+
+	repo.Client = &auth.Client{
+		Client:     &http.Client{Transport: retry.NewTransport(transport)},
+		Cache:      auth.NewCache(),
+		Credential: credential,
+	}
+	return repo, nil
+}
+
+// getCredentialFunc returns the credentials used to access registry. If a registry auth file is provided, it is
+// used for all registries. Otherwise, the pod's service account token is used for the OpenShift internal registry.
+func getCredentialFunc(registry string, opts *Options) (auth.CredentialFunc, error) {
+	if opts.RegistryAuthFile != "" {
+		log.Printf("Using registry credentials from %s", opts.RegistryAuthFile)
+		store, err := credentials.NewFileStore(opts.RegistryAuthFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read registry auth file %s: %w", opts.RegistryAuthFile, err)
+		}
+		return credentials.Credential(store), nil
+	}
+
+	if !isInternalRegistry(registry) {
+		return nil, nil
+	}
+	token, err := os.ReadFile(serviceAccountTokenPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read service account token: %w", err)
+	}
+	log.Printf("Using mounted service account token for registry authentication")
+	return auth.StaticCredential(registry, auth.Credential{
+		Username: "serviceaccount",
+		Password: strings.TrimSpace(string(token)),
+	}), nil
+}
+
+// isInternalRegistry returns whether registry refers to the OpenShift internal registry
+func isInternalRegistry(registry string) bool {
+	return strings.Contains(registry, "openshift") || strings.Contains(registry, "svc.cluster.local")
+}
+
+// getTLSConfig returns a TLS config that trusts the system certificates, the service account CA (used by the
+// OpenShift internal registry) and any additional CA bundles in opts.
+func getTLSConfig(opts *Options) (*tls.Config, error) {
+	if opts.Insecure {
+		log.Printf("Warning: TLS certificate verification is disabled for the backup registry")
+		return &tls.Config{InsecureSkipVerify: true}, nil //nolint:gosec // explicitly requested through configuration
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		log.Printf("Failed to read system certificates, only configured CA bundles will be trusted: %s", err)
+		rootCAs = x509.NewCertPool()
+	}
+
+	caFiles := opts.CAFiles
+	if _, err := os.Stat(serviceAccountCAPath); err == nil {
+		caFiles = append([]string{serviceAccountCAPath}, caFiles...)
+	}
+	for _, caFile := range caFiles {
+		pemCerts, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle %s: %w", caFile, err)
+		}
+		if !rootCAs.AppendCertsFromPEM(pemCerts) {
+			return nil, fmt.Errorf("no valid certificates found in CA bundle %s", caFile)
+		}
+	}
+	return &tls.Config{RootCAs: rootCAs}, nil
+}
+
+// withRetries calls fn until it succeeds, returns an error that cannot be resolved by retrying or the maximum number
+// of attempts is reached. Errors are classified using failureCode as the default exit code.
+func withRetries(ctx context.Context, operation string, failureCode int, fn func() error) error {
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		if !isRetriable(err) || attempt == maxAttempts {
+			break
+		}
+		backoff := time.Duration(attempt) * retryBackoff
+		log.Printf("Failed to %s (attempt %d/%d), retrying in %s: %s", operation, attempt, maxAttempts, backoff, err)
+		select {
+		case <-ctx.Done():
+			return classifyRegistryError(fmt.Errorf("failed to %s: %w", operation, ctx.Err()), failureCode)
+		case <-time.After(backoff):
+		}
+	}
+	return classifyRegistryError(fmt.Errorf("failed to %s: %w", operation, err), failureCode)
+}
@@ -0,0 +1,184 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/encryption"
+	"github.com/devfile/devworkspace-operator/project-backup/internal/archive"
+)
+
+// Restore pulls the backup artifact opts.BackupImage from the backup target and extracts its layers into opts.ProjectsRoot,
+// reassembling the workspace data at the time of the backup. Layers that contain the data of a volume in opts.Volumes are
+// extracted into the volume's directory in opts.VolumesPath; layers of other volumes are skipped.
+// If opts.ProjectsRoot is not empty, the restore is skipped to avoid overwriting existing data. Likewise, volumes that
+// are not empty are not restored.
+func Restore(ctx context.Context, opts *Options) error {
+	target, err := NewTarget(opts.BackupImage, opts)
+	if err != nil {
+		return err
+	}
+	return restoreFrom(ctx, target, opts)
+}
+
+// restoreFrom restores the backup referenced by target into opts.ProjectsRoot (see Restore).
+func restoreFrom(ctx context.Context, target Target, opts *Options) error {
+	log.Printf("Restoring DevWorkspace from image %s to path %s", opts.BackupImage, opts.ProjectsRoot)
+
+	entries, err := os.ReadDir(opts.ProjectsRoot)
+	if err != nil {
+		return NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("failed to read PROJECTS_ROOT %s: %w", opts.ProjectsRoot, err))
+	}
+	if len(entries) > 0 {
+		log.Printf("PROJECTS_ROOT %s is not empty. Skipping restore action.", opts.ProjectsRoot)
+		return nil
+	}
+
+	var manifestDesc ocispec.Descriptor
+	var manifestBytes []byte
+	err = withRetries(ctx, "fetch backup manifest", backup.ExitCodeTransferFailed, func() error {
+		manifestDesc, err = target.Resolve(ctx, target.Reference())
+		if err != nil {
+			return err
+		}
+		manifestBytes, err = content.FetchAll(ctx, target, manifestDesc)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	manifest := ocispec.Manifest{}
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return NewExitError(backup.ExitCodeTransferFailed, fmt.Errorf("failed to parse backup manifest %s: %w", manifestDesc.Digest, err))
+	}
+	if len(manifest.Layers) == 0 {
+		return NewExitError(backup.ExitCodeBackupNotFound, fmt.Errorf("backup artifact %s does not contain any layers", manifestDesc.Digest))
+	}
+
+	tmpDir, err := os.MkdirTemp("", "devworkspace-restore-")
+	if err != nil {
+		return NewExitError(backup.ExitCodeArchiveFailed, fmt.Errorf("failed to create temporary directory: %w", err))
+	}
+	defer os.RemoveAll(tmpDir)
+
+	volumePaths, err := getRestoredVolumePaths(opts)
+	if err != nil {
+		return err
+	}
+
+	// Layers are extracted in order, as a layer may contain the contents of a directory created by a previous layer
+	for idx, layer := range manifest.Layers {
+		destDir := opts.ProjectsRoot
+		if volume := layer.Annotations[LayerVolumeAnnotation]; volume != "" {
+			if destDir = volumePaths[volume]; destDir == "" {
+				log.Printf("Skipping backup layer %s of volume %s, as the volume is not restored", layer.Digest, volume)
+				continue
+			}
+		}
+		if layer.MediaType == BackupEncryptedArchiveMediaType && !opts.EncryptionKey.CanDecrypt() {
+			return NewExitError(backup.ExitCodeEncryptionFailed,
+				fmt.Errorf("backup %s is encrypted, but no key to decrypt it is configured", manifestDesc.Digest))
+		}
+		if err := restoreLayer(ctx, target, layer, filepath.Join(tmpDir, fmt.Sprintf(BackupLayerNameFormat, idx)), destDir, opts.EncryptionKey); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Restore completed successfully.")
+	return nil
+}
+
+// getRestoredVolumePaths returns the directories that the data of each volume in opts.Volumes is restored into. Volumes
+// whose directory is not empty are not restored, to avoid overwriting existing data.
+func getRestoredVolumePaths(opts *Options) (map[string]string, error) {
+	volumePaths := map[string]string{}
+	for _, volume := range opts.Volumes {
+		volumePath := filepath.Join(opts.VolumesPath, volume)
+		entries, err := os.ReadDir(volumePath)
+		if err != nil {
+			return nil, NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("failed to read volume directory %s: %w", volumePath, err))
+		}
+		if len(entries) > 0 {
+			log.Printf("Volume %s is not empty. Skipping restore of the volume.", volume)
+			continue
+		}
+		volumePaths[volume] = volumePath
+	}
+	return volumePaths, nil
+}
+
+// restoreLayer downloads an archive layer of a backup artifact to archivePath and extracts it into destDir. Encrypted
+// layers are decrypted with key.
+func restoreLayer(ctx context.Context, target content.Fetcher, layer ocispec.Descriptor, archivePath, destDir string, key *encryption.Key) error {
+	downloadPath := archivePath
+	if layer.MediaType == BackupEncryptedArchiveMediaType {
+		downloadPath = archivePath + ".enc"
+	}
+	err := withRetries(ctx, "download backup layer", backup.ExitCodeTransferFailed, func() error {
+		return downloadLayer(ctx, target, layer, downloadPath)
+	})
+	if err != nil {
+		return err
+	}
+	defer os.Remove(downloadPath)
+	if downloadPath != archivePath {
+		log.Printf("Decrypting backup layer %s", layer.Digest)
+		if err := decryptLayer(downloadPath, archivePath, key); err != nil {
+			return err
+		}
+		defer os.Remove(archivePath)
+	}
+
+	log.Printf("Extracting backup layer %s to %s", layer.Digest, destDir)
+	if err := archive.Extract(archivePath, destDir); err != nil {
+		return NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+	return nil
+}
+
+func downloadLayer(ctx context.Context, fetcher content.Fetcher, layer ocispec.Descriptor, destFile string) error {
+	reader, err := fetcher.Fetch(ctx, layer)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	out, err := os.Create(destFile)
+	if err != nil {
+		return NewExitError(backup.ExitCodeArchiveFailed, err)
+	}
+	defer out.Close()
+
+	verifyReader := content.NewVerifyReader(reader, layer)
+	if _, err := io.Copy(out, newProgressReader(verifyReader, fmt.Sprintf("Downloading layer %s", layer.Digest), layer.Size)); err != nil {
+		return err
+	}
+	if err := verifyReader.Verify(); err != nil {
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,169 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+)
+
+func TestGetRestoredVolumePaths(t *testing.T) {
+	tests := []struct {
+		name string
+		// files are the files that exist in the volumes directory before the restore
+		files    map[string]string
+		volumes  []string
+		want     []string
+		wantCode int
+	}{
+		{
+			name:    "Empty volumes are restored",
+			volumes: []string{"home", "cache"},
+			want:    []string{"home", "cache"},
+		},
+		{
+			name:    "Volumes that are not empty are not restored",
+			files:   map[string]string{"home/.bashrc": "existing"},
+			volumes: []string{"home", "cache"},
+			want:    []string{"cache"},
+		},
+		{
+			name:     "Missing volume directory",
+			volumes:  []string{"home", "missing"},
+			wantCode: backup.ExitCodeInvalidConfiguration,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Options{VolumesPath: t.TempDir(), Volumes: tt.volumes}
+			for _, volume := range []string{"home", "cache"} {
+				require.NoError(t, os.MkdirAll(filepath.Join(opts.VolumesPath, volume), 0755))
+			}
+			writeWorkspaceFiles(t, opts.VolumesPath, tt.files)
+
+			volumePaths, err := getRestoredVolumePaths(opts)
+			if tt.wantCode != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, AsExitError(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			want := map[string]string{}
+			for _, volume := range tt.want {
+				want[volume] = filepath.Join(opts.VolumesPath, volume)
+			}
+			assert.Equal(t, want, volumePaths)
+		})
+	}
+}
+
+func TestRestoreVolumeLayers(t *testing.T) {
+	ctx := context.Background()
+	target := newMemoryTarget()
+	opts := newBackupOptions(t)
+	opts.Volumes = []string{"home", "cache"}
+	writeWorkspaceFiles(t, opts.VolumesPath, map[string]string{"cache/data.bin": "cached"})
+	_, err := backupTo(ctx, target, opts)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		// volumes are the volumes that are restored
+		volumes []string
+		// files are the files that exist before the restore, relative to the volumes directory
+		files map[string]string
+		// wantRestored are the volumes whose contents are expected to be restored
+		wantRestored []string
+	}{
+		{
+			name:         "All volumes",
+			volumes:      []string{"home", "cache"},
+			wantRestored: []string{"home", "cache"},
+		},
+		{
+			name:         "Layers of volumes that are not restored are skipped",
+			volumes:      []string{"cache"},
+			wantRestored: []string{"cache"},
+		},
+		{
+			name:         "Volumes that are not empty are not overwritten",
+			volumes:      []string{"home", "cache"},
+			files:        map[string]string{"home/.bashrc": "existing"},
+			wantRestored: []string{"cache"},
+		},
+		{
+			name:         "No volumes",
+			wantRestored: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restoreOpts := &Options{ProjectsRoot: t.TempDir(), VolumesPath: t.TempDir(), Volumes: tt.volumes}
+			for _, volume := range []string{"home", "cache"} {
+				require.NoError(t, os.MkdirAll(filepath.Join(restoreOpts.VolumesPath, volume), 0755))
+			}
+			writeWorkspaceFiles(t, restoreOpts.VolumesPath, tt.files)
+
+			require.NoError(t, restoreFrom(ctx, target, restoreOpts))
+
+			assertSameContents(t, opts.SourcePath, restoreOpts.ProjectsRoot)
+			for _, volume := range tt.wantRestored {
+				assertSameContents(t, filepath.Join(opts.VolumesPath, volume), filepath.Join(restoreOpts.VolumesPath, volume))
+			}
+			for volume, content := range tt.files {
+				data, err := os.ReadFile(filepath.Join(restoreOpts.VolumesPath, filepath.FromSlash(volume)))
+				require.NoError(t, err)
+				assert.Equal(t, content, string(data), "Existing files should not be overwritten")
+			}
+			if len(tt.volumes) < 2 {
+				entries, err := os.ReadDir(filepath.Join(restoreOpts.VolumesPath, "home"))
+				require.NoError(t, err)
+				assert.Empty(t, entries, "Volume that is not restored should not be written to")
+			}
+		})
+	}
+}
+
+func TestRestoreSkipsProjectsRootThatIsNotEmpty(t *testing.T) {
+	ctx := context.Background()
+	target := newMemoryTarget()
+	opts := newBackupOptions(t)
+	_, err := backupTo(ctx, target, opts)
+	require.NoError(t, err)
+
+	restoreOpts := newRestoreOptions(t, opts)
+	writeWorkspaceFiles(t, restoreOpts.ProjectsRoot, map[string]string{"existing/file": "existing"})
+	require.NoError(t, restoreFrom(ctx, target, restoreOpts))
+
+	entries, err := os.ReadDir(restoreOpts.ProjectsRoot)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "existing", entries[0].Name())
+}
+
+func TestRestoreMissingBackup(t *testing.T) {
+	opts := newRestoreOptions(t, &Options{})
+	err := restoreFrom(context.Background(), newMemoryTarget(), opts)
+	require.Error(t, err)
+	assert.Equal(t, backup.ExitCodeBackupNotFound, AsExitError(err).Code)
+}
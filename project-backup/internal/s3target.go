@@ -0,0 +1,236 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/s3"
+)
+
+// s3Target stores backups in S3-compatible object storage. The backups of a workspace are stored under a path in the
+// bucket, using the same layout as an OCI registry:
+//
+//	<path>/blobs/<algorithm>/<digest>  archive layers and manifests of backups
+//	<path>/tags/<tag>                  descriptor of the manifest referenced by the tag
+type s3Target struct {
+	client    *s3.Client
+	location  string
+	path      string
+	reference string
+}
+
+// newS3Target returns a target for a location of the form s3://<bucket>/<path>[:<tag>|@<digest>].
+func newS3Target(location string, opts *Options) (*s3Target, error) {
+	target := &s3Target{reference: BackupTag}
+	bucketPath := strings.TrimPrefix(location, backup.S3LocationPrefix)
+	if idx := strings.LastIndex(bucketPath, "@"); idx >= 0 {
+		bucketPath, target.reference = bucketPath[:idx], bucketPath[idx+1:]
+	} else if idx := strings.LastIndex(bucketPath, ":"); idx > strings.LastIndex(bucketPath, "/") {
+		bucketPath, target.reference = bucketPath[:idx], bucketPath[idx+1:]
+	}
+	bucket, objectPath, _ := strings.Cut(bucketPath, "/")
+	target.path = strings.Trim(objectPath, "/")
+	if bucket == "" || target.path == "" || target.reference == "" {
+		return nil, NewExitError(backup.ExitCodeInvalidConfiguration, fmt.Errorf("invalid S3 backup location %s", location))
+	}
+	target.location = backup.S3LocationPrefix + bucket + "/" + target.path
+
+	tlsConfig, err := getTLSConfig(opts)
+	if err != nil {
+		return nil, NewExitError(backup.ExitCodeInvalidConfiguration, err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	target.client, err = s3.NewClient(s3.Config{
+		Endpoint:        opts.S3Endpoint,
+		Region:          opts.S3Region,
+		Bucket:          bucket,
+		AccessKeyID:     opts.S3AccessKeyID,
+		SecretAccessKey: opts.S3SecretAccessKey,
+		HTTPClient:      &http.Client{Transport: transport},
+	})
+	if err != nil {
+		return nil, NewExitError(backup.ExitCodeInvalidConfiguration, err)
+	}
+	return target, nil
+}
+
+func (t *s3Target) Reference() string {
+	return t.reference
+}
+
+func (t *s3Target) Location() string {
+	return t.location
+}
+
+func (t *s3Target) blobKey(dgst digest.Digest) string {
+	return path.Join(t.path, "blobs", dgst.Algorithm().String(), dgst.Encoded())
+}
+
+func (t *s3Target) tagKey(tag string) string {
+	return path.Join(t.path, "tags", tag)
+}
+
+func (t *s3Target) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	_, err := t.client.HeadObject(ctx, t.blobKey(target.Digest))
+	if s3.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (t *s3Target) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	reader, err := t.client.GetObject(ctx, t.blobKey(target.Digest))
+	if s3.IsNotFound(err) {
+		return nil, fmt.Errorf("%s: %w", target.Digest, errdef.ErrNotFound)
+	}
+	return reader, err
+}
+
+func (t *s3Target) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	return t.client.PutObject(ctx, t.blobKey(expected.Digest), content, expected.Size)
+}
+
+// Resolve returns the descriptor of the manifest referenced by a tag or digest.
+func (t *s3Target) Resolve(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	if dgst, err := digest.Parse(reference); err == nil {
+		size, err := t.client.HeadObject(ctx, t.blobKey(dgst))
+		if s3.IsNotFound(err) {
+			return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+		}
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		return ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: dgst, Size: size}, nil
+	}
+
+	reader, err := t.client.GetObject(ctx, t.tagKey(reference))
+	if s3.IsNotFound(err) {
+		return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+	}
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer reader.Close()
+	desc := ocispec.Descriptor{}
+	if err := json.NewDecoder(reader).Decode(&desc); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to read tag %s: %w", reference, err)
+	}
+	return desc, nil
+}
+
+func (t *s3Target) Tag(ctx context.Context, desc ocispec.Descriptor, reference string) error {
+	descBytes, err := json.Marshal(desc)
+	if err != nil {
+		return err
+	}
+	return t.client.PutObject(ctx, t.tagKey(reference), bytes.NewReader(descBytes), int64(len(descBytes)))
+}
+
+// Tags lists the tags in lexical order, starting after last.
+func (t *s3Target) Tags(ctx context.Context, last string, fn func(tags []string) error) error {
+	tagsPrefix := t.tagKey("") + "/"
+	var tags []string
+	err := t.client.ListObjects(ctx, tagsPrefix, func(keys []string) error {
+		for _, key := range keys {
+			if tag := strings.TrimPrefix(key, tagsPrefix); tag > last {
+				tags = append(tags, tag)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(tags)
+	return fn(tags)
+}
+
+// Delete removes a backup manifest and all tags that reference it. Unlike a registry, object storage does not
+// garbage collect unreferenced blobs, so the config and layers of the manifest that are not used by any remaining
+// backup are removed as well.
+func (t *s3Target) Delete(ctx context.Context, target ocispec.Descriptor) error {
+	manifest, err := t.fetchManifest(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	usedBlobs := map[digest.Digest]bool{}
+	err = t.Tags(ctx, "", func(tags []string) error {
+		for _, tag := range tags {
+			desc, err := t.Resolve(ctx, tag)
+			if err != nil {
+				return err
+			}
+			if desc.Digest == target.Digest {
+				if err := t.client.DeleteObject(ctx, t.tagKey(tag)); err != nil {
+					return err
+				}
+				continue
+			}
+			other, err := t.fetchManifest(ctx, desc)
+			if err != nil {
+				return err
+			}
+			usedBlobs[other.Config.Digest] = true
+			for _, layer := range other.Layers {
+				usedBlobs[layer.Digest] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	blobs := append([]ocispec.Descriptor{manifest.Config}, manifest.Layers...)
+	for _, blob := range blobs {
+		if blob.Digest == "" || usedBlobs[blob.Digest] {
+			continue
+		}
+		if err := t.client.DeleteObject(ctx, t.blobKey(blob.Digest)); err != nil {
+			return err
+		}
+	}
+	return t.client.DeleteObject(ctx, t.blobKey(target.Digest))
+}
+
+func (t *s3Target) fetchManifest(ctx context.Context, desc ocispec.Descriptor) (*ocispec.Manifest, error) {
+	manifestBytes, err := content.FetchAll(ctx, t, desc)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ocispec.Manifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse backup manifest %s: %w", desc.Digest, err)
+	}
+	return manifest, nil
+}
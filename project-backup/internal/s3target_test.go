@@ -0,0 +1,244 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/pkg/library/backup/s3/s3test"
+)
+
+func newTestS3Target(t *testing.T, location string) (*s3Target, *s3test.Server) {
+	server := s3test.NewServer("backups")
+	t.Cleanup(server.Close)
+	target, err := newS3Target(location, &Options{
+		S3Endpoint:        server.URL,
+		S3AccessKeyID:     "access-key",
+		S3SecretAccessKey: "secret-key",
+	})
+	require.NoError(t, err)
+	return target, server
+}
+
+// objectKeys returns the keys of the objects stored in server, in lexical order.
+func objectKeys(server *s3test.Server) []string {
+	var keys []string
+	for key := range server.Objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestNewS3Target(t *testing.T) {
+	tests := []struct {
+		name          string
+		location      string
+		wantLocation  string
+		wantReference string
+		wantPath      string
+		wantErr       bool
+	}{
+		{
+			name:          "Location with tag",
+			location:      "s3://backups/test-ns/test-workspace:backup-20260101T000000Z",
+			wantLocation:  "s3://backups/test-ns/test-workspace",
+			wantReference: "backup-20260101T000000Z",
+			wantPath:      "test-ns/test-workspace",
+		},
+		{
+			name:          "Location with digest",
+			location:      "s3://backups/test-ns/test-workspace@sha256:0123",
+			wantLocation:  "s3://backups/test-ns/test-workspace",
+			wantReference: "sha256:0123",
+			wantPath:      "test-ns/test-workspace",
+		},
+		{
+			name:          "Location without tag defaults to latest",
+			location:      "s3://backups/prefix/test-ns/test-workspace/",
+			wantLocation:  "s3://backups/prefix/test-ns/test-workspace",
+			wantReference: BackupTag,
+			wantPath:      "prefix/test-ns/test-workspace",
+		},
+		{
+			name:     "Location without path",
+			location: "s3://backups:latest",
+			wantErr:  true,
+		},
+		{
+			name:     "Location without bucket",
+			location: "s3:///test-ns/test-workspace",
+			wantErr:  true,
+		},
+		{
+			name:     "Location with empty tag",
+			location: "s3://backups/test-ns/test-workspace:",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := newS3Target(tt.location, &Options{S3Endpoint: "http://localhost:9000"})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, backup.ExitCodeInvalidConfiguration, AsExitError(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLocation, target.Location())
+			assert.Equal(t, tt.wantReference, target.Reference())
+			assert.Equal(t, tt.wantPath, target.path)
+		})
+	}
+}
+
+func TestS3TargetResolve(t *testing.T) {
+	ctx := context.Background()
+	target, _ := newTestS3Target(t, "s3://backups/test-ns/test-workspace:latest")
+	manifest := pushManifest(t, target, "backup", "latest")
+
+	tests := []struct {
+		name      string
+		reference string
+		wantErr   error
+	}{
+		{
+			name:      "Tag",
+			reference: "latest",
+		},
+		{
+			name:      "Digest",
+			reference: manifest.Digest.String(),
+		},
+		{
+			name:      "Missing tag",
+			reference: "missing",
+			wantErr:   errdef.ErrNotFound,
+		},
+		{
+			name:      "Missing digest",
+			reference: "sha256:" + strings.Repeat("0", 64),
+			wantErr:   errdef.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc, err := target.Resolve(ctx, tt.reference)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, manifest.Digest, desc.Digest)
+			assert.Equal(t, manifest.Size, desc.Size)
+			assert.Equal(t, ocispec.MediaTypeImageManifest, desc.MediaType)
+		})
+	}
+}
+
+func TestS3TargetTags(t *testing.T) {
+	ctx := context.Background()
+	target, server := newTestS3Target(t, "s3://backups/test-ns/test-workspace:latest")
+	server.PageSize = 2
+	pushManifest(t, target, "backup-1", "backup-1", "backup-2", "latest")
+	// Tags of another workspace that shares the prefix are not listed
+	other, err := newS3Target("s3://backups/test-ns/test-workspace-2:latest", &Options{S3Endpoint: server.URL})
+	require.NoError(t, err)
+	pushManifest(t, other, "other", "other")
+
+	tests := []struct {
+		name string
+		last string
+		want []string
+	}{
+		{
+			name: "All tags",
+			want: []string{"backup-1", "backup-2", "latest"},
+		},
+		{
+			name: "Tags after last",
+			last: "backup-1",
+			want: []string{"backup-2", "latest"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tags []string
+			err := target.Tags(ctx, tt.last, func(page []string) error {
+				tags = append(tags, page...)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tags)
+		})
+	}
+}
+
+func TestS3TargetDelete(t *testing.T) {
+	ctx := context.Background()
+	target, server := newTestS3Target(t, "s3://backups/test-ns/test-workspace:latest")
+	pushLayerBlob := func(data string) ocispec.Descriptor {
+		desc, err := oras.PushBytes(ctx, target, BackupArchiveMediaType, []byte(data))
+		require.NoError(t, err)
+		return desc
+	}
+	sharedLayer := pushLayerBlob("shared")
+	oldLayer := pushLayerBlob("old")
+	newLayer := pushLayerBlob("new")
+	pack := func(layers ...ocispec.Descriptor) ocispec.Descriptor {
+		manifest, err := oras.PackManifest(ctx, target, oras.PackManifestVersion1_1, BackupArtifactType, oras.PackManifestOptions{Layers: layers})
+		require.NoError(t, err)
+		return manifest
+	}
+	oldManifest := pack(sharedLayer, oldLayer)
+	newManifest := pack(sharedLayer, newLayer)
+	require.NoError(t, target.Tag(ctx, oldManifest, "backup-1"))
+	require.NoError(t, target.Tag(ctx, oldManifest, "backup-1-copy"))
+	require.NoError(t, target.Tag(ctx, newManifest, "backup-2"))
+	require.NoError(t, target.Tag(ctx, newManifest, "latest"))
+
+	require.NoError(t, target.Delete(ctx, oldManifest))
+
+	wantKeys := []string{
+		target.blobKey(ocispec.DescriptorEmptyJSON.Digest),
+		target.blobKey(sharedLayer.Digest),
+		target.blobKey(newLayer.Digest),
+		target.blobKey(newManifest.Digest),
+		target.tagKey("backup-2"),
+		target.tagKey("latest"),
+	}
+	sort.Strings(wantKeys)
+	assert.Equal(t, wantKeys, objectKeys(server), "Blobs of remaining backups should be kept")
+
+	_, err := target.Resolve(ctx, "backup-1")
+	assert.ErrorIs(t, err, errdef.ErrNotFound)
+	exists, err := target.Exists(ctx, oldLayer)
+	require.NoError(t, err)
+	assert.False(t, exists, "Layer used only by the deleted backup should be removed")
+
+	err = target.Delete(ctx, oldManifest)
+	assert.ErrorIs(t, err, errdef.ErrNotFound)
+}
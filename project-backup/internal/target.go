@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+)
+
+// Target is a storage location for the backups of a workspace. Backups are stored as OCI artifacts: content-addressed
+// blobs for the archive layers and manifest of each backup, and tags that reference manifests.
+type Target interface {
+	content.Storage
+	content.Resolver
+	content.Tagger
+	content.Deleter
+	registry.TagLister
+
+	// Reference returns the tag or digest selected by the target's location, e.g. "latest"
+	Reference() string
+	// Location returns the location of the workspace's backups, without tag or digest
+	Location() string
+}
+
+// NewTarget returns the backup target for location, which is either a reference in an OCI registry (e.g.
+// "quay.io/org/ns/name:latest") or a location in S3-compatible object storage (e.g. "s3://bucket/ns/name:latest").
+func NewTarget(location string, opts *Options) (Target, error) {
+	if strings.HasPrefix(location, backup.S3LocationPrefix) {
+		return newS3Target(location, opts)
+	}
+	repo, err := NewRepository(location, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &registryTarget{repo}, nil
+}
+
+// registryTarget stores backups in an OCI registry.
+type registryTarget struct {
+	*remote.Repository
+}
+
+func (t *registryTarget) Reference() string {
+	return t.Repository.Reference.Reference
+}
+
+func (t *registryTarget) Location() string {
+	return fmt.Sprintf("%s/%s", t.Repository.Reference.Registry, t.Repository.Reference.Repository)
+}
+
+// formatReference returns the reference of the backup identified by tagOrDigest in target.
+func formatReference(target Target, tagOrDigest string) string {
+	if strings.Contains(tagOrDigest, ":") {
+		return target.Location() + "@" + tagOrDigest
+	}
+	return target.Location() + ":" + tagOrDigest
+}
@@ -0,0 +1,158 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// memoryTarget is an in-memory backup target. Deleting a manifest removes the tags that reference it, like deleting a
+// manifest from a registry does.
+type memoryTarget struct {
+	*memory.Store
+	reference string
+	tags      map[string]ocispec.Descriptor
+	deleted   map[digest.Digest]bool
+}
+
+func newMemoryTarget() *memoryTarget {
+	return &memoryTarget{
+		Store:     memory.New(),
+		reference: BackupTag,
+		tags:      map[string]ocispec.Descriptor{},
+		deleted:   map[digest.Digest]bool{},
+	}
+}
+
+func (t *memoryTarget) Reference() string {
+	return t.reference
+}
+
+func (t *memoryTarget) Location() string {
+	return "registry.example.com/test-ns/test-workspace"
+}
+
+func (t *memoryTarget) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	if t.deleted[target.Digest] {
+		return false, nil
+	}
+	return t.Store.Exists(ctx, target)
+}
+
+func (t *memoryTarget) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if t.deleted[target.Digest] {
+		return nil, fmt.Errorf("%s: %w", target.Digest, errdef.ErrNotFound)
+	}
+	return t.Store.Fetch(ctx, target)
+}
+
+func (t *memoryTarget) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	delete(t.deleted, expected.Digest)
+	if exists, _ := t.Store.Exists(ctx, expected); exists {
+		_, err := io.Copy(io.Discard, content)
+		return err
+	}
+	return t.Store.Push(ctx, expected, content)
+}
+
+func (t *memoryTarget) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	desc, ok := t.tags[reference]
+	if !ok {
+		return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+	}
+	return desc, nil
+}
+
+func (t *memoryTarget) Tag(ctx context.Context, desc ocispec.Descriptor, reference string) error {
+	if exists, err := t.Exists(ctx, desc); err != nil || !exists {
+		return fmt.Errorf("%s: %w", desc.Digest, errdef.ErrNotFound)
+	}
+	t.tags[reference] = desc
+	return nil
+}
+
+func (t *memoryTarget) Tags(_ context.Context, last string, fn func(tags []string) error) error {
+	var tags []string
+	for tag := range t.tags {
+		if tag > last {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return fn(tags)
+}
+
+func (t *memoryTarget) Delete(ctx context.Context, target ocispec.Descriptor) error {
+	if exists, err := t.Exists(ctx, target); err != nil || !exists {
+		return fmt.Errorf("%s: %w", target.Digest, errdef.ErrNotFound)
+	}
+	t.deleted[target.Digest] = true
+	for tag, desc := range t.tags {
+		if desc.Digest == target.Digest {
+			delete(t.tags, tag)
+		}
+	}
+	return nil
+}
+
+// pushManifest pushes a backup manifest without layers that is distinguished by name, and tags it with tags.
+func pushManifest(t *testing.T, target Target, name string, tags ...string) ocispec.Descriptor {
+	ctx := context.Background()
+	manifest, err := oras.PackManifest(ctx, target, oras.PackManifestVersion1_1, BackupArtifactType, oras.PackManifestOptions{
+		ManifestAnnotations: map[string]string{DevWorkspaceNameAnnotation: name},
+	})
+	require.NoError(t, err)
+	for _, tag := range tags {
+		require.NoError(t, target.Tag(ctx, manifest, tag))
+	}
+	return manifest
+}
+
+func TestFormatReference(t *testing.T) {
+	tests := []struct {
+		name        string
+		tagOrDigest string
+		want        string
+	}{
+		{
+			name:        "Tag",
+			tagOrDigest: "backup-20260101T000000Z",
+			want:        "registry.example.com/test-ns/test-workspace:backup-20260101T000000Z",
+		},
+		{
+			name:        "Digest",
+			tagOrDigest: "sha256:0123",
+			want:        "registry.example.com/test-ns/test-workspace@sha256:0123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatReference(newMemoryTarget(), tt.tagOrDigest))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/devfile/devworkspace-operator/pkg/library/backup"
+	"github.com/devfile/devworkspace-operator/project-backup/internal"
+)
+
+func main() {
+	doBackup := flag.Bool("backup", false, "Back up the workspace data in $BACKUP_SOURCE_PATH to the backup target")
+	doRestore := flag.Bool("restore", false, "Restore the workspace data in $BACKUP_IMAGE to $PROJECTS_ROOT")
+	doDelete := flag.Bool("delete", false, "Delete all backups of the workspace from the backup target")
+	flag.Parse()
+
+	modes := 0
+	for _, mode := range []bool{*doBackup, *doRestore, *doDelete} {
+		if mode {
+			modes++
+		}
+	}
+	if modes != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [--backup|--restore|--delete]\n", os.Args[0])
+		os.Exit(backup.ExitCodeInvalidConfiguration)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var err error
+	switch {
+	case *doBackup:
+		err = runBackup(ctx)
+	case *doRestore:
+		err = runRestore(ctx)
+	case *doDelete:
+		err = runDelete(ctx)
+	}
+	if err != nil {
+		exitErr := internal.AsExitError(err)
+		log.Printf("Error: %s", exitErr)
+		if err := internal.WriteTerminationMessage(exitErr.Error()); err != nil {
+			log.Printf("Failed to write termination message: %s", err)
+		}
+		cancel()
+		os.Exit(exitErr.Code)
+	}
+}
+
+func runBackup(ctx context.Context) error {
+	opts, err := internal.ReadBackupOptions()
+	if err != nil {
+		return err
+	}
+	result, err := internal.Backup(ctx, opts)
+	if err != nil {
+		return err
+	}
+	// Report the result of the backup to the controller through the termination message
+	message, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if err := internal.WriteTerminationMessage(string(message)); err != nil {
+		log.Printf("Failed to write termination message: %s", err)
+	}
+	return nil
+}
+
+func runRestore(ctx context.Context) error {
+	opts, err := internal.ReadRestoreOptions()
+	if err != nil {
+		return err
+	}
+	return internal.Restore(ctx, opts)
+}
+
+func runDelete(ctx context.Context) error {
+	opts, err := internal.ReadDeleteOptions()
+	if err != nil {
+		return err
+	}
+	_, err = internal.DeleteAll(ctx, opts)
+	return err
+}
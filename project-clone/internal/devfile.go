@@ -25,11 +25,16 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/devfile/devworkspace-operator/pkg/provision/metadata"
+	"github.com/devfile/devworkspace-operator/project-clone/internal/shell"
 )
 
 const (
 	ProjectSparseCheckout = "sparseCheckout"
 	ProjectSubDir         = "subDir"
+	ProjectDepth          = "depth"
+	ProjectFilter         = "filter"
+	ProjectSingleBranch   = "singleBranch"
+	ProjectLFS            = "lfs"
 )
 
 // ReadFlattenedDevWorkspace reads the flattened DevWorkspaceTemplateSpec from disk. The location of the flattened
@@ -54,6 +59,47 @@ func ReadFlattenedDevWorkspace() (*dw.DevWorkspaceTemplateSpec, error) {
 	return dwts, nil
 }
 
+// GetCloneOptions returns the options for cloning a git project. Options defined via the project's attributes
+// override the defaults set in DefaultCloneOptions.
+func GetCloneOptions(project *dw.Project) (shell.CloneOptions, error) {
+	options := DefaultCloneOptions
+	var err error
+	if project.Attributes.Exists(ProjectDepth) {
+		depth := project.Attributes.GetNumber(ProjectDepth, &err)
+		if err != nil || depth < 0 {
+			return options, fmt.Errorf("invalid value for %s attribute on project %s: must be a non-negative number", ProjectDepth, project.Name)
+		}
+		options.Depth = int(depth)
+	}
+	if project.Attributes.Exists(ProjectFilter) {
+		options.Filter = project.Attributes.GetString(ProjectFilter, &err)
+		if err != nil {
+			return options, fmt.Errorf("failed to read %s attribute on project %s: %w", ProjectFilter, project.Name, err)
+		}
+	}
+	if project.Attributes.Exists(ProjectSingleBranch) {
+		options.SingleBranch = project.Attributes.GetBoolean(ProjectSingleBranch, &err)
+		if err != nil {
+			return options, fmt.Errorf("failed to read %s attribute on project %s: %w", ProjectSingleBranch, project.Name, err)
+		}
+	}
+	return options, nil
+}
+
+// IsLFSEnabled returns whether Git LFS objects should be fetched for a git project. Fetching Git LFS objects is
+// enabled unless disabled via the project's attributes.
+func IsLFSEnabled(project *dw.Project) (bool, error) {
+	if !project.Attributes.Exists(ProjectLFS) {
+		return true, nil
+	}
+	var err error
+	enabled := project.Attributes.GetBoolean(ProjectLFS, &err)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s attribute on project %s: %w", ProjectLFS, project.Name, err)
+	}
+	return enabled, nil
+}
+
 // StarterProjectToRegularProject converts a starter project defined in a DevWorkspace to a standard Project for
 // easier handling
 func StarterProjectToRegularProject(starterProject *dw.StarterProject) dw.Project {
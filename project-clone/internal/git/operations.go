@@ -30,11 +30,36 @@ import (
 )
 
 // CloneProject clones the project to path specified by projectPath
-func CloneProject(project *dw.Project, projectPath string) error {
-	log.Printf("Cloning project %s to %s", project.Name, projectPath)
+func CloneProject(project *dw.Project, projectPath string, options shell.CloneOptions, logger *log.Logger) error {
+	logger.Printf("Cloning project %s to %s", project.Name, projectPath)
 
+	defaultRemoteName, defaultRemoteURL, err := getCheckoutRemote(project)
+	if err != nil {
+		return err
+	}
+
+	if project.Attributes.Exists(internal.ProjectSparseCheckout) {
+		if err := shell.GitSparseCloneProject(logger, defaultRemoteURL, defaultRemoteName, projectPath, options); err != nil {
+			return fmt.Errorf("failed to sparsely git clone from %s: %s", defaultRemoteURL, err)
+		}
+	} else {
+		// Delegate to standard git binary because git.PlainClone takes a lot of memory for large repos
+		err := shell.GitCloneProject(logger, defaultRemoteURL, defaultRemoteName, projectPath, options)
+		if err != nil {
+			return fmt.Errorf("failed to git clone from %s: %s", defaultRemoteURL, err)
+		}
+
+	}
+
+	logger.Printf("Cloned project %s to %s", project.Name, projectPath)
+	return nil
+}
+
+// getCheckoutRemote returns the name and URL of the remote that the project is cloned from, i.e. the remote referenced
+// by checkoutFrom or the project's only remote.
+func getCheckoutRemote(project *dw.Project) (string, string, error) {
 	if len(project.Git.Remotes) == 0 {
-		return fmt.Errorf("project does not define remotes")
+		return "", "", fmt.Errorf("project does not define remotes")
 	}
 
 	var defaultRemoteName, defaultRemoteURL string
@@ -48,42 +73,27 @@ func CloneProject(project *dw.Project, projectPath string) error {
 				}
 			} else {
 				// need to specify
-				return fmt.Errorf("project checkoutFrom remote can't be omitted with multiple remotes")
+				return "", "", fmt.Errorf("project checkoutFrom remote can't be omitted with multiple remotes")
 			}
 		}
 		remoteURL, ok := project.Git.Remotes[defaultRemoteName]
 		if !ok {
-			return fmt.Errorf("project checkoutFrom refers to non-existing remote %s", defaultRemoteName)
+			return "", "", fmt.Errorf("project checkoutFrom refers to non-existing remote %s", defaultRemoteName)
 		}
 		defaultRemoteURL = remoteURL
 	} else {
 		if len(project.Git.Remotes) > 1 {
-			return fmt.Errorf("project checkoutFrom field is required when a project defines multiple remotes")
+			return "", "", fmt.Errorf("project checkoutFrom field is required when a project defines multiple remotes")
 		}
 		for remoteName, remoteUrl := range project.Git.Remotes {
 			defaultRemoteName, defaultRemoteURL = remoteName, remoteUrl
 		}
 	}
-
-	if project.Attributes.Exists(internal.ProjectSparseCheckout) {
-		if err := shell.GitSparseCloneProject(defaultRemoteURL, defaultRemoteName, projectPath); err != nil {
-			return fmt.Errorf("failed to sparsely git clone from %s: %s", defaultRemoteURL, err)
-		}
-	} else {
-		// Delegate to standard git binary because git.PlainClone takes a lot of memory for large repos
-		err := shell.GitCloneProject(defaultRemoteURL, defaultRemoteName, projectPath)
-		if err != nil {
-			return fmt.Errorf("failed to git clone from %s: %s", defaultRemoteURL, err)
-		}
-
-	}
-
-	log.Printf("Cloned project %s to %s", project.Name, projectPath)
-	return nil
+	return defaultRemoteName, defaultRemoteURL, nil
 }
 
-func SetupSparseCheckout(project *dw.Project, projectPath string) error {
-	log.Printf("Setting up sparse checkout for project %s", project.Name)
+func SetupSparseCheckout(project *dw.Project, projectPath string, logger *log.Logger) error {
+	logger.Printf("Setting up sparse checkout for project %s", project.Name)
 
 	var err error
 	sparseCheckoutDir := project.Attributes.GetString(internal.ProjectSparseCheckout, &err)
@@ -93,16 +103,17 @@ func SetupSparseCheckout(project *dw.Project, projectPath string) error {
 	if sparseCheckoutDir == "" {
 		return nil
 	}
-	if err := shell.GitSetupSparseCheckout(projectPath, sparseCheckoutDir); err != nil {
+	if err := shell.GitSetupSparseCheckout(logger, projectPath, sparseCheckoutDir); err != nil {
 		return fmt.Errorf("error running sparse-checkout set: %w", err)
 	}
 
 	return nil
 }
 
-// SetupRemotes sets up a git remote in repo for each remote in project.Git.Remotes
-func SetupRemotes(repo *git.Repository, project *dw.Project, projectPath string) error {
-	log.Printf("Setting up remotes for project %s", project.Name)
+// SetupRemotes sets up a git remote in repo for each remote in project.Git.Remotes. If fetchDepth is greater than
+// zero, the history fetched from each remote is truncated to fetchDepth commits.
+func SetupRemotes(repo *git.Repository, project *dw.Project, projectPath string, fetchDepth int, logger *log.Logger) error {
+	logger.Printf("Setting up remotes for project %s", project.Name)
 	for remoteName, remoteUrl := range project.Git.Remotes {
 		_, err := repo.CreateRemote(&gitConfig.RemoteConfig{
 			Name: remoteName,
@@ -111,29 +122,64 @@ func SetupRemotes(repo *git.Repository, project *dw.Project, projectPath string)
 		if err != nil && err != git.ErrRemoteExists {
 			return fmt.Errorf("failed to add remote %s: %s", remoteName, err)
 		}
-		err = shell.GitFetchRemote(projectPath, remoteName)
+		err = shell.GitFetchRemote(logger, projectPath, remoteName, fetchDepth)
 		if err != nil {
 			return fmt.Errorf("failed to fetch from remote %s: %s", remoteUrl, err)
 		}
-		log.Printf("Fetched remote %s at %s", remoteName, remoteUrl)
+		logger.Printf("Fetched remote %s at %s", remoteName, remoteUrl)
 	}
 	return nil
 }
 
-func SetupSubmodules(project *dw.Project, projectPath string) error {
+func SetupSubmodules(project *dw.Project, projectPath string, logger *log.Logger) error {
 	if _, err := os.Stat(path.Join(projectPath, ".gitmodules")); os.IsNotExist(err) {
 		// No submodules; do nothing
 		return nil
 	}
-	log.Printf("Initializing submodules for project %s", project.Name)
-	if err := shell.GitInitSubmodules(projectPath); err != nil {
+	logger.Printf("Initializing submodules for project %s", project.Name)
+	if err := shell.GitInitSubmodules(logger, projectPath); err != nil {
 		return fmt.Errorf("git submodule update --init --recursive failed: %s", err)
 	}
 	return nil
 }
 
-// CheckoutReference sets the current HEAD in repo to point at the revision and remote referenced by checkoutFrom
-func CheckoutReference(project *dw.Project, projectPath string) error {
+// SetupLFS fetches the Git LFS objects required by the checked out commit if the project uses Git LFS and fetching
+// Git LFS objects is not disabled via the project's attributes. Objects are fetched from the remote the project was
+// cloned from using the git binary, so the same credentials and certificates are used as when cloning the project.
+func SetupLFS(project *dw.Project, projectPath string, logger *log.Logger) error {
+	enabled, err := internal.IsLFSEnabled(project)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		logger.Printf("Fetching Git LFS objects is disabled for project %s", project.Name)
+		return nil
+	}
+	usesLFS, err := shell.GitUsesLFS(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to read .gitattributes: %s", err)
+	}
+	if !usesLFS {
+		return nil
+	}
+	remote, _, err := getCheckoutRemote(project)
+	if err != nil {
+		return err
+	}
+	logger.Printf("Fetching Git LFS objects for project %s", project.Name)
+	if err := shell.GitLFSInstall(logger, projectPath); err != nil {
+		return fmt.Errorf("git lfs install failed: %s", err)
+	}
+	if err := shell.GitLFSPull(logger, projectPath, remote); err != nil {
+		return fmt.Errorf("git lfs pull failed: %s", err)
+	}
+	return nil
+}
+
+// CheckoutReference sets the current HEAD in repo to point at the revision and remote referenced by checkoutFrom.
+// If the project was cloned with partial history (i.e. a shallow or single-branch clone) and the revision is not
+// present in the cloned repository, the revision is fetched from the remote first.
+func CheckoutReference(project *dw.Project, projectPath string, options shell.CloneOptions, logger *log.Logger) error {
 	checkoutFrom := project.Git.CheckoutFrom
 	if checkoutFrom == nil || checkoutFrom.Revision == "" {
 		return nil
@@ -153,57 +199,89 @@ func CheckoutReference(project *dw.Project, projectPath string) error {
 	if err != nil {
 		return fmt.Errorf("failed to resolve git revision %s: %w", revision, err)
 	}
+	if refType == shell.GitRefUnknown && options.IsPartialHistory() {
+		refType, err = fetchRevision(projectPath, defaultRemoteName, revision, options.Depth, logger)
+		if err != nil {
+			return fmt.Errorf("failed to resolve git revision %s: %w", revision, err)
+		}
+	}
 	switch refType {
 	case shell.GitRefLocalBranch:
-		return checkoutLocalBranch(projectPath, revision, defaultRemoteName)
+		return checkoutLocalBranch(projectPath, revision, defaultRemoteName, logger)
 	case shell.GitRefRemoteBranch:
-		return checkoutRemoteBranch(projectPath, revision, defaultRemoteName)
+		return checkoutRemoteBranch(projectPath, revision, defaultRemoteName, logger)
 	case shell.GitRefTag:
-		return checkoutTag(projectPath, revision)
+		return checkoutTag(projectPath, revision, logger)
 	case shell.GitRefHash:
-		return checkoutCommit(projectPath, revision)
+		return checkoutCommit(projectPath, revision, logger)
 	default:
-		log.Printf("Could not find revision %s in repository, using default branch", checkoutFrom.Revision)
+		logger.Printf("Could not find revision %s in repository, using default branch", checkoutFrom.Revision)
 		return nil
 	}
 }
 
-func checkoutLocalBranch(projectPath, branchName, remote string) error {
-	log.Printf("Checking out local branch %s", branchName)
-	if err := shell.GitCheckoutBranchLocal(projectPath, branchName); err != nil {
+// fetchRevision fetches a revision that is not present in a repository cloned with partial history. The revision
+// is fetched as a branch, tag, or commit hash, in that order, and the type of reference fetched is returned.
+// Returns GitRefUnknown if the revision could not be fetched.
+func fetchRevision(projectPath, remote, revision string, depth int, logger *log.Logger) (shell.GitRefType, error) {
+	logger.Printf("Revision %s is not present in cloned history, fetching from remote %s", revision, remote)
+	refspecs := []string{
+		fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", revision, remote, revision),
+		fmt.Sprintf("+refs/tags/%s:refs/tags/%s", revision, revision),
+		revision,
+	}
+	for _, refspec := range refspecs {
+		if err := shell.GitFetchRefspec(projectPath, remote, refspec, depth); err != nil {
+			continue
+		}
+		refType, err := shell.GitResolveReference(projectPath, remote, revision)
+		if err != nil {
+			return shell.GitRefUnknown, err
+		}
+		if refType != shell.GitRefUnknown {
+			logger.Printf("Fetched revision %s from remote %s", revision, remote)
+			return refType, nil
+		}
+	}
+	return shell.GitRefUnknown, nil
+}
+
+func checkoutLocalBranch(projectPath, branchName, remote string, logger *log.Logger) error {
+	logger.Printf("Checking out local branch %s", branchName)
+	if err := shell.GitCheckoutBranchLocal(logger, projectPath, branchName); err != nil {
 		return fmt.Errorf("failed to checkout branch %s: %s", branchName, err)
 	}
 
-	log.Printf("Setting tracking remote for branch %s to %s", branchName, remote)
-	if err := shell.GitSetTrackingRemoteBranch(projectPath, branchName, remote); err != nil {
+	logger.Printf("Setting tracking remote for branch %s to %s", branchName, remote)
+	if err := shell.GitSetTrackingRemoteBranch(logger, projectPath, branchName, remote); err != nil {
 		return fmt.Errorf("failed to set tracking for branch %s: %w", branchName, err)
 	}
 
 	return nil
 }
 
-func checkoutRemoteBranch(projectPath, branchName, remote string) error {
-	log.Printf("Checking out remote branch %s", branchName)
+func checkoutRemoteBranch(projectPath, branchName, remote string, logger *log.Logger) error {
+	logger.Printf("Checking out remote branch %s", branchName)
 
-	if err := shell.GitCheckoutBranch(projectPath, branchName, remote); err != nil {
+	if err := shell.GitCheckoutBranch(logger, projectPath, branchName, remote); err != nil {
 		return fmt.Errorf("failed to checkout branch %s: %s", branchName, err)
 	}
 	return nil
 }
 
-func checkoutTag(projectPath, tagName string) error {
-	log.Printf("Checking out tag %s", tagName)
+func checkoutTag(projectPath, tagName string, logger *log.Logger) error {
+	logger.Printf("Checking out tag %s", tagName)
 
-	if err := shell.GitCheckoutRef(projectPath, tagName); err != nil {
+	if err := shell.GitCheckoutRef(logger, projectPath, tagName); err != nil {
 		return fmt.Errorf("failed to checkout tag %s: %s", tagName, err)
 	}
 	return nil
 }
 
-func checkoutCommit(projectPath, hash string) error {
-	log.Printf("Checking out commit %s", hash)
+func checkoutCommit(projectPath, hash string, logger *log.Logger) error {
+	logger.Printf("Checking out commit %s", hash)
 
-	if err := shell.GitCheckoutRef(projectPath, hash); err != nil {
+	if err := shell.GitCheckoutRef(logger, projectPath, hash); err != nil {
 		return fmt.Errorf("failed to checkout commit %s: %s", hash, err)
 	}
 	return nil
@@ -26,89 +26,131 @@ import (
 	projectslib "github.com/devfile/devworkspace-operator/pkg/library/projects"
 
 	"github.com/devfile/devworkspace-operator/project-clone/internal"
+	"github.com/devfile/devworkspace-operator/project-clone/internal/shell"
 )
 
-func SetupGitProject(project dw.Project) error {
+// SetupResult is the outcome of setting up a git project
+type SetupResult struct {
+	// GitCache reports whether a mirror of the project was used if the project is cloned while the git mirror cache
+	// is available
+	GitCache projectslib.GitCacheResult
+	// Warnings are problems encountered while setting up the project that did not prevent it from being set up
+	Warnings []string
+}
+
+// SetupGitProject clones or updates a git project, writing its output to logger.
+func SetupGitProject(project dw.Project, logger *log.Logger) (SetupResult, error) {
 	needClone, needRemotes, err := internal.CheckProjectState(&project)
 	if err != nil {
-		return fmt.Errorf("failed to check state of repo on disk: %s", err)
+		return SetupResult{}, fmt.Errorf("failed to check state of repo on disk: %s", err)
 	}
 	if needClone {
-		return doInitialGitClone(&project)
+		return doInitialGitClone(&project, logger)
 	} else if needRemotes {
-		return setupRemotesForExistingProject(&project)
+		return SetupResult{}, setupRemotesForExistingProject(&project, logger)
 	} else {
-		log.Printf("Project '%s' is already cloned and has all remotes configured", project.Name)
-		return nil
+		logger.Printf("Project '%s' is already cloned and has all remotes configured", project.Name)
+		return SetupResult{}, nil
 	}
 }
 
-func doInitialGitClone(project *dw.Project) error {
+func doInitialGitClone(project *dw.Project, logger *log.Logger) (SetupResult, error) {
 	// Clone into a temp dir and then move set up project to PROJECTS_ROOT to try and make clone atomic in case
 	// project-clone container is terminated
-	tmpClonePath := path.Join(internal.CloneTmpDir, projectslib.GetClonePath(project))
+	tmpClonePath := path.Join(internal.ProjectTmpDir(project), projectslib.GetClonePath(project))
+	cloneOptions, err := internal.GetCloneOptions(project)
+	if err != nil {
+		return SetupResult{}, err
+	}
+	result := SetupResult{GitCache: useGitMirror(project, &cloneOptions, logger)}
 	if err := os.MkdirAll(path.Dir(tmpClonePath), 0755); err != nil {
-		return fmt.Errorf("failed to create parent directories for temp clone path %s: %w", tmpClonePath, err)
+		return result, fmt.Errorf("failed to create parent directories for temp clone path %s: %w", tmpClonePath, err)
 	}
 	var cloneErr error
 	for attempt := 0; attempt <= internal.CloneRetries; attempt++ {
 		if attempt > 0 {
-			delayBeforeRetry(project.Name, attempt)
+			delayBeforeRetry(project.Name, attempt, logger)
 			if err := os.RemoveAll(tmpClonePath); err != nil {
-				log.Printf("Warning: cleanup before retry failed: %s", err)
+				logger.Printf("Warning: cleanup before retry failed: %s", err)
 			}
 		}
-		cloneErr = CloneProject(project, tmpClonePath)
+		cloneErr = CloneProject(project, tmpClonePath, cloneOptions, logger)
 		if cloneErr == nil {
 			break
 		}
 		if attempt < internal.CloneRetries {
-			log.Printf("Failed git clone for project %s (attempt %d/%d): %s", project.Name, attempt+1, internal.CloneRetries+1, cloneErr)
+			logger.Printf("Failed git clone for project %s (attempt %d/%d): %s", project.Name, attempt+1, internal.CloneRetries+1, cloneErr)
 		}
 	}
 	if cloneErr != nil {
-		return fmt.Errorf("failed to clone project: %w", cloneErr)
+		return result, fmt.Errorf("failed to clone project: %w", cloneErr)
 	}
 
 	if project.Attributes.Exists(internal.ProjectSparseCheckout) {
-		if err := SetupSparseCheckout(project, tmpClonePath); err != nil {
-			return fmt.Errorf("failed to set up sparse checkout on project %s: %w", project.Name, err)
+		if err := SetupSparseCheckout(project, tmpClonePath, logger); err != nil {
+			return result, fmt.Errorf("failed to set up sparse checkout on project %s: %w", project.Name, err)
 		}
 	}
 
 	repo, err := internal.OpenRepo(tmpClonePath)
 	if err != nil {
-		return fmt.Errorf("failed to open existing project in filesystem: %s", err)
+		return result, fmt.Errorf("failed to open existing project in filesystem: %s", err)
 	} else if repo == nil {
-		return fmt.Errorf("unexpected error while setting up remotes for project: git repository not present")
+		return result, fmt.Errorf("unexpected error while setting up remotes for project: git repository not present")
 	}
 
-	if err := SetupRemotes(repo, project, tmpClonePath); err != nil {
-		return fmt.Errorf("failed to set up remotes for project: %s", err)
+	if err := SetupRemotes(repo, project, tmpClonePath, cloneOptions.Depth, logger); err != nil {
+		return result, fmt.Errorf("failed to set up remotes for project: %s", err)
 	}
 
-	if err := CheckoutReference(project, tmpClonePath); err != nil {
-		return fmt.Errorf("failed to checkout revision: %s", err)
+	if err := CheckoutReference(project, tmpClonePath, cloneOptions, logger); err != nil {
+		return result, fmt.Errorf("failed to checkout revision: %s", err)
 	}
 
-	if err := SetupSubmodules(project, tmpClonePath); err != nil {
-		log.Printf("Failed to set up submodules in project: %s", err)
+	if err := SetupSubmodules(project, tmpClonePath, logger); err != nil {
+		logger.Printf("Failed to set up submodules in project: %s", err)
 	}
 
-	if err := copyProjectFromTmpDir(project, tmpClonePath); err != nil {
-		return err
+	if err := SetupLFS(project, tmpClonePath, logger); err != nil {
+		logger.Printf("Failed to fetch Git LFS objects for project %s: %s", project.Name, err)
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to fetch Git LFS objects: %s", err))
 	}
 
-	return nil
+	if err := copyProjectFromTmpDir(project, tmpClonePath, logger); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// useGitMirror sets the mirror of the project's remote in the git mirror cache, if any, as a reference in options.
+// Returns whether a mirror was found, or an empty result if the git mirror cache is not available.
+func useGitMirror(project *dw.Project, options *shell.CloneOptions, logger *log.Logger) projectslib.GitCacheResult {
+	if internal.GitCachePath == "" {
+		return ""
+	}
+	_, remoteURL, err := getCheckoutRemote(project)
+	if err != nil {
+		// Reported when cloning the project
+		return ""
+	}
+	mirrorPath, ok := internal.GetGitMirrorPath(remoteURL)
+	if !ok {
+		logger.Printf("No git mirror available for project %s", project.Name)
+		return projectslib.GitCacheMiss
+	}
+	logger.Printf("Using git mirror %s for project %s", mirrorPath, project.Name)
+	options.Reference = mirrorPath
+	return projectslib.GitCacheHit
 }
 
-func delayBeforeRetry(projectName string, attempt int) {
+func delayBeforeRetry(projectName string, attempt int, logger *log.Logger) {
 	delay := internal.BaseRetryDelay * (1 << (attempt - 1))
-	log.Printf("Retrying git clone for project %s (attempt %d/%d) after %s", projectName, attempt+1, internal.CloneRetries+1, delay)
+	logger.Printf("Retrying git clone for project %s (attempt %d/%d) after %s", projectName, attempt+1, internal.CloneRetries+1, delay)
 	time.Sleep(delay)
 }
 
-func setupRemotesForExistingProject(project *dw.Project) error {
+func setupRemotesForExistingProject(project *dw.Project, logger *log.Logger) error {
 	projectPath := path.Join(internal.ProjectsRoot, projectslib.GetClonePath(project))
 	repo, err := internal.OpenRepo(projectPath)
 	if err != nil {
@@ -116,13 +158,13 @@ func setupRemotesForExistingProject(project *dw.Project) error {
 	} else if repo == nil {
 		return fmt.Errorf("unexpected error while setting up remotes for project: git repository not present")
 	}
-	if err := SetupRemotes(repo, project, projectPath); err != nil {
+	if err := SetupRemotes(repo, project, projectPath, 0, logger); err != nil {
 		return fmt.Errorf("failed to set up remotes for project: %s", err)
 	}
 	return nil
 }
 
-func copyProjectFromTmpDir(project *dw.Project, tmpClonePath string) error {
+func copyProjectFromTmpDir(project *dw.Project, tmpClonePath string, logger *log.Logger) error {
 	projectPath := path.Join(internal.ProjectsRoot, projectslib.GetClonePath(project))
 	if err := os.MkdirAll(path.Dir(projectPath), 0755); err != nil {
 		return fmt.Errorf("failed to create parent directories for project path %s: %w", projectPath, err)
@@ -136,12 +178,12 @@ func copyProjectFromTmpDir(project *dw.Project, tmpClonePath string) error {
 			return fmt.Errorf("failed to process subDir on project: %w", err)
 		}
 		subDirPath := path.Join(tmpClonePath, subDirSubPath)
-		log.Printf("Moving subdirectory %s in project %s from temporary directory to %s", subDirSubPath, project.Name, projectPath)
+		logger.Printf("Moving subdirectory %s in project %s from temporary directory to %s", subDirSubPath, project.Name, projectPath)
 		if err := os.Rename(subDirPath, projectPath); err != nil {
 			return fmt.Errorf("failed to move subdirectory of cloned project to %s: %w", internal.ProjectsRoot, err)
 		}
 	} else {
-		log.Printf("Moving cloned project %s from temporary directory %s to %s", project.Name, tmpClonePath, projectPath)
+		logger.Printf("Moving cloned project %s from temporary directory %s to %s", project.Name, tmpClonePath, projectPath)
 		if err := os.Rename(tmpClonePath, projectPath); err != nil {
 			return fmt.Errorf("failed to move cloned project to %s: %w", internal.ProjectsRoot, err)
 		}
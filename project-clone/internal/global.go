@@ -20,11 +20,16 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path"
 	"regexp"
 	"strconv"
 	"time"
 
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
 	"github.com/devfile/devworkspace-operator/pkg/library/constants"
+	projectslib "github.com/devfile/devworkspace-operator/pkg/library/projects"
+	"github.com/devfile/devworkspace-operator/project-clone/internal/shell"
 	gittransport "github.com/go-git/go-git/v5/plumbing/transport"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
@@ -36,8 +41,11 @@ const (
 	sshConfigMountPath   = "/etc/ssh/ssh_config"
 	publicCertsDir       = "/public-certs"
 	cloneRetriesEnvVar   = "PROJECT_CLONE_RETRIES"
+	lfsSkipSmudgeEnvVar  = "GIT_LFS_SKIP_SMUDGE"
 	defaultCloneRetries  = 3
 	maxCloneRetries      = 10
+	defaultParallelism   = 1
+	maxParallelism       = 16
 	BaseRetryDelay       = 1 * time.Second
 )
 
@@ -45,8 +53,14 @@ var (
 	ProjectsRoot     string
 	CloneTmpDir      string
 	CloneRetries     int
+	CloneParallelism int
 	tokenAuthMethod  map[string]*githttp.BasicAuth
 	credentialsRegex = regexp.MustCompile(`https://(.+):(.+)@(.+)`)
+
+	// DefaultCloneOptions are the clone options used for git projects that do not override them via attributes
+	DefaultCloneOptions shell.CloneOptions
+	// GitCachePath is the path where the git mirror cache is mounted. Empty if the git mirror cache is not available.
+	GitCachePath string
 )
 
 // Read and store ProjectsRoot env var for reuse throughout project-clone.
@@ -79,7 +93,72 @@ func init() {
 		}
 	}
 
+	CloneParallelism = defaultParallelism
+	if val := os.Getenv(dwconstants.ProjectCloneParallelism); val != "" {
+		parsed, err := strconv.Atoi(val)
+		if err != nil || parsed < 1 {
+			log.Printf("Invalid value for %s: %q, using default (%d)", dwconstants.ProjectCloneParallelism, val, defaultParallelism)
+		} else if parsed > maxParallelism {
+			log.Printf("Value for %s (%d) exceeds maximum (%d), using maximum", dwconstants.ProjectCloneParallelism, parsed, maxParallelism)
+			CloneParallelism = maxParallelism
+		} else {
+			CloneParallelism = parsed
+		}
+	}
+
+	if val := os.Getenv(dwconstants.ProjectCloneDepth); val != "" {
+		parsed, err := strconv.Atoi(val)
+		if err != nil || parsed < 0 {
+			log.Printf("Invalid value for %s: %q, cloning full history by default", dwconstants.ProjectCloneDepth, val)
+		} else {
+			DefaultCloneOptions.Depth = parsed
+		}
+	}
+	DefaultCloneOptions.Filter = os.Getenv(dwconstants.ProjectCloneFilter)
+	if val := os.Getenv(dwconstants.ProjectCloneSingleBranch); val != "" {
+		parsed, err := strconv.ParseBool(val)
+		if err != nil {
+			log.Printf("Invalid value for %s: %q, cloning all branches by default", dwconstants.ProjectCloneSingleBranch, val)
+		} else {
+			DefaultCloneOptions.SingleBranch = parsed
+		}
+	}
+
+	GitCachePath = os.Getenv(dwconstants.ProjectCloneGitCachePath)
+
+	// Git LFS objects are fetched explicitly once a project is checked out, so that failing to fetch them does not
+	// fail cloning the project when the Git LFS filter is configured as required
+	if err := os.Setenv(lfsSkipSmudgeEnvVar, "1"); err != nil {
+		log.Printf("Failed to set %s: %s", lfsSkipSmudgeEnvVar, err)
+	}
+
 	setupAuth()
+	if err := setupSSH(); err != nil {
+		log.Printf("Failed to set up SSH key for cloning projects: %s", err)
+	}
+}
+
+// ProjectTmpDir returns the temporary directory used for setting up a project. Each project uses a separate
+// directory within CloneTmpDir so that projects can be set up concurrently.
+func ProjectTmpDir(project *dw.Project) string {
+	return path.Join(CloneTmpDir, project.Name)
+}
+
+// GetGitMirrorPath returns the path of the mirror of a git remote in the git mirror cache. Returns false if the git
+// mirror cache is not available or does not contain a mirror for the remote.
+func GetGitMirrorPath(remoteURL string) (string, bool) {
+	if GitCachePath == "" {
+		return "", false
+	}
+	mirrorName, err := projectslib.GetGitMirrorName(remoteURL)
+	if err != nil {
+		return "", false
+	}
+	mirrorPath := path.Join(GitCachePath, mirrorName)
+	if exists, err := DirExists(mirrorPath); err != nil || !exists {
+		return "", false
+	}
+	return mirrorPath, true
 }
 
 func GetAuthForHost(repoURLStr string) (gittransport.AuthMethod, error) {
@@ -92,6 +171,7 @@ func GetAuthForHost(repoURLStr string) (gittransport.AuthMethod, error) {
 		identityFiles := ssh_config.GetAll(endpoint.Host, "IdentityFile")
 		if len(identityFiles) == 0 {
 			log.Printf("No SSH key found for host %s", endpoint.Host)
+			return nil, nil
 		} else if len(identityFiles) > 1 {
 			// Probably should try all keys, one by one, in the future
 			log.Printf("Warning: multiple SSH keys found for host %s. Using first match.", endpoint.Host)
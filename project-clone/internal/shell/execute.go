@@ -16,9 +16,11 @@
 package shell
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os/exec"
+	"strconv"
 )
 
 type GitRefType int64
@@ -31,53 +33,112 @@ const (
 	GitRefHash
 )
 
+// CloneOptions are additional options used when cloning a git project
+type CloneOptions struct {
+	// Depth truncates the cloned history to the specified number of commits. Zero means full history.
+	Depth int
+	// Filter is the partial clone filter, e.g. 'blob:none'
+	Filter string
+	// SingleBranch clones only the history of the default branch
+	SingleBranch bool
+	// Reference is the path to a local mirror of the repository. Objects available in the mirror are copied from it
+	// instead of being downloaded from the remote.
+	Reference string
+}
+
+// IsPartialHistory returns whether cloning with these options can result in a repository that does not contain
+// all branches, tags and commits of the remote.
+func (o CloneOptions) IsPartialHistory() bool {
+	return o.Depth > 0 || o.SingleBranch
+}
+
+func (o CloneOptions) args() []string {
+	var args []string
+	if o.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(o.Depth))
+	}
+	if o.Filter != "" {
+		args = append(args, "--filter", o.Filter)
+	}
+	if o.SingleBranch {
+		args = append(args, "--single-branch")
+	} else if o.Depth > 0 {
+		// --depth implies --single-branch unless --no-single-branch is passed
+		args = append(args, "--no-single-branch")
+	}
+	if o.Reference != "" {
+		// Objects are copied from the mirror, as it is not available to the workspace once the project is cloned
+		args = append(args, "--reference-if-able", o.Reference, "--dissociate")
+	}
+	return args
+}
+
 // GitCloneProject constructs a command-line string for cloning a git project, and delegates execution
 // to the os/exec package.
-func GitCloneProject(repoUrl, defaultRemoteName, destPath string) error {
-	args := []string{
-		"clone",
+func GitCloneProject(logger *log.Logger, repoUrl, defaultRemoteName, destPath string, options CloneOptions) error {
+	args := []string{"clone"}
+	args = append(args, options.args()...)
+	args = append(args,
 		repoUrl,
 		"--origin", defaultRemoteName,
 		"--",
 		destPath,
-	}
-	return executeCommand("git", args...)
+	)
+	return executeCommand(logger, "git", args...)
 }
 
-func GitSparseCloneProject(repoUrl, defaultRemoteName, destPath string) error {
-	args := []string{
-		"clone",
-		"--sparse",
+func GitSparseCloneProject(logger *log.Logger, repoUrl, defaultRemoteName, destPath string, options CloneOptions) error {
+	args := []string{"clone", "--sparse"}
+	args = append(args, options.args()...)
+	args = append(args,
 		repoUrl,
 		"--origin", defaultRemoteName,
 		"--",
 		destPath,
-	}
-	return executeCommand("git", args...)
+	)
+	return executeCommand(logger, "git", args...)
 }
 
-func GitSetupSparseCheckout(projectPath string, sparseCheckoutDir string) error {
-	return executeCommand("git", "-C", projectPath, "sparse-checkout", "set", sparseCheckoutDir)
+func GitSetupSparseCheckout(logger *log.Logger, projectPath string, sparseCheckoutDir string) error {
+	return executeCommand(logger, "git", "-C", projectPath, "sparse-checkout", "set", sparseCheckoutDir)
 }
 
-func GitFetchRemote(projectPath, remote string) error {
-	return executeCommand("git", "-C", projectPath, "fetch", remote)
+// GitFetchRemote fetches a remote. If depth is greater than zero, fetched history is truncated to depth commits.
+func GitFetchRemote(logger *log.Logger, projectPath, remote string, depth int) error {
+	args := []string{"-C", projectPath, "fetch"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	args = append(args, remote)
+	return executeCommand(logger, "git", args...)
+}
+
+// GitFetchRefspec fetches a single refspec from a remote, e.g. a branch, tag or commit hash that is not
+// included in the history of a shallow or single-branch clone. If depth is greater than zero, fetched history
+// is truncated to depth commits. Output is discarded as fetching may be expected to fail.
+func GitFetchRefspec(projectPath, remote, refspec string, depth int) error {
+	args := []string{"-C", projectPath, "fetch"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	args = append(args, remote, refspec)
+	return executeCommandSilent("git", args...)
 }
 
-func GitCheckoutRef(projectPath, reference string) error {
-	return executeCommand("git", "-C", projectPath, "checkout", reference)
+func GitCheckoutRef(logger *log.Logger, projectPath, reference string) error {
+	return executeCommand(logger, "git", "-C", projectPath, "checkout", reference)
 }
 
-func GitCheckoutBranch(projectPath, branchName, remote string) error {
-	return executeCommand("git", "-C", projectPath, "checkout", "-b", branchName, "--track", fmt.Sprintf("%s/%s", remote, branchName))
+func GitCheckoutBranch(logger *log.Logger, projectPath, branchName, remote string) error {
+	return executeCommand(logger, "git", "-C", projectPath, "checkout", "-b", branchName, "--track", fmt.Sprintf("%s/%s", remote, branchName))
 }
 
-func GitCheckoutBranchLocal(projectPath, branchName string) error {
-	return executeCommand("git", "-C", projectPath, "checkout", branchName)
+func GitCheckoutBranchLocal(logger *log.Logger, projectPath, branchName string) error {
+	return executeCommand(logger, "git", "-C", projectPath, "checkout", branchName)
 }
 
-func GitSetTrackingRemoteBranch(projectPath, branchName, remote string) error {
-	return executeCommand("git", "-C", projectPath, "branch", "--set-upstream-to", fmt.Sprintf("%s/%s", remote, branchName), branchName)
+func GitSetTrackingRemoteBranch(logger *log.Logger, projectPath, branchName, remote string) error {
+	return executeCommand(logger, "git", "-C", projectPath, "branch", "--set-upstream-to", fmt.Sprintf("%s/%s", remote, branchName), branchName)
 }
 
 // GitResolveReference determines if the provided revision is a (local) branch, tag, or hash for use when preparing a
@@ -99,14 +160,42 @@ func GitResolveReference(projectPath, remote, revision string) (GitRefType, erro
 	return GitRefUnknown, nil
 }
 
-func GitInitSubmodules(projectPath string) error {
-	return executeCommand("git", "-C", projectPath, "submodule", "update", "--init", "--recursive")
+func GitInitSubmodules(logger *log.Logger, projectPath string) error {
+	return executeCommand(logger, "git", "-C", projectPath, "submodule", "update", "--init", "--recursive")
+}
+
+// GitUsesLFS returns whether any .gitattributes file in the commit checked out in projectPath assigns the Git LFS
+// filter to files. Files are read from the commit rather than the working tree so that files excluded by sparse
+// checkout are also considered.
+func GitUsesLFS(projectPath string) (bool, error) {
+	err := executeCommandSilent("git", "-C", projectPath, "grep", "-q", "-e", "filter=lfs", "HEAD", "--", ":(glob)**/.gitattributes")
+	if err == nil {
+		return true, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		// git grep exits with status 1 when there are no matches
+		return false, nil
+	}
+	return false, err
+}
+
+// GitLFSInstall configures the Git LFS filters and hooks in the repository at projectPath
+func GitLFSInstall(logger *log.Logger, projectPath string) error {
+	return executeCommand(logger, "git", "-C", projectPath, "lfs", "install", "--local")
+}
+
+// GitLFSPull fetches the Git LFS objects required by the checked out commit from a remote and replaces pointer
+// files in the working tree with their content
+func GitLFSPull(logger *log.Logger, projectPath, remote string) error {
+	return executeCommand(logger, "git", "-C", projectPath, "lfs", "pull", remote)
 }
 
-func executeCommand(name string, args ...string) error {
+// executeCommand runs a command, writing its output to logger
+func executeCommand(logger *log.Logger, name string, args ...string) error {
 	cmd := exec.Command(name, args...)
-	cmd.Stderr = log.Writer()
-	cmd.Stdout = log.Writer()
+	cmd.Stderr = logger.Writer()
+	cmd.Stdout = logger.Writer()
 	return cmd.Run()
 }
 
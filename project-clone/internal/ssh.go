@@ -0,0 +1,163 @@
+//
+// Copyright (c) 2019-2025 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	projectslib "github.com/devfile/devworkspace-operator/pkg/library/projects"
+)
+
+const (
+	gitSSHCommandEnvVar     = "GIT_SSH_COMMAND"
+	sshAskPassEnvVar        = "SSH_ASKPASS"
+	sshAskPassRequireEnvVar = "SSH_ASKPASS_REQUIRE"
+	sshKeyFileName          = "id_project_clone"
+	sshKnownHostsFileName   = "known_hosts"
+	sshAskPassFileName      = "askpass.sh"
+)
+
+// SSHTmpDir is the temporary directory containing the SSH key and configuration used for cloning projects over SSH.
+// It is empty if no SSH key is mounted to the container.
+var SSHTmpDir string
+
+// setupSSH configures git to use the SSH key mounted from the SSH secret, if present, for cloning projects
+// over SSH. The key is copied into a temporary directory readable only by the current user, as ssh refuses
+// to use private keys with looser permissions. If the key is protected by a passphrase, it is provided to ssh
+// via SSH_ASKPASS. Host key verification is configured according to the PROJECT_CLONE_SSH_HOST_KEY_POLICY and
+// PROJECT_CLONE_SSH_KNOWN_HOSTS environment variables.
+func setupSSH() error {
+	keyPath := path.Join(projectslib.ProjectCloneSSHKeyMountPath, dwconstants.SSHSecretPrivateKeyKey)
+	privateKey, err := os.ReadFile(keyPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// No SSH key mounted; use default ssh configuration
+			return nil
+		}
+		return fmt.Errorf("failed to read SSH key: %w", err)
+	}
+	passphrase, err := readOptionalFile(path.Join(projectslib.ProjectCloneSSHKeyMountPath, dwconstants.SSHSecretPassphraseKey))
+	if err != nil {
+		return fmt.Errorf("failed to read SSH key passphrase: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "project-clone-ssh-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory for SSH configuration: %w", err)
+	}
+	SSHTmpDir = tmpDir
+
+	tmpKeyPath := path.Join(tmpDir, sshKeyFileName)
+	if err := os.WriteFile(tmpKeyPath, privateKey, 0600); err != nil {
+		return fmt.Errorf("failed to copy SSH key: %w", err)
+	}
+
+	knownHosts := getKnownHosts()
+	knownHostsPath := path.Join(tmpDir, sshKnownHostsFileName)
+	if err := os.WriteFile(knownHostsPath, []byte(knownHosts), 0600); err != nil {
+		return fmt.Errorf("failed to write known_hosts file: %w", err)
+	}
+
+	if _, isSet := os.LookupEnv(gitSSHCommandEnvVar); isSet {
+		log.Printf("Environment variable %s is set; not configuring SSH key for git", gitSSHCommandEnvVar)
+		return nil
+	}
+	sshCommand := fmt.Sprintf("ssh -i %s -o IdentitiesOnly=yes -o UserKnownHostsFile=%s -o StrictHostKeyChecking=%s",
+		tmpKeyPath, knownHostsPath, getStrictHostKeyChecking(knownHosts != ""))
+	if err := os.Setenv(gitSSHCommandEnvVar, sshCommand); err != nil {
+		return err
+	}
+
+	if len(strings.TrimSpace(string(passphrase))) > 0 {
+		askPassPath := path.Join(tmpDir, sshAskPassFileName)
+		passphrasePath := path.Join(projectslib.ProjectCloneSSHKeyMountPath, dwconstants.SSHSecretPassphraseKey)
+		askPassScript := fmt.Sprintf("#!/bin/sh\ncat '%s'\n", passphrasePath)
+		if err := os.WriteFile(askPassPath, []byte(askPassScript), 0700); err != nil {
+			return fmt.Errorf("failed to write SSH askpass script: %w", err)
+		}
+		if err := os.Setenv(sshAskPassEnvVar, askPassPath); err != nil {
+			return err
+		}
+		if err := os.Setenv(sshAskPassRequireEnvVar, "force"); err != nil {
+			return err
+		}
+	}
+	log.Printf("Using SSH key from secret %s for SSH remotes", dwconstants.SSHSecretName)
+	return nil
+}
+
+// getKnownHosts returns the known_hosts entries from the SSH secret and the PROJECT_CLONE_SSH_KNOWN_HOSTS
+// environment variable
+func getKnownHosts() string {
+	var knownHosts []string
+	secretKnownHosts, err := readOptionalFile(path.Join(projectslib.ProjectCloneSSHKeyMountPath, dwconstants.SSHSecretKnownHostsKey))
+	if err != nil {
+		log.Printf("Failed to read known_hosts from secret %s: %s", dwconstants.SSHSecretName, err)
+	} else if len(secretKnownHosts) > 0 {
+		knownHosts = append(knownHosts, strings.TrimSpace(string(secretKnownHosts)))
+	}
+	if envKnownHosts := os.Getenv(dwconstants.ProjectCloneSSHKnownHosts); envKnownHosts != "" {
+		knownHosts = append(knownHosts, strings.TrimSpace(envKnownHosts))
+	}
+	if len(knownHosts) == 0 {
+		return ""
+	}
+	return strings.Join(knownHosts, "\n") + "\n"
+}
+
+// getStrictHostKeyChecking returns the value of the ssh StrictHostKeyChecking option corresponding to the
+// PROJECT_CLONE_SSH_HOST_KEY_POLICY environment variable. If the policy is not set, host keys are verified strictly
+// when known_hosts entries are provided. Otherwise, host keys are not verified: as the known_hosts file is created
+// for every clone, accepting new host keys would not protect against a man-in-the-middle either.
+func getStrictHostKeyChecking(hasKnownHosts bool) string {
+	policy := v1alpha1.ProjectCloneSSHHostKeyPolicy(os.Getenv(dwconstants.ProjectCloneSSHHostKeyPolicy))
+	switch policy {
+	case v1alpha1.ProjectCloneSSHHostKeyStrict:
+		return "yes"
+	case v1alpha1.ProjectCloneSSHHostKeyAcceptNew:
+		return "accept-new"
+	case v1alpha1.ProjectCloneSSHHostKeyDisabled:
+		return "no"
+	case "":
+		// Use default below
+	default:
+		log.Printf("Invalid value for %s: %q, using default", dwconstants.ProjectCloneSSHHostKeyPolicy, policy)
+	}
+	if hasKnownHosts {
+		return "yes"
+	}
+	log.Printf("No known_hosts entries provided; host keys of SSH remotes are not verified")
+	return "no"
+}
+
+// readOptionalFile reads a file, returning nil if it does not exist
+func readOptionalFile(filePath string) ([]byte, error) {
+	contents, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return contents, nil
+}
@@ -80,6 +80,20 @@ func OpenRepo(repoPath string) (*git.Repository, error) {
 	return repo, nil
 }
 
+// GetCheckedOutCommit returns the hash of the commit checked out in the git repo at repoPath. Returns an empty
+// string if repoPath is not a git repo or the checked out commit cannot be determined.
+func GetCheckedOutCommit(repoPath string) string {
+	repo, err := OpenRepo(repoPath)
+	if err != nil || repo == nil {
+		return ""
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return ""
+	}
+	return head.Hash().String()
+}
+
 // DirExists returns true if the path at dir exists and is a directory. Returns an error if the path
 // exists in the filesystem but does not refer to a directory.
 func DirExists(dir string) (bool, error) {
@@ -35,8 +35,9 @@ const (
 	tmpDir = "/tmp/"
 )
 
-// SetupZipProject downloads and extracts a zip-type project to the corresponding clonePath.
-func SetupZipProject(project v1alpha2.Project, httpClient *http.Client) error {
+// SetupZipProject downloads and extracts a zip-type project to the corresponding clonePath, writing its output
+// to logger.
+func SetupZipProject(project v1alpha2.Project, httpClient *http.Client, logger *log.Logger) error {
 	if project.Zip == nil {
 		return fmt.Errorf("project has no 'zip' source")
 	}
@@ -45,25 +46,25 @@ func SetupZipProject(project v1alpha2.Project, httpClient *http.Client) error {
 	projectPath := path.Join(internal.ProjectsRoot, clonePath)
 	if exists, err := internal.DirExists(projectPath); exists {
 		// Assume project is already set up
-		log.Printf("Project '%s' is already configured", project.Name)
+		logger.Printf("Project '%s' is already configured", project.Name)
 		return nil
 	} else if err != nil {
 		return fmt.Errorf("failed to check path %s: %s", projectPath, err)
 	}
 
-	tmpProjectsPath := path.Join(internal.CloneTmpDir, clonePath)
+	tmpProjectsPath := path.Join(internal.ProjectTmpDir(&project), clonePath)
 	if err := os.MkdirAll(path.Dir(tmpProjectsPath), 0755); err != nil {
 		return fmt.Errorf("failed to create parent directories for temp path %s: %w", tmpProjectsPath, err)
 	}
 
-	zipFilePath := path.Join(tmpDir, fmt.Sprintf("%s.zip", clonePath))
-	log.Printf("Downloading project archive from %s", url)
+	zipFilePath := path.Join(tmpDir, fmt.Sprintf("%s.zip", project.Name))
+	logger.Printf("Downloading project archive from %s", url)
 	err := downloadZip(url, zipFilePath, httpClient)
 	if err != nil {
 		return fmt.Errorf("failed to download archive: %s", err)
 	}
 
-	log.Printf("Extracting project archive to %s", tmpProjectsPath)
+	logger.Printf("Extracting project archive to %s", tmpProjectsPath)
 	err = unzip(zipFilePath, tmpProjectsPath)
 	if err != nil {
 		return fmt.Errorf("failed to extract project zip archive: %s", err)
@@ -73,12 +74,12 @@ func SetupZipProject(project v1alpha2.Project, httpClient *http.Client) error {
 	if err := os.MkdirAll(path.Dir(projectPath), 0755); err != nil {
 		return fmt.Errorf("failed to create parent directories for project path %s: %w", projectPath, err)
 	}
-	log.Printf("Moving extracted project archive to %s", projectPath)
+	logger.Printf("Moving extracted project archive to %s", projectPath)
 	if err := os.Rename(tmpProjectsPath, projectPath); err != nil {
 		return fmt.Errorf("failed to move unzipped project to PROJECTS_ROOT: %w", err)
 	}
 
-	err = dropTopLevelFolder(projectPath)
+	err = dropTopLevelFolder(projectPath, logger)
 	if err != nil {
 		return fmt.Errorf("failed to process extracted project archive: %s", err)
 	}
@@ -190,7 +191,7 @@ func unzip(archivePath string, destPath string) error {
 //
 // and removes directory /projects/my-project/my-project-master/
 // If the specified path contains additional files or directories, no changes are made.
-func dropTopLevelFolder(projectPath string) error {
+func dropTopLevelFolder(projectPath string, logger *log.Logger) error {
 	files, err := os.ReadDir(projectPath)
 	if err != nil {
 		return err
@@ -204,7 +205,7 @@ func dropTopLevelFolder(projectPath string) error {
 		return nil
 	}
 	topLevelPath := path.Join(projectPath, topLevelFolder.Name())
-	log.Printf("Moving files from %s to %s", topLevelPath, projectPath)
+	logger.Printf("Moving files from %s to %s", topLevelPath, projectPath)
 	topLevelContents, err := os.ReadDir(topLevelPath)
 	if err != nil {
 		return err
@@ -16,6 +16,7 @@
 package main
 
 import (
+	"bytes"
 	"crypto/tls"
 	"io"
 	"log"
@@ -23,8 +24,12 @@ import (
 	"os"
 	"os/signal"
 	"path"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	projectslib "github.com/devfile/devworkspace-operator/pkg/library/projects"
 	"github.com/devfile/devworkspace-operator/project-clone/internal"
 	"github.com/devfile/devworkspace-operator/project-clone/internal/bootstrap"
@@ -32,6 +37,7 @@ import (
 	"github.com/devfile/devworkspace-operator/project-clone/internal/zip"
 	gitclient "github.com/go-git/go-git/v5/plumbing/transport/client"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
@@ -52,16 +58,15 @@ func main() {
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-sigs
-		if err := os.RemoveAll(internal.CloneTmpDir); err != nil {
-			log.Printf("Encountered error cleaning up temporary directory: %s", err)
-		}
+		cleanupTmpDirs()
 		os.Exit(0)
 	}()
-	defer os.RemoveAll(internal.CloneTmpDir)
+	defer cleanupTmpDirs()
 
 	workspace, err := internal.ReadFlattenedDevWorkspace()
 	if err != nil {
 		log.Printf("Failed to read current DevWorkspace: %s", err)
+		cleanupTmpDirs()
 		os.Exit(1)
 	}
 
@@ -95,27 +100,45 @@ func main() {
 		gitclient.InstallProtocol("https", githttp.NewClient(httpClient))
 	}
 
-	encounteredError := false
-	for _, project := range projects {
-		log.Printf("Processing project %s", project.Name)
-		var err error
-		switch {
-		case project.Git != nil:
-			err = git.SetupGitProject(project)
-		case project.Zip != nil:
-			err = zip.SetupZipProject(project, httpClient)
-		default:
-			log.Printf("Project does not specify Git or Zip source")
-			copyLogFileToProjectsRoot()
-			os.Exit(0)
+	// Projects following a project that specifies neither a Git nor a Zip source are not set up
+	var skippedProjects []dw.Project
+	for idx, project := range projects {
+		if project.Git == nil && project.Zip == nil {
+			skippedProjects = projects[idx:]
+			projects = projects[:idx]
+			break
 		}
-		if err != nil {
-			log.Printf("Encountered error while setting up project %s: %s", project.Name, err)
+	}
+
+	results := setupProjects(projects, httpClient)
+	encounteredError := false
+	for _, result := range results {
+		if result.State == projectslib.ProjectCloneFailed {
 			encounteredError = true
 		}
 	}
+	if len(skippedProjects) > 0 {
+		log.Printf("Project %s does not specify Git or Zip source", skippedProjects[0].Name)
+		results = append(results, projectslib.ProjectCloneResult{
+			Name:  skippedProjects[0].Name,
+			State: projectslib.ProjectCloneFailed,
+			Error: "project does not specify Git or Zip source",
+		})
+		for _, project := range skippedProjects[1:] {
+			results = append(results, projectslib.ProjectCloneResult{
+				Name:  project.Name,
+				State: projectslib.ProjectCloneSkipped,
+			})
+		}
+		writeTerminationMessage(results)
+		copyLogFileToProjectsRoot()
+		cleanupTmpDirs()
+		os.Exit(0)
+	}
+	writeTerminationMessage(results)
 	if encounteredError {
 		copyLogFileToProjectsRoot()
+		cleanupTmpDirs()
 		os.Exit(0)
 	}
 
@@ -131,6 +154,123 @@ func main() {
 	}
 }
 
+// setupProjects sets up projects, running up to internal.CloneParallelism setups concurrently. Projects whose
+// clone paths overlap with an earlier project's clone path are only set up once that project is done, preserving
+// the result of setting up projects in order. When projects are set up concurrently, the output for each project
+// is buffered and written to the log once that project is done to avoid interleaving output between projects.
+// Returns the result of setting up each project, in the same order as projects.
+func setupProjects(projects []dw.Project, httpClient *http.Client) []projectslib.ProjectCloneResult {
+	done := make([]chan struct{}, len(projects))
+	for idx := range projects {
+		done[idx] = make(chan struct{})
+	}
+	results := make([]projectslib.ProjectCloneResult, len(projects))
+	semaphore := make(chan struct{}, internal.CloneParallelism)
+
+	var wg sync.WaitGroup
+	for idx := range projects {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[idx])
+			for _, dependency := range getOverlappingProjects(projects, idx) {
+				<-done[dependency]
+			}
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			results[idx] = setupProject(projects[idx], httpClient)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if result.State == projectslib.ProjectCloneFailed {
+			log.Printf("Encountered error while setting up project %s: %s", result.Name, result.Error)
+		}
+	}
+	return results
+}
+
+// setupProject sets up a single project. If projects are set up concurrently, output is written to a separate
+// buffer that is flushed to the log once the project is set up.
+func setupProject(project dw.Project, httpClient *http.Client) projectslib.ProjectCloneResult {
+	logger := log.Default()
+	if internal.CloneParallelism > 1 {
+		buf := &bytes.Buffer{}
+		logger = log.New(buf, "", log.Flags())
+		defer func() {
+			if _, err := log.Writer().Write(buf.Bytes()); err != nil {
+				log.Printf("Failed to write output for project %s: %s", project.Name, err)
+			}
+		}()
+	}
+
+	logger.Printf("Processing project %s", project.Name)
+	start := time.Now()
+	var err error
+	var gitResult git.SetupResult
+	if project.Git != nil {
+		gitResult, err = git.SetupGitProject(project, logger)
+	} else {
+		err = zip.SetupZipProject(project, httpClient, logger)
+	}
+	result := projectslib.ProjectCloneResult{
+		Name:     project.Name,
+		State:    projectslib.ProjectCloneSucceeded,
+		Duration: time.Since(start).Round(time.Millisecond).String(),
+		GitCache: gitResult.GitCache,
+		Warnings: gitResult.Warnings,
+	}
+	if err != nil {
+		result.State = projectslib.ProjectCloneFailed
+		result.Error = err.Error()
+	} else if project.Git != nil {
+		result.Commit = internal.GetCheckedOutCommit(path.Join(internal.ProjectsRoot, projectslib.GetClonePath(&project)))
+	}
+	return result
+}
+
+// writeTerminationMessage writes the result of setting up each project to the container's termination message,
+// allowing the DevWorkspace Operator to report failures on the DevWorkspace.
+func writeTerminationMessage(results []projectslib.ProjectCloneResult) {
+	message, err := projectslib.EncodeProjectCloneResults(&projectslib.ProjectCloneResults{Projects: results})
+	if err != nil {
+		log.Printf("Failed to encode project clone results: %s", err)
+		return
+	}
+	if err := os.WriteFile(corev1.TerminationMessagePathDefault, message, 0644); err != nil {
+		log.Printf("Failed to write project clone results to %s: %s", corev1.TerminationMessagePathDefault, err)
+	}
+}
+
+// getOverlappingProjects returns the indices of projects before projects[idx] whose clone path is equal to,
+// contains, or is contained in the clone path of projects[idx].
+func getOverlappingProjects(projects []dw.Project, idx int) []int {
+	var overlapping []int
+	clonePath := path.Clean(projectslib.GetClonePath(&projects[idx]))
+	for otherIdx := 0; otherIdx < idx; otherIdx++ {
+		otherClonePath := path.Clean(projectslib.GetClonePath(&projects[otherIdx]))
+		if clonePath == otherClonePath ||
+			strings.HasPrefix(clonePath, otherClonePath+"/") ||
+			strings.HasPrefix(otherClonePath, clonePath+"/") {
+			overlapping = append(overlapping, otherIdx)
+		}
+	}
+	return overlapping
+}
+
+// cleanupTmpDirs removes temporary directories used for setting up projects
+func cleanupTmpDirs() {
+	for _, tmpDir := range []string{internal.CloneTmpDir, internal.SSHTmpDir} {
+		if tmpDir == "" {
+			continue
+		}
+		if err := os.RemoveAll(tmpDir); err != nil {
+			log.Printf("Encountered error cleaning up temporary directory: %s", err)
+		}
+	}
+}
+
 // copyLogFileToProjectsRoot copies the predefined log file into a persistent directory ($PROJECTS_ROOT)
 // so that issues in setting up a devfile's projects are persisted beyond workspace restarts. Note that
 // not all output from the project clone container is propagated to the log file. For example, the progress
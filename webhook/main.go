@@ -38,10 +38,12 @@ import (
 	"github.com/devfile/devworkspace-operator/webhook/server"
 	"github.com/devfile/devworkspace-operator/webhook/workspace"
 
+	corev1 "k8s.io/api/core/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	clientconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -118,6 +120,12 @@ func main() {
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: ":6789",
 		NewCache:               cacheFunc,
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				// Namespaces are read only to validate storage profiles; avoid caching all namespaces on the cluster
+				DisableFor: []client.Object{&corev1.Namespace{}},
+			},
+		},
 	})
 	if err != nil {
 		log.Error(err, "Failed to create manager")
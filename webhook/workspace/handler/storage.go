@@ -0,0 +1,58 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	dwv2 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/devfile/devworkspace-operator/pkg/constants"
+	nsconfig "github.com/devfile/devworkspace-operator/pkg/provision/config"
+)
+
+// validateStorageProfile checks that the storage profile selected by a DevWorkspace, if any, is allowed in the
+// DevWorkspace's namespace. On update, the check is only performed if the selected storage profile changed, so
+// that existing DevWorkspaces are not blocked if the namespace's allowed storage profiles are modified.
+func (h *WebhookHandler) validateStorageProfile(ctx context.Context, newWksp, oldWksp *dwv2.DevWorkspace) error {
+	if !newWksp.Spec.Template.Attributes.Exists(constants.StorageProfileAttribute) {
+		return nil
+	}
+	var attrErr error
+	profileName := newWksp.Spec.Template.Attributes.GetString(constants.StorageProfileAttribute, &attrErr)
+	if attrErr != nil {
+		return fmt.Errorf("failed to parse %s attribute as a string", constants.StorageProfileAttribute)
+	}
+	if profileName == "" {
+		return fmt.Errorf("attribute %s must not be empty", constants.StorageProfileAttribute)
+	}
+	if oldWksp != nil && oldWksp.Spec.Template.Attributes.GetString(constants.StorageProfileAttribute, nil) == profileName {
+		return nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := h.Client.Get(ctx, types.NamespacedName{Name: newWksp.Namespace}, namespace); err != nil {
+		return fmt.Errorf("failed to read namespace %s to validate storage profile: %w", newWksp.Namespace, err)
+	}
+	if !nsconfig.IsStorageProfileAllowed(namespace, profileName) {
+		return fmt.Errorf("storage profile %s is not allowed in namespace %s. Allowed storage profiles: %s",
+			profileName, newWksp.Namespace, namespace.Annotations[constants.NamespaceAllowedStorageProfilesAnnotation])
+	}
+	return nil
+}